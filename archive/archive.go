@@ -0,0 +1,129 @@
+// Package archive extracts zip archives to disk with limits on the number
+// of files and the total extracted size.
+package archive
+
+import (
+	"archive/zip"
+	"bytes"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// Limits bounds what Extract writes; zero values mean no limit
+type Limits struct {
+	MaxFiles int
+	MaxBytes int64
+}
+
+// File describes an extracted file
+type File struct {
+	Path string `json:"path"`
+	Size int64  `json:"size"`
+}
+
+// Extract unpacks a zip archive into dir, creating it if needed. Entries
+// that would land outside dir are rejected. The size limit is enforced on
+// the bytes actually written, not on the sizes the archive claims.
+func Extract(data []byte, dir string, limits Limits) ([]File, error) {
+	reader, err := zip.NewReader(bytes.NewReader(data), int64(len(data)))
+	if err != nil {
+		return nil, fmt.Errorf("failed to open archive: %w", err)
+	}
+
+	root, err := filepath.Abs(dir)
+	if err != nil {
+		return nil, fmt.Errorf("failed to resolve directory: %w", err)
+	}
+	if err := os.MkdirAll(root, 0755); err != nil {
+		return nil, fmt.Errorf("failed to create directory: %w", err)
+	}
+
+	var files []File
+	var total int64
+	for _, entry := range reader.File {
+		target, err := entryPath(root, entry.Name)
+		if err != nil {
+			return files, err
+		}
+
+		if entry.FileInfo().IsDir() {
+			if err := os.MkdirAll(target, 0755); err != nil {
+				return files, fmt.Errorf("failed to create directory: %w", err)
+			}
+			continue
+		}
+		if !entry.Mode().IsRegular() {
+			return files, fmt.Errorf("%s: only regular files can be extracted", entry.Name)
+		}
+
+		if limits.MaxFiles > 0 && len(files) >= limits.MaxFiles {
+			return files, fmt.Errorf("archive has more than %d files", limits.MaxFiles)
+		}
+
+		remaining := int64(-1)
+		if limits.MaxBytes > 0 {
+			remaining = limits.MaxBytes - total
+		}
+		size, err := extractFile(entry, target, remaining)
+		if err != nil {
+			return files, err
+		}
+
+		total += size
+		files = append(files, File{Path: target, Size: size})
+	}
+
+	return files, nil
+}
+
+// entryPath returns where an entry is extracted, rejecting absolute paths
+// and paths that escape root
+func entryPath(root, name string) (string, error) {
+	if filepath.IsAbs(name) || strings.HasPrefix(name, "/") {
+		return "", fmt.Errorf("%s: absolute paths are not allowed", name)
+	}
+	target := filepath.Join(root, filepath.FromSlash(name))
+	if target != root && !strings.HasPrefix(target, root+string(filepath.Separator)) {
+		return "", fmt.Errorf("%s: path escapes the target directory", name)
+	}
+	return target, nil
+}
+
+// extractFile writes an entry to target, failing once more than remaining
+// bytes have been written (remaining < 0 means no limit)
+func extractFile(entry *zip.File, target string, remaining int64) (int64, error) {
+	if err := os.MkdirAll(filepath.Dir(target), 0755); err != nil {
+		return 0, fmt.Errorf("failed to create directory: %w", err)
+	}
+
+	src, err := entry.Open()
+	if err != nil {
+		return 0, fmt.Errorf("failed to open %s: %w", entry.Name, err)
+	}
+	defer src.Close()
+
+	dst, err := os.OpenFile(target, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, 0644)
+	if err != nil {
+		return 0, fmt.Errorf("failed to create %s: %w", target, err)
+	}
+	defer dst.Close()
+
+	var reader io.Reader = src
+	if remaining >= 0 {
+		reader = io.LimitReader(src, remaining+1)
+	}
+	size, err := io.Copy(dst, reader)
+	if err != nil {
+		return size, fmt.Errorf("failed to extract %s: %w", entry.Name, err)
+	}
+	if remaining >= 0 && size > remaining {
+		dst.Close()
+		os.Remove(target)
+		return 0, fmt.Errorf("extracted files exceed the size limit")
+	}
+
+	return size, nil
+}
@@ -0,0 +1,93 @@
+package archive
+
+import (
+	"archive/zip"
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// buildZip creates a zip archive from entry names and contents
+func buildZip(t *testing.T, entries map[string]string) []byte {
+	t.Helper()
+
+	var buf bytes.Buffer
+	w := zip.NewWriter(&buf)
+	for name, content := range entries {
+		f, err := w.Create(name)
+		if err != nil {
+			t.Fatalf("Failed to create %s: %v", name, err)
+		}
+		if _, err := f.Write([]byte(content)); err != nil {
+			t.Fatalf("Failed to write %s: %v", name, err)
+		}
+	}
+	if err := w.Close(); err != nil {
+		t.Fatalf("Failed to close archive: %v", err)
+	}
+	return buf.Bytes()
+}
+
+func TestExtract(t *testing.T) {
+	dir := t.TempDir()
+	data := buildZip(t, map[string]string{
+		"report.txt":          "ok\n",
+		"coverage/index.html": "<html></html>",
+	})
+
+	files, err := Extract(data, filepath.Join(dir, "out"), Limits{MaxFiles: 10, MaxBytes: 1024})
+	if err != nil {
+		t.Fatalf("Extract failed: %v", err)
+	}
+	if len(files) != 2 {
+		t.Fatalf("Expected 2 files, got %+v", files)
+	}
+
+	content, err := os.ReadFile(filepath.Join(dir, "out", "coverage", "index.html"))
+	if err != nil || string(content) != "<html></html>" {
+		t.Errorf("Unexpected extracted content %q: %v", content, err)
+	}
+}
+
+func TestExtract_Limits(t *testing.T) {
+	tests := []struct {
+		name    string
+		entries map[string]string
+		limits  Limits
+		wantErr string
+	}{
+		{
+			name:    "too many files",
+			entries: map[string]string{"a": "1", "b": "2", "c": "3"},
+			limits:  Limits{MaxFiles: 2},
+			wantErr: "more than 2 files",
+		},
+		{
+			name:    "too large",
+			entries: map[string]string{"big": strings.Repeat("x", 2048)},
+			limits:  Limits{MaxBytes: 1024},
+			wantErr: "size limit",
+		},
+		{
+			name:    "path traversal",
+			entries: map[string]string{"../escape.txt": "x"},
+			wantErr: "escapes the target directory",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dir := t.TempDir()
+			_, err := Extract(buildZip(t, tt.entries), dir, tt.limits)
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("Expected error containing %q, got %v", tt.wantErr, err)
+			}
+		})
+	}
+
+	if _, err := os.Stat(filepath.Join(filepath.Dir(t.TempDir()), "escape.txt")); err == nil {
+		t.Error("Path traversal entry was written outside the directory")
+	}
+}
@@ -0,0 +1,271 @@
+package auth
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+
+	"github-mcp-server-go/protocol"
+)
+
+// defaultHost is the GitHub host used by accounts without an explicit host
+const defaultHost = "github.com"
+
+// accountNamePattern matches the names accounts may be stored under
+var accountNamePattern = regexp.MustCompile(`^[A-Za-z0-9._-]+$`)
+
+// ValidateAccountName reports an error for account names that cannot be
+// used as a token file name, so an account cannot point outside the store
+func ValidateAccountName(account string) error {
+	if !accountNamePattern.MatchString(account) || account == "." || account == ".." {
+		return fmt.Errorf("invalid account name %q: use letters, digits, '.', '_' and '-'", account)
+	}
+	return nil
+}
+
+// accountContextKey is the context key for a per-call account override
+type accountContextKey struct{}
+
+// WithAccount returns a context that makes requests use the named account
+// instead of the active one
+func WithAccount(ctx context.Context, account string) context.Context {
+	return context.WithValue(ctx, accountContextKey{}, account)
+}
+
+// AccountFromContext returns the account override carried by the context
+func AccountFromContext(ctx context.Context) (string, bool) {
+	account, ok := ctx.Value(accountContextKey{}).(string)
+	return account, ok && account != ""
+}
+
+// ListAccounts lists the stored accounts
+func (t *Tool) ListAccounts(ctx context.Context, args map[string]interface{}) (*protocol.CallToolResult, error) {
+	tokens, err := t.store.ListTokens()
+	if err != nil {
+		return nil, fmt.Errorf("failed to list accounts: %w", err)
+	}
+
+	if len(tokens) == 0 {
+		return &protocol.CallToolResult{
+			Content: []protocol.Content{
+				protocol.TextContent("No accounts stored"),
+			},
+		}, nil
+	}
+
+	sort.Slice(tokens, func(i, j int) bool {
+		return tokens[i].ID < tokens[j].ID
+	})
+
+	active := t.activeAccount()
+	lines := make([]string, 0, len(tokens))
+	for _, token := range tokens {
+		marker := " "
+		if token.ID == active {
+			marker = "*"
+		}
+
+		line := fmt.Sprintf("%s %s: %s on %s (scopes: %v)", marker, token.ID, token.Login, hostName(token.Host), token.Scope)
+		if !token.ExpiresAt.IsZero() {
+			line += fmt.Sprintf(", expires %s", token.ExpiresAt.Format(time.RFC3339))
+		}
+		lines = append(lines, line)
+	}
+
+	return &protocol.CallToolResult{
+		Content: []protocol.Content{
+			protocol.TextContent(strings.Join(lines, "\n")),
+		},
+	}, nil
+}
+
+// SwitchAccount makes a stored account the active one
+func (t *Tool) SwitchAccount(ctx context.Context, args map[string]interface{}) (*protocol.CallToolResult, error) {
+	account, ok := args["account"].(string)
+	if !ok || account == "" {
+		return nil, fmt.Errorf("account is required and must be a string")
+	}
+	if err := ValidateAccountName(account); err != nil {
+		return nil, err
+	}
+
+	token, err := t.store.GetToken(account)
+	if err != nil {
+		if isNotFoundError(err) {
+			return &protocol.CallToolResult{
+				Content: []protocol.Content{
+					protocol.ErrorContent(fmt.Sprintf("Account %s not found; login with auth_login_token or auth_login_device first", account)),
+				},
+			}, nil
+		}
+		return nil, fmt.Errorf("failed to get account: %w", err)
+	}
+
+	if err := t.setActiveAccount(account); err != nil {
+		return nil, err
+	}
+
+	return &protocol.CallToolResult{
+		Content: []protocol.Content{
+			protocol.TextContent(fmt.Sprintf("Switched to account %s (%s on %s)", account, token.Login, hostName(token.Host))),
+		},
+	}, nil
+}
+
+// Token returns the access token of the account selected for the request,
+// or an empty string when the active account is not logged in. It
+// implements github.TokenProvider.
+func (t *Tool) Token(ctx context.Context) (string, error) {
+	account, explicit, err := t.requestAccount(ctx)
+	if err != nil {
+		return "", err
+	}
+
+	token, err := t.store.GetToken(account)
+	if err != nil {
+		if isNotFoundError(err) && !explicit {
+			return "", nil
+		}
+		if isNotFoundError(err) {
+			return "", fmt.Errorf("account %s is not logged in", account)
+		}
+		return "", fmt.Errorf("failed to get stored token: %w", err)
+	}
+
+	return token.AccessToken, nil
+}
+
+// BaseURL returns the API base URL for the host of the account selected for
+// the request, or an empty string for the client default. It implements
+// github.BaseURLProvider.
+func (t *Tool) BaseURL(ctx context.Context) (string, error) {
+	account, _, err := t.requestAccount(ctx)
+	if err != nil {
+		return "", err
+	}
+
+	token, err := t.store.GetToken(account)
+	if err != nil {
+		if isNotFoundError(err) {
+			return "", nil
+		}
+		return "", fmt.Errorf("failed to get stored token: %w", err)
+	}
+
+	return hostAPIBaseURL(token.Host), nil
+}
+
+// requestAccount returns the account for a request and whether it was
+// explicitly requested
+func (t *Tool) requestAccount(ctx context.Context) (string, bool, error) {
+	if account, ok := AccountFromContext(ctx); ok {
+		return account, true, ValidateAccountName(account)
+	}
+	return t.activeAccount(), false, nil
+}
+
+// accountArg returns the account named by a tool's arguments, falling back
+// to the request's account
+func (t *Tool) accountArg(ctx context.Context, args map[string]interface{}) (string, error) {
+	if account, ok := args["account"].(string); ok && account != "" {
+		return account, ValidateAccountName(account)
+	}
+	account, _, err := t.requestAccount(ctx)
+	return account, err
+}
+
+// activeAccount returns the currently selected account
+func (t *Tool) activeAccount() string {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return t.active
+}
+
+// setActiveAccount selects an account and persists the choice
+func (t *Tool) setActiveAccount(account string) error {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if err := os.WriteFile(filepath.Join(t.storagePath, activeAccountFile), []byte(account), 0600); err != nil {
+		return fmt.Errorf("failed to save active account: %w", err)
+	}
+	t.active = account
+
+	return nil
+}
+
+// loadActiveAccount restores the selected account
+func (t *Tool) loadActiveAccount() error {
+	data, err := os.ReadFile(filepath.Join(t.storagePath, activeAccountFile))
+	if err != nil {
+		if os.IsNotExist(err) {
+			t.active = defaultAccount
+			return nil
+		}
+		return fmt.Errorf("failed to read active account: %w", err)
+	}
+
+	t.active = strings.TrimSpace(string(data))
+	if t.active == "" || ValidateAccountName(t.active) != nil {
+		t.active = defaultAccount
+	}
+
+	return nil
+}
+
+// migrateLegacyToken moves a token stored before accounts existed to the
+// default account
+func (t *Tool) migrateLegacyToken() error {
+	token, err := t.store.GetToken(legacyTokenID)
+	if err != nil {
+		if isNotFoundError(err) {
+			return nil
+		}
+		return fmt.Errorf("failed to read legacy token: %w", err)
+	}
+
+	if _, err := t.store.GetToken(defaultAccount); err == nil {
+		return nil
+	}
+
+	token.ID = defaultAccount
+	if err := t.store.StoreToken(token); err != nil {
+		return fmt.Errorf("failed to migrate legacy token: %w", err)
+	}
+	if err := t.store.DeleteToken(legacyTokenID); err != nil {
+		return fmt.Errorf("failed to remove legacy token: %w", err)
+	}
+
+	return nil
+}
+
+// hostName returns the display name of an account's host
+func hostName(host string) string {
+	if host == "" {
+		return defaultHost
+	}
+	return host
+}
+
+// hostAPIBaseURL returns the REST API base URL for a host, or an empty
+// string for github.com
+func hostAPIBaseURL(host string) string {
+	if host == "" || host == defaultHost {
+		return ""
+	}
+	return "https://" + host + "/api/v3/"
+}
+
+// hostOAuthBaseURL returns the OAuth base URL for a host, or an empty
+// string for github.com
+func hostOAuthBaseURL(host string) string {
+	if host == "" || host == defaultHost {
+		return ""
+	}
+	return "https://" + host + "/"
+}
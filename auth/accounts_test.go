@@ -0,0 +1,191 @@
+package auth
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github-mcp-server-go/protocol"
+	"github-mcp-server-go/storage"
+)
+
+func TestSwitchAccount(t *testing.T) {
+	tool, cleanup := setupTestTool(t)
+	defer cleanup()
+
+	ctx := context.Background()
+	if _, err := tool.LoginWithToken(ctx, map[string]interface{}{"token": "ghp_personal", "account": "personal"}); err != nil {
+		t.Fatalf("Failed to login personal account: %v", err)
+	}
+	if _, err := tool.LoginWithToken(ctx, map[string]interface{}{"token": "ghp_work", "account": "work"}); err != nil {
+		t.Fatalf("Failed to login work account: %v", err)
+	}
+
+	// The most recent login becomes active
+	if token, _ := tool.Token(ctx); token != "ghp_work" {
+		t.Errorf("Expected work token to be active, got %q", token)
+	}
+
+	result, err := tool.SwitchAccount(ctx, map[string]interface{}{"account": "personal"})
+	if err != nil {
+		t.Fatalf("SwitchAccount() error = %v", err)
+	}
+	if result.Content[0].Error {
+		t.Fatalf("Unexpected error content: %q", result.Content[0].Text)
+	}
+	if token, _ := tool.Token(ctx); token != "ghp_personal" {
+		t.Errorf("Expected personal token after switch, got %q", token)
+	}
+
+	// Unknown accounts are rejected without changing the active account
+	result, err = tool.SwitchAccount(ctx, map[string]interface{}{"account": "missing"})
+	if err != nil {
+		t.Fatalf("SwitchAccount() error = %v", err)
+	}
+	if !result.Content[0].Error {
+		t.Errorf("Expected error content for unknown account, got %q", result.Content[0].Text)
+	}
+	if tool.activeAccount() != "personal" {
+		t.Errorf("Expected personal to stay active, got %q", tool.activeAccount())
+	}
+
+	// The active account survives a restart
+	reopened, err := NewToolWithOptions(tool.storagePath, tool.opts)
+	if err != nil {
+		t.Fatalf("Failed to reopen auth tool: %v", err)
+	}
+	if token, _ := reopened.Token(ctx); token != "ghp_personal" {
+		t.Errorf("Expected personal token after restart, got %q", token)
+	}
+
+	// Listing marks the active account
+	result, err = tool.ListAccounts(ctx, map[string]interface{}{})
+	if err != nil {
+		t.Fatalf("ListAccounts() error = %v", err)
+	}
+	text := result.Content[0].Text
+	if !strings.Contains(text, "* personal: octocat on github.com") || !strings.Contains(text, "  work: octocat") {
+		t.Errorf("Unexpected account list: %q", text)
+	}
+}
+
+func TestToken_AccountOverride(t *testing.T) {
+	tool, cleanup := setupTestTool(t)
+	defer cleanup()
+
+	ctx := context.Background()
+	if _, err := tool.LoginWithToken(ctx, map[string]interface{}{"token": "ghp_bot", "account": "bot"}); err != nil {
+		t.Fatalf("Failed to login bot account: %v", err)
+	}
+	if _, err := tool.LoginWithToken(ctx, map[string]interface{}{"token": "ghp_main", "account": "main"}); err != nil {
+		t.Fatalf("Failed to login main account: %v", err)
+	}
+
+	if token, _ := tool.Token(WithAccount(ctx, "bot")); token != "ghp_bot" {
+		t.Errorf("Expected bot token for overridden call, got %q", token)
+	}
+	if token, _ := tool.Token(ctx); token != "ghp_main" {
+		t.Errorf("Expected override not to change the active account, got %q", token)
+	}
+
+	// Naming an account that is not logged in is an error rather than a
+	// silent fallback to other credentials
+	if _, err := tool.Token(WithAccount(ctx, "missing")); err == nil {
+		t.Error("Expected error for an unknown account override")
+	}
+}
+
+func TestBaseURL(t *testing.T) {
+	tool, cleanup := setupTestTool(t)
+	defer cleanup()
+
+	ctx := context.Background()
+	if err := tool.store.StoreToken(&storage.Token{ID: "enterprise", Host: "ghe.example.com", AccessToken: "ghp_ghe"}); err != nil {
+		t.Fatalf("Failed to store token: %v", err)
+	}
+	if err := tool.store.StoreToken(&storage.Token{ID: defaultAccount, AccessToken: "ghp_dotcom"}); err != nil {
+		t.Fatalf("Failed to store token: %v", err)
+	}
+
+	if url, _ := tool.BaseURL(WithAccount(ctx, "enterprise")); url != "https://ghe.example.com/api/v3/" {
+		t.Errorf("Expected enterprise API URL, got %q", url)
+	}
+	if url, _ := tool.BaseURL(ctx); url != "" {
+		t.Errorf("Expected default API URL for github.com, got %q", url)
+	}
+}
+
+func TestMigrateLegacyToken(t *testing.T) {
+	tmpDir, err := os.MkdirTemp("", "auth-accounts-test-*")
+	if err != nil {
+		t.Fatalf("Failed to create temp directory: %v", err)
+	}
+	defer os.RemoveAll(tmpDir)
+
+	store, err := storage.NewFileSystemStore(filepath.Join(tmpDir, "auth"))
+	if err != nil {
+		t.Fatalf("Failed to create token store: %v", err)
+	}
+	if err := store.StoreToken(&storage.Token{ID: legacyTokenID, AccessToken: "ghp_legacy"}); err != nil {
+		t.Fatalf("Failed to store legacy token: %v", err)
+	}
+
+	tool, err := NewTool(tmpDir)
+	if err != nil {
+		t.Fatalf("Failed to create auth tool: %v", err)
+	}
+
+	if token, _ := tool.Token(context.Background()); token != "ghp_legacy" {
+		t.Errorf("Expected legacy token in the default account, got %q", token)
+	}
+	if _, err := store.GetToken(legacyTokenID); err == nil {
+		t.Error("Expected legacy token to be removed after migration")
+	}
+}
+
+func TestValidateAccountName(t *testing.T) {
+	for _, name := range []string{"default", "work-2", "bot_account", "me.example"} {
+		if err := ValidateAccountName(name); err != nil {
+			t.Errorf("Expected %q to be valid, got %v", name, err)
+		}
+	}
+	for _, name := range []string{"", ".", "..", "../escape", "a/b", `a\b`, "/etc/passwd", "name with space", "tab\t"} {
+		if err := ValidateAccountName(name); err == nil {
+			t.Errorf("Expected %q to be rejected", name)
+		}
+	}
+}
+
+func TestInvalidAccountRejected(t *testing.T) {
+	tool, cleanup := setupTestTool(t)
+	defer cleanup()
+
+	ctx := context.Background()
+	args := map[string]interface{}{"token": "ghp_escape", "account": "../escape"}
+	if _, err := tool.LoginWithToken(ctx, args); err == nil {
+		t.Error("Expected LoginWithToken to reject a path in the account name")
+	}
+	if _, err := os.Stat(filepath.Join(tool.storagePath, "escape.json")); !os.IsNotExist(err) {
+		t.Errorf("Expected no token file outside the store, got %v", err)
+	}
+
+	for name, call := range map[string]func(context.Context, map[string]interface{}) (*protocol.CallToolResult, error){
+		"LoginWithDevice": tool.LoginWithDevice,
+		"Logout":          tool.Logout,
+		"GetAuthStatus":   tool.GetAuthStatus,
+		"SwitchAccount":   tool.SwitchAccount,
+	} {
+		if _, err := call(ctx, map[string]interface{}{"account": "..", "client_id": "test-client"}); err == nil {
+			t.Errorf("Expected %s to reject account ..", name)
+		}
+	}
+
+	if _, err := tool.Token(WithAccount(ctx, "../escape")); err == nil {
+		t.Error("Expected Token to reject an invalid account override")
+	}
+	if _, err := tool.BaseURL(WithAccount(ctx, "a/b")); err == nil {
+		t.Error("Expected BaseURL to reject an invalid account override")
+	}
+}
@@ -0,0 +1,212 @@
+package auth
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github-mcp-server-go/storage"
+)
+
+// gitTokenUsername is the username git sends with a token that has no
+// recorded login
+const gitTokenUsername = "x-access-token"
+
+// accountNameUnsafe matches the characters not allowed in account names
+var accountNameUnsafe = regexp.MustCompile(`[^A-Za-z0-9._-]`)
+
+// credentialRequest is a credential description in the git credential
+// helper protocol
+type credentialRequest struct {
+	Protocol string
+	Host     string
+	Username string
+	Password string
+}
+
+// ServeCredential implements the git credential helper protocol for the
+// get, store and erase operations. The credential description is read from
+// in and, for get, the matching stored token is written to out.
+func (t *Tool) ServeCredential(operation string, in io.Reader, out io.Writer) error {
+	req, err := readCredentialRequest(in)
+	if err != nil {
+		return err
+	}
+
+	// Only HTTPS credentials for a host are served
+	if req.Host == "" || (req.Protocol != "" && req.Protocol != "https") {
+		return nil
+	}
+
+	switch operation {
+	case "get":
+		return t.getCredential(req, out)
+	case "store":
+		return t.storeCredential(req)
+	case "erase":
+		return t.eraseCredential(req)
+	default:
+		// Unknown operations must be ignored by helpers
+		return nil
+	}
+}
+
+// getCredential writes the token stored for the requested host
+func (t *Tool) getCredential(req *credentialRequest, out io.Writer) error {
+	token, err := t.credentialToken(req)
+	if err != nil || token == nil {
+		return err
+	}
+
+	username := token.Login
+	if username == "" {
+		username = gitTokenUsername
+	}
+
+	_, err = fmt.Fprintf(out, "username=%s\npassword=%s\n", username, token.AccessToken)
+	return err
+}
+
+// storeCredential saves a credential git reports as working, unless it is
+// already stored for the host
+func (t *Tool) storeCredential(req *credentialRequest) error {
+	if req.Password == "" {
+		return nil
+	}
+
+	token, err := t.credentialToken(req)
+	if err != nil {
+		return err
+	}
+	if token != nil && token.AccessToken == req.Password {
+		return nil
+	}
+
+	if token == nil {
+		account := credentialAccount(req)
+		if err := ValidateAccountName(account); err != nil {
+			return err
+		}
+		token = &storage.Token{
+			ID:        account,
+			TokenType: "bearer",
+		}
+		if !strings.EqualFold(req.Host, defaultHost) {
+			token.Host = req.Host
+		}
+		if req.Username != gitTokenUsername {
+			token.Login = req.Username
+		}
+	}
+	token.AccessToken = req.Password
+
+	if err := t.store.StoreToken(token); err != nil {
+		return fmt.Errorf("failed to store credential: %w", err)
+	}
+
+	return nil
+}
+
+// eraseCredential removes a stored token that git reports as rejected
+func (t *Tool) eraseCredential(req *credentialRequest) error {
+	token, err := t.credentialToken(req)
+	if err != nil || token == nil {
+		return err
+	}
+
+	// Keep the token if it has been replaced since git read it
+	if req.Password != "" && token.AccessToken != req.Password {
+		return nil
+	}
+
+	if err := t.store.DeleteToken(token.ID); err != nil && !isNotFoundError(err) {
+		return fmt.Errorf("failed to erase credential: %w", err)
+	}
+
+	return nil
+}
+
+// credentialToken finds the stored token for the requested host and
+// username, preferring the active account, or nil if there is none
+func (t *Tool) credentialToken(req *credentialRequest) (*storage.Token, error) {
+	tokens, err := t.store.ListTokens()
+	if err != nil {
+		return nil, fmt.Errorf("failed to list tokens: %w", err)
+	}
+
+	var matches []*storage.Token
+	for _, token := range tokens {
+		if !strings.EqualFold(hostName(token.Host), req.Host) {
+			continue
+		}
+		if req.Username != "" && req.Username != gitTokenUsername && !strings.EqualFold(token.Login, req.Username) {
+			continue
+		}
+		matches = append(matches, token)
+	}
+	if len(matches) == 0 {
+		return nil, nil
+	}
+
+	active := t.activeAccount()
+	sort.Slice(matches, func(i, j int) bool {
+		if (matches[i].ID == active) != (matches[j].ID == active) {
+			return matches[i].ID == active
+		}
+		if (matches[i].ID == defaultAccount) != (matches[j].ID == defaultAccount) {
+			return matches[i].ID == defaultAccount
+		}
+		return matches[i].ID < matches[j].ID
+	})
+
+	return matches[0], nil
+}
+
+// credentialAccount names the account for a credential stored by git.
+// Characters git allows in usernames and hosts but account names do not
+// are replaced with underscores.
+func credentialAccount(req *credentialRequest) string {
+	host := accountNameUnsafe.ReplaceAllString(strings.ToLower(req.Host), "_")
+	if req.Username == "" || req.Username == gitTokenUsername {
+		return "git-" + host
+	}
+	username := accountNameUnsafe.ReplaceAllString(req.Username, "_")
+	return fmt.Sprintf("git-%s-%s", username, host)
+}
+
+// readCredentialRequest parses key=value lines up to a blank line or EOF
+func readCredentialRequest(in io.Reader) (*credentialRequest, error) {
+	req := &credentialRequest{}
+
+	scanner := bufio.NewScanner(in)
+	for scanner.Scan() {
+		line := strings.TrimRight(scanner.Text(), "\r")
+		if line == "" {
+			break
+		}
+
+		key, value, ok := strings.Cut(line, "=")
+		if !ok {
+			continue
+		}
+
+		switch key {
+		case "protocol":
+			req.Protocol = value
+		case "host":
+			req.Host = value
+		case "username":
+			req.Username = value
+		case "password":
+			req.Password = value
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read credential request: %w", err)
+	}
+
+	return req, nil
+}
@@ -0,0 +1,121 @@
+package auth
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github-mcp-server-go/storage"
+)
+
+func TestServeCredential(t *testing.T) {
+	tool, cleanup := setupTestTool(t)
+	defer cleanup()
+
+	for _, token := range []*storage.Token{
+		{ID: "personal", Login: "octocat", AccessToken: "ghp_personal"},
+		{ID: "work", Login: "octo-work", Host: "ghe.example.com", AccessToken: "ghp_work"},
+	} {
+		if err := tool.store.StoreToken(token); err != nil {
+			t.Fatalf("Failed to store token: %v", err)
+		}
+	}
+
+	tests := []struct {
+		name  string
+		input string
+		want  string
+	}{
+		{
+			name:  "github.com",
+			input: "protocol=https\nhost=github.com\n\n",
+			want:  "username=octocat\npassword=ghp_personal\n",
+		},
+		{
+			name:  "enterprise host",
+			input: "protocol=https\nhost=ghe.example.com\npath=org/repo.git\n",
+			want:  "username=octo-work\npassword=ghp_work\n",
+		},
+		{
+			name:  "unknown host",
+			input: "protocol=https\nhost=gitlab.com\n",
+			want:  "",
+		},
+		{
+			name:  "username mismatch",
+			input: "protocol=https\nhost=github.com\nusername=someone-else\n",
+			want:  "",
+		},
+		{
+			name:  "non-https protocol",
+			input: "protocol=http\nhost=github.com\n",
+			want:  "",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var out bytes.Buffer
+			if err := tool.ServeCredential("get", strings.NewReader(tt.input), &out); err != nil {
+				t.Fatalf("ServeCredential() error = %v", err)
+			}
+			if out.String() != tt.want {
+				t.Errorf("Expected %q, got %q", tt.want, out.String())
+			}
+		})
+	}
+}
+
+func TestServeCredential_StoreAndErase(t *testing.T) {
+	tool, cleanup := setupTestTool(t)
+	defer cleanup()
+
+	input := "protocol=https\nhost=ghe.example.com\nusername=hubot\npassword=ghp_hubot\n"
+	if err := tool.ServeCredential("store", strings.NewReader(input), &bytes.Buffer{}); err != nil {
+		t.Fatalf("store error = %v", err)
+	}
+
+	var out bytes.Buffer
+	if err := tool.ServeCredential("get", strings.NewReader("protocol=https\nhost=ghe.example.com\n"), &out); err != nil {
+		t.Fatalf("get error = %v", err)
+	}
+	if out.String() != "username=hubot\npassword=ghp_hubot\n" {
+		t.Errorf("Unexpected stored credential: %q", out.String())
+	}
+
+	// Erasing a stale password keeps the current token
+	stale := "protocol=https\nhost=ghe.example.com\npassword=ghp_old\n"
+	if err := tool.ServeCredential("erase", strings.NewReader(stale), &bytes.Buffer{}); err != nil {
+		t.Fatalf("erase error = %v", err)
+	}
+	tokens, _ := tool.store.ListTokens()
+	if len(tokens) != 1 {
+		t.Fatalf("Expected token to survive a stale erase, got %d tokens", len(tokens))
+	}
+
+	if err := tool.ServeCredential("erase", strings.NewReader(input), &bytes.Buffer{}); err != nil {
+		t.Fatalf("erase error = %v", err)
+	}
+	tokens, _ = tool.store.ListTokens()
+	if len(tokens) != 0 {
+		t.Errorf("Expected token to be erased, got %d tokens", len(tokens))
+	}
+}
+
+func TestServeCredential_HostileUsername(t *testing.T) {
+	tool, cleanup := setupTestTool(t)
+	defer cleanup()
+
+	input := "protocol=https\nhost=github.com\nusername=/../../../tmp/x\npassword=ghp_hostile\n"
+	if err := tool.ServeCredential("store", strings.NewReader(input), &bytes.Buffer{}); err != nil {
+		t.Fatalf("store error = %v", err)
+	}
+
+	tokens, _ := tool.store.ListTokens()
+	if len(tokens) != 1 || tokens[0].ID != "git-_.._.._.._tmp_x-github.com" {
+		t.Fatalf("Expected the username to be reduced to a plain account name, got %+v", tokens)
+	}
+	if err := ValidateAccountName(tokens[0].ID); err != nil {
+		t.Errorf("Expected a valid account name: %v", err)
+	}
+}
@@ -0,0 +1,343 @@
+package auth
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github-mcp-server-go/protocol"
+	"github-mcp-server-go/storage"
+)
+
+const (
+	// defaultOAuthBaseURL is the host serving GitHub's OAuth endpoints
+	defaultOAuthBaseURL = "https://github.com/"
+
+	// deviceGrantType is the OAuth grant type for device code exchange
+	deviceGrantType = "urn:ietf:params:oauth:grant-type:device_code"
+
+	// defaultPollInterval is used when the server does not specify one
+	defaultPollInterval = 5
+
+	// defaultDeviceCodeExpiry is used when the server does not say how long
+	// the device code is valid, in seconds
+	defaultDeviceCodeExpiry = 900
+
+	// slowDownIncrement is added to the interval when asked to slow down
+	slowDownIncrement = 5
+)
+
+// Device login states
+const (
+	deviceStatusPending  = "pending"
+	deviceStatusComplete = "complete"
+	deviceStatusFailed   = "failed"
+)
+
+// DeviceCode represents the response to a device authorization request
+type DeviceCode struct {
+	DeviceCode      string `json:"device_code"`
+	UserCode        string `json:"user_code"`
+	VerificationURI string `json:"verification_uri"`
+	ExpiresIn       int    `json:"expires_in"`
+	Interval        int    `json:"interval"`
+}
+
+// deviceTokenResponse represents a response from the OAuth token endpoint
+type deviceTokenResponse struct {
+	AccessToken      string `json:"access_token"`
+	TokenType        string `json:"token_type"`
+	Scope            string `json:"scope"`
+	RefreshToken     string `json:"refresh_token"`
+	ExpiresIn        int    `json:"expires_in"`
+	Error            string `json:"error"`
+	ErrorDescription string `json:"error_description"`
+	Interval         int    `json:"interval"`
+}
+
+// deviceLogin tracks an in-progress device authorization flow
+type deviceLogin struct {
+	code    *DeviceCode
+	account string
+	host    string
+	status  string
+	err     error
+	done    chan struct{}
+	cancel  context.CancelFunc
+}
+
+// LoginWithDevice starts the OAuth device authorization flow. It returns the
+// verification URL and user code, then polls for the token in the background.
+func (t *Tool) LoginWithDevice(ctx context.Context, args map[string]interface{}) (*protocol.CallToolResult, error) {
+	clientID := t.opts.OAuthClientID
+	if clientIDArg, ok := args["client_id"].(string); ok && clientIDArg != "" {
+		clientID = clientIDArg
+	}
+	if clientID == "" {
+		return nil, fmt.Errorf("an OAuth client ID is required (pass client_id or configure -oauth-client-id)")
+	}
+
+	scopes := parseScopes(args["scopes"])
+	if scopes == nil {
+		scopes = []string{"repo", "read:org"}
+	}
+
+	account, err := t.accountArg(ctx, args)
+	if err != nil {
+		return nil, err
+	}
+	host, _ := args["host"].(string)
+
+	code, err := t.requestDeviceCode(ctx, host, clientID, scopes)
+	if err != nil {
+		return &protocol.CallToolResult{
+			Content: []protocol.Content{
+				protocol.ErrorContent(fmt.Sprintf("Failed to start device login: %v", err)),
+			},
+		}, nil
+	}
+
+	// Poll in the background; the login outlives this tool call
+	pollCtx, cancel := context.WithTimeout(context.Background(), time.Duration(code.ExpiresIn)*time.Second)
+	login := &deviceLogin{
+		code:    code,
+		account: account,
+		host:    host,
+		status:  deviceStatusPending,
+		done:    make(chan struct{}),
+		cancel:  cancel,
+	}
+
+	t.mu.Lock()
+	if t.device != nil && t.device.status == deviceStatusPending {
+		t.device.cancel()
+	}
+	t.device = login
+	t.mu.Unlock()
+
+	go t.pollDeviceToken(pollCtx, clientID, login)
+
+	message := fmt.Sprintf("To authenticate, open %s and enter the code: %s\nThe code expires in %d minutes. Use auth_status to check whether login has completed.",
+		code.VerificationURI, code.UserCode, code.ExpiresIn/60)
+
+	// Optionally block until the user has finished authorizing
+	if wait, ok := args["wait"].(bool); ok && wait {
+		select {
+		case <-login.done:
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+
+		t.mu.Lock()
+		status, loginErr := login.status, login.err
+		t.mu.Unlock()
+		if status != deviceStatusComplete {
+			return &protocol.CallToolResult{
+				Content: []protocol.Content{
+					protocol.ErrorContent(fmt.Sprintf("Device login failed: %v", loginErr)),
+				},
+			}, nil
+		}
+		message = fmt.Sprintf("Successfully authenticated with %s via device login (account: %s)", hostName(host), account)
+	}
+
+	return &protocol.CallToolResult{
+		Content: []protocol.Content{
+			protocol.TextContent(message),
+		},
+	}, nil
+}
+
+// requestDeviceCode requests a device and user code for the client
+func (t *Tool) requestDeviceCode(ctx context.Context, host, clientID string, scopes []string) (*DeviceCode, error) {
+	form := url.Values{
+		"client_id": {clientID},
+		"scope":     {strings.Join(scopes, " ")},
+	}
+
+	var code DeviceCode
+	if err := t.postOAuthForm(ctx, host, "login/device/code", form, &code); err != nil {
+		return nil, err
+	}
+	if code.DeviceCode == "" || code.UserCode == "" {
+		return nil, fmt.Errorf("invalid device code response")
+	}
+	if code.Interval <= 0 {
+		code.Interval = defaultPollInterval
+	}
+	if code.ExpiresIn <= 0 {
+		code.ExpiresIn = defaultDeviceCodeExpiry
+	}
+
+	return &code, nil
+}
+
+// pollDeviceToken polls the token endpoint until the user authorizes the
+// device, the code expires, or the login is cancelled
+func (t *Tool) pollDeviceToken(ctx context.Context, clientID string, login *deviceLogin) {
+	defer login.cancel()
+
+	finish := func(status string, err error) {
+		t.mu.Lock()
+		login.status = status
+		login.err = err
+		t.mu.Unlock()
+		close(login.done)
+	}
+
+	form := url.Values{
+		"client_id":   {clientID},
+		"device_code": {login.code.DeviceCode},
+		"grant_type":  {deviceGrantType},
+	}
+
+	interval := login.code.Interval
+	for {
+		select {
+		case <-ctx.Done():
+			finish(deviceStatusFailed, fmt.Errorf("device code expired or login was cancelled"))
+			return
+		case <-time.After(time.Duration(interval) * t.pollUnit):
+		}
+
+		var resp deviceTokenResponse
+		if err := t.postOAuthForm(ctx, login.host, "login/oauth/access_token", form, &resp); err != nil {
+			finish(deviceStatusFailed, err)
+			return
+		}
+
+		switch resp.Error {
+		case "":
+			if err := t.storeDeviceToken(ctx, login, &resp); err != nil {
+				finish(deviceStatusFailed, err)
+				return
+			}
+			finish(deviceStatusComplete, nil)
+			return
+		case "authorization_pending":
+			continue
+		case "slow_down":
+			if resp.Interval > 0 {
+				interval = resp.Interval
+			} else {
+				interval += slowDownIncrement
+			}
+		default:
+			finish(deviceStatusFailed, fmt.Errorf("%s: %s", resp.Error, resp.ErrorDescription))
+			return
+		}
+	}
+}
+
+// storeDeviceToken saves a token obtained through the device flow under the
+// login's account and makes that account active
+func (t *Tool) storeDeviceToken(ctx context.Context, login *deviceLogin, resp *deviceTokenResponse) error {
+	if resp.AccessToken == "" {
+		return fmt.Errorf("token response did not include an access token")
+	}
+
+	token := &storage.Token{
+		ID:           login.account,
+		Host:         login.host,
+		AccessToken:  resp.AccessToken,
+		TokenType:    resp.TokenType,
+		RefreshToken: resp.RefreshToken,
+		Scope:        parseScopes(resp.Scope),
+	}
+	if resp.ExpiresIn > 0 {
+		token.ExpiresAt = time.Now().UTC().Add(time.Duration(resp.ExpiresIn) * time.Second)
+	}
+
+	// Record the login and authoritative scopes; the token response is
+	// enough to proceed if this fails
+	t.validateToken(ctx, token)
+
+	if err := t.store.StoreToken(token); err != nil {
+		return fmt.Errorf("failed to store token: %w", err)
+	}
+
+	return t.setActiveAccount(login.account)
+}
+
+// postOAuthForm posts a form to an OAuth endpoint of the host and decodes the
+// JSON response
+func (t *Tool) postOAuthForm(ctx context.Context, host, path string, form url.Values, dest interface{}) error {
+	baseURL := hostOAuthBaseURL(host)
+	if baseURL == "" {
+		baseURL = t.opts.OAuthBaseURL
+	}
+	if baseURL == "" {
+		baseURL = defaultOAuthBaseURL
+	}
+	if !strings.HasSuffix(baseURL, "/") {
+		baseURL += "/"
+	}
+
+	req, err := http.NewRequestWithContext(ctx, "POST", baseURL+path, strings.NewReader(form.Encode()))
+	if err != nil {
+		return fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req.Header.Set("Accept", "application/json")
+
+	resp, err := t.httpClient().Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to execute request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= 400 {
+		return fmt.Errorf("OAuth error: status code %d", resp.StatusCode)
+	}
+
+	if err := json.NewDecoder(resp.Body).Decode(dest); err != nil {
+		return fmt.Errorf("failed to decode response: %w", err)
+	}
+
+	return nil
+}
+
+// deviceStatus describes the most recent device login, if any
+func (t *Tool) deviceStatus() string {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if t.device == nil {
+		return ""
+	}
+
+	switch t.device.status {
+	case deviceStatusPending:
+		return fmt.Sprintf("Device login pending: open %s and enter the code %s", t.device.code.VerificationURI, t.device.code.UserCode)
+	case deviceStatusFailed:
+		return fmt.Sprintf("Device login failed: %v", t.device.err)
+	default:
+		return "Device login completed"
+	}
+}
+
+// parseScopes converts a scopes argument or a comma/space separated scope
+// string into a slice
+func parseScopes(value interface{}) []string {
+	switch v := value.(type) {
+	case []string:
+		return v
+	case []interface{}:
+		var scopes []string
+		for _, item := range v {
+			if str, ok := item.(string); ok && str != "" {
+				scopes = append(scopes, str)
+			}
+		}
+		return scopes
+	case string:
+		return strings.FieldsFunc(v, func(r rune) bool {
+			return r == ',' || r == ' '
+		})
+	}
+	return nil
+}
@@ -0,0 +1,170 @@
+package auth
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// newTestOAuthServer starts a stand-in for GitHub's OAuth device endpoints.
+// The token endpoint answers with the given responses in order.
+func newTestOAuthServer(t *testing.T, responses ...map[string]interface{}) *httptest.Server {
+	var polls int32
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if err := r.ParseForm(); err != nil {
+			t.Errorf("Failed to parse form: %v", err)
+		}
+		if r.Form.Get("client_id") != "test-client" {
+			t.Errorf("Expected client_id test-client, got %q", r.Form.Get("client_id"))
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Path {
+		case "/login/device/code":
+			json.NewEncoder(w).Encode(map[string]interface{}{
+				"device_code":      "device-123",
+				"user_code":        "ABCD-1234",
+				"verification_uri": "https://github.com/login/device",
+				"expires_in":       900,
+				"interval":         1,
+			})
+		case "/login/oauth/access_token":
+			if r.Form.Get("grant_type") != deviceGrantType {
+				t.Errorf("Unexpected grant_type %q", r.Form.Get("grant_type"))
+			}
+			n := int(atomic.AddInt32(&polls, 1)) - 1
+			if n >= len(responses) {
+				n = len(responses) - 1
+			}
+			json.NewEncoder(w).Encode(responses[n])
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+}
+
+func setupDeviceTool(t *testing.T, baseURL string) (*Tool, func()) {
+	tool, cleanup := setupTestTool(t)
+	tool.opts.OAuthClientID = "test-client"
+	tool.opts.OAuthBaseURL = baseURL
+	tool.pollUnit = time.Millisecond
+	return tool, cleanup
+}
+
+func TestLoginWithDevice(t *testing.T) {
+	srv := newTestOAuthServer(t,
+		map[string]interface{}{"error": "authorization_pending"},
+		map[string]interface{}{"error": "slow_down", "interval": 2},
+		map[string]interface{}{
+			"access_token":  "ghu_device",
+			"token_type":    "bearer",
+			"scope":         "repo,read:org",
+			"refresh_token": "ghr_refresh",
+			"expires_in":    28800,
+		},
+	)
+	defer srv.Close()
+
+	tool, cleanup := setupDeviceTool(t, srv.URL)
+	defer cleanup()
+
+	// Without waiting, the tool returns the code for the user to enter
+	result, err := tool.LoginWithDevice(context.Background(), map[string]interface{}{})
+	if err != nil {
+		t.Fatalf("LoginWithDevice() error = %v", err)
+	}
+	if !strings.Contains(result.Content[0].Text, "ABCD-1234") || !strings.Contains(result.Content[0].Text, "https://github.com/login/device") {
+		t.Errorf("Expected verification URL and user code, got %q", result.Content[0].Text)
+	}
+
+	// Wait for the background poll to complete
+	tool.mu.Lock()
+	done := tool.device.done
+	tool.mu.Unlock()
+	select {
+	case <-done:
+	case <-time.After(5 * time.Second):
+		t.Fatal("Timed out waiting for device login")
+	}
+
+	token, err := tool.GetToken()
+	if err != nil {
+		t.Fatalf("Failed to retrieve stored token: %v", err)
+	}
+	if token.AccessToken != "ghu_device" || token.RefreshToken != "ghr_refresh" {
+		t.Errorf("Unexpected stored token: %+v", token)
+	}
+	if token.Login != "octocat" {
+		t.Errorf("Expected login recorded from the API, got %q", token.Login)
+	}
+	if !token.ExpiresAt.Equal(testTokenExpiry) {
+		t.Errorf("Expected expiry reported by the API, got %v", token.ExpiresAt)
+	}
+
+	status, err := tool.GetAuthStatus(context.Background(), map[string]interface{}{})
+	if err != nil {
+		t.Fatalf("GetAuthStatus() error = %v", err)
+	}
+	if !strings.Contains(status.Content[0].Text, "completed") {
+		t.Errorf("Expected completed device login in status, got %q", status.Content[0].Text)
+	}
+}
+
+func TestLoginWithDevice_Denied(t *testing.T) {
+	srv := newTestOAuthServer(t,
+		map[string]interface{}{"error": "access_denied", "error_description": "The user has denied your application access."},
+	)
+	defer srv.Close()
+
+	tool, cleanup := setupDeviceTool(t, srv.URL)
+	defer cleanup()
+
+	result, err := tool.LoginWithDevice(context.Background(), map[string]interface{}{"wait": true})
+	if err != nil {
+		t.Fatalf("LoginWithDevice() error = %v", err)
+	}
+	if !result.Content[0].Error || !strings.Contains(result.Content[0].Text, "access_denied") {
+		t.Errorf("Expected access_denied error, got %+v", result.Content[0])
+	}
+
+	if _, err := tool.GetToken(); err == nil {
+		t.Error("Expected no token to be stored after denied login")
+	}
+}
+
+func TestLoginWithDevice_MissingClientID(t *testing.T) {
+	tool, cleanup := setupTestTool(t)
+	defer cleanup()
+
+	if _, err := tool.LoginWithDevice(context.Background(), map[string]interface{}{}); err == nil {
+		t.Error("Expected error without a client ID")
+	}
+}
+
+func TestRequestDeviceCode_DefaultExpiry(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"device_code":      "device-123",
+			"user_code":        "ABCD-1234",
+			"verification_uri": "https://github.com/login/device",
+		})
+	}))
+	defer srv.Close()
+
+	tool, cleanup := setupDeviceTool(t, srv.URL)
+	defer cleanup()
+
+	code, err := tool.requestDeviceCode(context.Background(), "", "test-client", []string{"repo"})
+	if err != nil {
+		t.Fatalf("requestDeviceCode() error = %v", err)
+	}
+	if code.ExpiresIn != defaultDeviceCodeExpiry || code.Interval != defaultPollInterval {
+		t.Errorf("Expected default expiry and interval, got %+v", code)
+	}
+}
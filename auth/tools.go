@@ -3,27 +3,99 @@ package auth
 import (
 	"context"
 	"fmt"
+	"net/http"
 	"path/filepath"
+	"strings"
+	"sync"
+	"time"
 
+	"github-mcp-server-go/github"
 	"github-mcp-server-go/protocol"
 	"github-mcp-server-go/storage"
 )
 
-// Tool represents the authentication tools handler
+const (
+	// defaultAccount is the account used when none has been selected
+	defaultAccount = "default"
+
+	// legacyTokenID is the ID the single token was stored under before
+	// accounts were introduced
+	legacyTokenID = "pat"
+
+	// activeAccountFile records the selected account in the storage path
+	activeAccountFile = "active_account"
+)
+
+// ToolOptions configures optional authentication behaviour
+type ToolOptions struct {
+	// OAuthClientID is the OAuth app client ID used for device login
+	OAuthClientID string
+
+	// OAuthBaseURL is the base URL of the OAuth endpoints (defaults to https://github.com/)
+	OAuthBaseURL string
+
+	// APIBaseURL is the base URL of the REST API used to validate tokens
+	// (defaults to https://api.github.com/)
+	APIBaseURL string
+
+	// HTTPClient is used for OAuth requests (defaults to http.DefaultClient)
+	HTTPClient *http.Client
+
+	// Store selects the token store backend (defaults to plaintext files)
+	Store storage.StoreConfig
+}
+
+// Tool represents the authentication tools handler. Each stored token is a
+// named account; one account is active at a time.
 type Tool struct {
-	store storage.SecureTokenStore
+	store       storage.SecureTokenStore
+	opts        ToolOptions
+	storagePath string
+
+	// pollUnit scales the device flow polling interval
+	pollUnit time.Duration
+
+	mu             sync.Mutex
+	active         string
+	device         *deviceLogin
+	requiredScopes []string
 }
 
 // NewTool creates a new authentication tool instance
 func NewTool(storagePath string) (*Tool, error) {
-	store, err := storage.NewFileSystemStore(filepath.Join(storagePath, "auth"))
+	return NewToolWithOptions(storagePath, ToolOptions{})
+}
+
+// NewToolWithOptions creates a new authentication tool instance with options
+func NewToolWithOptions(storagePath string, opts ToolOptions) (*Tool, error) {
+	store, err := storage.NewStore(filepath.Join(storagePath, "auth"), opts.Store)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create token store: %w", err)
 	}
 
-	return &Tool{
-		store: store,
-	}, nil
+	t := &Tool{
+		store:       store,
+		opts:        opts,
+		storagePath: storagePath,
+		pollUnit:    time.Second,
+	}
+
+	if err := t.loadActiveAccount(); err != nil {
+		return nil, err
+	}
+	if err := t.migrateLegacyToken(); err != nil {
+		return nil, err
+	}
+
+	return t, nil
+}
+
+// httpClient returns the HTTP client used for OAuth requests
+func (t *Tool) httpClient() *http.Client {
+	if t.opts.HTTPClient != nil {
+		return t.opts.HTTPClient
+	}
+	return http.DefaultClient
 }
 
 // LoginWithToken handles authentication using a personal access token
@@ -33,69 +105,127 @@ func (t *Tool) LoginWithToken(ctx context.Context, args map[string]interface{})
 		return nil, fmt.Errorf("token argument is required")
 	}
 
-	scopes, _ := args["scopes"].([]string)
-	if scopes == nil {
-		scopes = []string{"repo", "user"}
+	account, err := t.accountArg(ctx, args)
+	if err != nil {
+		return nil, err
 	}
+	host, _ := args["host"].(string)
 
 	token := &storage.Token{
-		ID:          "pat", // Use a fixed ID for PAT
+		ID:          account,
+		Host:        host,
 		AccessToken: tokenValue,
 		TokenType:   "bearer",
-		Scope:       scopes,
+	}
+
+	// Validate the token and record its real login, scopes and expiry
+	info, err := t.validateToken(ctx, token)
+	if err != nil {
+		return &protocol.CallToolResult{
+			Content: []protocol.Content{
+				protocol.ErrorContent(fmt.Sprintf("Failed to validate token: %v", err)),
+			},
+		}, nil
 	}
 
 	if err := t.store.StoreToken(token); err != nil {
 		return nil, fmt.Errorf("failed to store token: %w", err)
 	}
+	if err := t.setActiveAccount(account); err != nil {
+		return nil, err
+	}
+
+	lines := []string{fmt.Sprintf("Successfully authenticated as %s on %s with personal access token (account: %s, scopes: %v)", token.Login, hostName(token.Host), account, token.Scope)}
+	lines = append(lines, t.tokenWarnings(token, info)...)
 
 	return &protocol.CallToolResult{
 		Content: []protocol.Content{
-			protocol.TextContent(fmt.Sprintf("Successfully authenticated with personal access token (scopes: %v)", scopes)),
+			protocol.TextContent(strings.Join(lines, "\n")),
 		},
 	}, nil
 }
 
 // Logout handles user logout by removing stored tokens
 func (t *Tool) Logout(ctx context.Context, args map[string]interface{}) (*protocol.CallToolResult, error) {
-	if err := t.store.DeleteToken("pat"); err != nil && !isNotFoundError(err) {
+	account, err := t.accountArg(ctx, args)
+	if err != nil {
+		return nil, err
+	}
+	if err := t.store.DeleteToken(account); err != nil && !isNotFoundError(err) {
 		return nil, fmt.Errorf("failed to remove token: %w", err)
 	}
 
+	// Fall back to the default account when the active one is removed
+	if account == t.activeAccount() {
+		if err := t.setActiveAccount(defaultAccount); err != nil {
+			return nil, err
+		}
+	}
+
 	return &protocol.CallToolResult{
 		Content: []protocol.Content{
-			protocol.TextContent("Successfully logged out"),
+			protocol.TextContent(fmt.Sprintf("Successfully logged out of account %s", account)),
 		},
 	}, nil
 }
 
 // GetAuthStatus retrieves the current authentication status
 func (t *Tool) GetAuthStatus(ctx context.Context, args map[string]interface{}) (*protocol.CallToolResult, error) {
-	token, err := t.store.GetToken("pat")
+	content := []protocol.Content{}
+	if device := t.deviceStatus(); device != "" {
+		content = append(content, protocol.TextContent(device))
+	}
+
+	account, err := t.accountArg(ctx, args)
+	if err != nil {
+		return nil, err
+	}
+	token, err := t.store.GetToken(account)
 	if err != nil {
 		if isNotFoundError(err) {
+			content = append(content, protocol.TextContent(fmt.Sprintf("Not authenticated (account: %s)", account)))
 			return &protocol.CallToolResult{
-				Content: []protocol.Content{
-					protocol.TextContent("Not authenticated"),
-				},
+				Content: content,
 			}, nil
 		}
 		return nil, fmt.Errorf("failed to get token status: %w", err)
 	}
 
+	// Re-validate the token so the status reflects its current state
+	info, err := t.validateToken(ctx, token)
+	switch {
+	case github.IsStatus(err, http.StatusUnauthorized):
+		content = append(content, protocol.ErrorContent(fmt.Sprintf("Stored token for %s is invalid, expired or revoked; please login again", token.Login)))
+		return &protocol.CallToolResult{
+			Content: content,
+		}, nil
+	case err == nil:
+		if err := t.store.StoreToken(token); err != nil {
+			return nil, fmt.Errorf("failed to update token: %w", err)
+		}
+	}
+
+	lines := []string{fmt.Sprintf("Authenticated as %s on %s with %s token (account: %s, scopes: %v)", token.Login, hostName(token.Host), token.TokenType, account, token.Scope)}
+	if !token.ExpiresAt.IsZero() {
+		lines = append(lines, fmt.Sprintf("Token expires at %s", token.ExpiresAt.Format(time.RFC3339)))
+	}
+	if err != nil {
+		lines = append(lines, fmt.Sprintf("Warning: could not validate token: %v", err))
+	}
+	lines = append(lines, t.tokenWarnings(token, info)...)
+
+	content = append(content, protocol.TextContent(strings.Join(lines, "\n")))
 	return &protocol.CallToolResult{
-		Content: []protocol.Content{
-			protocol.TextContent(fmt.Sprintf("Authenticated with %s token (scopes: %v)", token.TokenType, token.Scope)),
-		},
+		Content: content,
 	}, nil
 }
 
-// GetToken retrieves the current authentication token
+// GetToken retrieves the token of the active account
 func (t *Tool) GetToken() (*storage.Token, error) {
-	return t.store.GetToken("pat")
+	return t.store.GetToken(t.activeAccount())
 }
 
 // isNotFoundError checks if an error is a "not found" error
 func isNotFoundError(err error) bool {
-	return err != nil && err.Error() == "token not found: pat"
+	return err != nil && strings.HasPrefix(err.Error(), "token not found: ")
 }
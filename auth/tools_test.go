@@ -2,10 +2,38 @@ package auth
 
 import (
 	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
 	"os"
+	"strings"
 	"testing"
+	"time"
 )
 
+// testTokenExpiry is the expiry reported by the stand-in API for test tokens
+var testTokenExpiry = time.Now().Add(72 * time.Hour).UTC().Truncate(time.Second)
+
+// newTestAPIServer starts a stand-in for GET /user. Every token except
+// ghp_invalid is accepted and reported with the repo and user scopes.
+func newTestAPIServer() *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/user" {
+			http.NotFound(w, r)
+			return
+		}
+		if r.Header.Get("Authorization") == "token ghp_invalid" {
+			w.WriteHeader(http.StatusUnauthorized)
+			fmt.Fprint(w, `{"message": "Bad credentials"}`)
+			return
+		}
+		w.Header().Set("X-OAuth-Scopes", "repo, user")
+		w.Header().Set("X-Accepted-OAuth-Scopes", "admin:enterprise")
+		w.Header().Set("GitHub-Authentication-Token-Expiration", testTokenExpiry.Format("2006-01-02 15:04:05 MST"))
+		fmt.Fprint(w, `{"login": "octocat", "id": 1}`)
+	}))
+}
+
 func setupTestTool(t *testing.T) (*Tool, func()) {
 	// Create temporary directory for test
 	tmpDir, err := os.MkdirTemp("", "auth-tools-test-*")
@@ -13,15 +41,20 @@ func setupTestTool(t *testing.T) (*Tool, func()) {
 		t.Fatalf("Failed to create temp directory: %v", err)
 	}
 
+	// Start a stand-in API server for token validation
+	api := newTestAPIServer()
+
 	// Create tool instance
-	tool, err := NewTool(tmpDir)
+	tool, err := NewToolWithOptions(tmpDir, ToolOptions{APIBaseURL: api.URL})
 	if err != nil {
+		api.Close()
 		os.RemoveAll(tmpDir)
 		t.Fatalf("Failed to create auth tool: %v", err)
 	}
 
 	// Return cleanup function
 	cleanup := func() {
+		api.Close()
 		os.RemoveAll(tmpDir)
 	}
 
@@ -164,3 +197,106 @@ func TestGetAuthStatus(t *testing.T) {
 		t.Error("Expected non-empty content in result")
 	}
 }
+
+func TestToken(t *testing.T) {
+	tool, cleanup := setupTestTool(t)
+	defer cleanup()
+
+	// No token before login
+	token, err := tool.Token(context.Background())
+	if err != nil {
+		t.Fatalf("Token() error = %v", err)
+	}
+	if token != "" {
+		t.Errorf("Expected empty token before login, got %q", token)
+	}
+
+	// Login makes the token available immediately
+	if _, err := tool.LoginWithToken(context.Background(), map[string]interface{}{"token": "ghp_test123"}); err != nil {
+		t.Fatalf("Failed to login for test setup: %v", err)
+	}
+	if token, _ = tool.Token(context.Background()); token != "ghp_test123" {
+		t.Errorf("Expected ghp_test123 after login, got %q", token)
+	}
+
+	// Logout removes it again
+	if _, err := tool.Logout(context.Background(), map[string]interface{}{}); err != nil {
+		t.Fatalf("Logout() error = %v", err)
+	}
+	if token, _ = tool.Token(context.Background()); token != "" {
+		t.Errorf("Expected empty token after logout, got %q", token)
+	}
+}
+
+func TestLoginWithToken_Validation(t *testing.T) {
+	tool, cleanup := setupTestTool(t)
+	defer cleanup()
+	tool.SetRequiredScopes([]string{"repo", "public_repo", "workflow"})
+
+	// Invalid tokens are rejected and not stored
+	result, err := tool.LoginWithToken(context.Background(), map[string]interface{}{"token": "ghp_invalid"})
+	if err != nil {
+		t.Fatalf("LoginWithToken() error = %v", err)
+	}
+	if !result.Content[0].Error {
+		t.Errorf("Expected error content for invalid token, got %q", result.Content[0].Text)
+	}
+	if _, err := tool.GetToken(); err == nil {
+		t.Error("Expected invalid token not to be stored")
+	}
+
+	// Valid tokens record the real login, scopes and expiry
+	result, err = tool.LoginWithToken(context.Background(), map[string]interface{}{"token": "ghp_test123"})
+	if err != nil {
+		t.Fatalf("LoginWithToken() error = %v", err)
+	}
+
+	token, err := tool.GetToken()
+	if err != nil {
+		t.Fatalf("Failed to retrieve stored token: %v", err)
+	}
+	if token.Login != "octocat" {
+		t.Errorf("Expected login octocat, got %q", token.Login)
+	}
+	if len(token.Scope) != 2 || token.Scope[0] != "repo" || token.Scope[1] != "user" {
+		t.Errorf("Expected scopes [repo user], got %v", token.Scope)
+	}
+	if !token.ExpiresAt.Equal(testTokenExpiry) {
+		t.Errorf("Expected expiry %v, got %v", testTokenExpiry, token.ExpiresAt)
+	}
+
+	// The token expires within the warning window and lacks the workflow scope
+	text := result.Content[0].Text
+	if !strings.Contains(text, "expires in") {
+		t.Errorf("Expected expiry warning, got %q", text)
+	}
+	if !strings.Contains(text, "missing scopes needed by registered tools: workflow") {
+		t.Errorf("Expected missing workflow scope warning, got %q", text)
+	}
+	// Scopes the endpoint accepts are alternatives, not requirements
+	if strings.Contains(text, "admin:enterprise") {
+		t.Errorf("Expected accepted scopes not to be required, got %q", text)
+	}
+}
+
+func TestMissingScopes(t *testing.T) {
+	tests := []struct {
+		name     string
+		granted  []string
+		required []string
+		want     []string
+	}{
+		{name: "all granted", granted: []string{"repo", "gist"}, required: []string{"repo", "gist"}, want: nil},
+		{name: "implied by broader scope", granted: []string{"admin:org"}, required: []string{"read:org"}, want: nil},
+		{name: "missing", granted: []string{"public_repo"}, required: []string{"repo", "workflow"}, want: []string{"repo", "workflow"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := missingScopes(tt.granted, tt.required)
+			if strings.Join(got, ",") != strings.Join(tt.want, ",") {
+				t.Errorf("missingScopes() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,134 @@
+package auth
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github-mcp-server-go/github"
+	"github-mcp-server-go/storage"
+)
+
+// tokenExpiryWarningWindow is how close to expiry a token triggers a warning
+const tokenExpiryWarningWindow = 7 * 24 * time.Hour
+
+// impliedScopes lists the classic OAuth scopes implicitly granted by a scope
+var impliedScopes = map[string][]string{
+	"repo":             {"repo:status", "repo_deployment", "public_repo", "repo:invite", "security_events"},
+	"admin:org":        {"write:org", "read:org", "manage_runners:org"},
+	"write:org":        {"read:org"},
+	"admin:public_key": {"write:public_key", "read:public_key"},
+	"write:public_key": {"read:public_key"},
+	"admin:repo_hook":  {"write:repo_hook", "read:repo_hook"},
+	"write:repo_hook":  {"read:repo_hook"},
+	"user":             {"read:user", "user:email", "user:follow"},
+	"write:packages":   {"read:packages"},
+	"admin:gpg_key":    {"write:gpg_key", "read:gpg_key"},
+	"write:gpg_key":    {"read:gpg_key"},
+	"project":          {"read:project"},
+	"write:discussion": {"read:discussion"},
+}
+
+// SetRequiredScopes sets the OAuth scopes needed by the registered tools
+func (t *Tool) SetRequiredScopes(scopes []string) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.requiredScopes = scopes
+}
+
+// validateToken calls GET /user with the token and records the real login,
+// scopes and expiry on it
+func (t *Tool) validateToken(ctx context.Context, token *storage.Token) (*github.TokenInfo, error) {
+	client := github.NewClient(token.AccessToken)
+	if baseURL := hostAPIBaseURL(token.Host); baseURL != "" {
+		client.SetBaseURL(baseURL)
+	} else if t.opts.APIBaseURL != "" {
+		client.SetBaseURL(t.opts.APIBaseURL)
+	}
+
+	user, info, err := client.GetAuthenticatedUser(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	token.Login = user.Login
+	if info.ScopesReported {
+		token.Scope = info.Scopes
+	}
+	if !info.ExpiresAt.IsZero() {
+		token.ExpiresAt = info.ExpiresAt
+	}
+
+	return info, nil
+}
+
+// tokenWarnings reports tokens that are expired, close to expiring, or
+// missing scopes needed by the registered tools
+func (t *Tool) tokenWarnings(token *storage.Token, info *github.TokenInfo) []string {
+	var warnings []string
+
+	if !token.ExpiresAt.IsZero() {
+		remaining := time.Until(token.ExpiresAt)
+		switch {
+		case remaining <= 0:
+			warnings = append(warnings, fmt.Sprintf("Warning: token expired at %s", token.ExpiresAt.Format(time.RFC3339)))
+		case remaining < tokenExpiryWarningWindow:
+			warnings = append(warnings, fmt.Sprintf("Warning: token expires in %s (at %s)", formatDuration(remaining), token.ExpiresAt.Format(time.RFC3339)))
+		}
+	}
+
+	if info == nil {
+		return warnings
+	}
+	if !info.ScopesReported {
+		warnings = append(warnings, "Note: scopes are not reported for fine-grained or app tokens; tool permissions could not be verified")
+		return warnings
+	}
+
+	t.mu.Lock()
+	required := append([]string{}, t.requiredScopes...)
+	t.mu.Unlock()
+
+	if missing := missingScopes(token.Scope, required); len(missing) > 0 {
+		warnings = append(warnings, fmt.Sprintf("Warning: token is missing scopes needed by registered tools: %s", strings.Join(missing, ", ")))
+	}
+
+	return warnings
+}
+
+// missingScopes returns the required scopes that are not granted, directly
+// or implied by a broader scope
+func missingScopes(granted, required []string) []string {
+	have := make(map[string]bool)
+	for _, scope := range granted {
+		have[scope] = true
+		for _, implied := range impliedScopes[scope] {
+			have[implied] = true
+		}
+	}
+
+	seen := make(map[string]bool)
+	var missing []string
+	for _, scope := range required {
+		if !have[scope] && !seen[scope] {
+			missing = append(missing, scope)
+			seen[scope] = true
+		}
+	}
+	sort.Strings(missing)
+
+	return missing
+}
+
+// formatDuration formats a duration in days or hours
+func formatDuration(d time.Duration) string {
+	if d >= 48*time.Hour {
+		return fmt.Sprintf("%d days", int(d.Hours()/24))
+	}
+	if d >= 2*time.Hour {
+		return fmt.Sprintf("%d hours", int(d.Hours()))
+	}
+	return fmt.Sprintf("%d minutes", int(d.Minutes()))
+}
@@ -0,0 +1,49 @@
+// github-mcp-server-go/credential.go
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github-mcp-server-go/auth"
+	"github-mcp-server-go/storage"
+)
+
+// runCredentialHelper implements `github-mcp-server credential <operation>`,
+// a git credential helper serving the tokens stored by the login tools:
+//
+//	git config --global credential.https://github.com.helper "/path/to/github-mcp-server credential -config-dir /path/to/data"
+func runCredentialHelper(args []string) int {
+	fs := flag.NewFlagSet("credential", flag.ContinueOnError)
+	configDirFlag := fs.String("config-dir", "data", "Directory holding the server's stored data")
+	tokenStoreFlag := fs.String("token-store", storage.BackendFile, "Backend for stored login tokens: file or encrypted")
+	tokenKeyFileFlag := fs.String("token-key-file", "", "Key file for the encrypted token store; otherwise GITHUB_MCP_TOKEN_PASSPHRASE is used")
+	if err := fs.Parse(args); err != nil {
+		return 2
+	}
+	if fs.NArg() != 1 {
+		fmt.Fprintln(os.Stderr, "usage: github-mcp-server credential [flags] get|store|erase")
+		return 2
+	}
+
+	tool, err := auth.NewToolWithOptions(filepath.Join(*configDirFlag, "auth"), auth.ToolOptions{
+		Store: storage.StoreConfig{
+			Backend:    *tokenStoreFlag,
+			Passphrase: os.Getenv("GITHUB_MCP_TOKEN_PASSPHRASE"),
+			KeyFile:    *tokenKeyFileFlag,
+		},
+	})
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Failed to open token store: %v\n", err)
+		return 1
+	}
+
+	if err := tool.ServeCredential(fs.Arg(0), os.Stdin, os.Stdout); err != nil {
+		fmt.Fprintf(os.Stderr, "Credential helper error: %v\n", err)
+		return 1
+	}
+
+	return 0
+}
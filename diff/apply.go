@@ -0,0 +1,380 @@
+package diff
+
+import (
+	"fmt"
+	"strings"
+)
+
+// DefaultFuzz is the number of context lines Apply may ignore at each end
+// of a hunk, as in patch(1)
+const DefaultFuzz = 2
+
+// AppliedHunk reports where a hunk was applied
+type AppliedHunk struct {
+	// Hunk is the 1-based index of the hunk in the file diff
+	Hunk int `json:"hunk"`
+
+	// Line is the line of the original content the hunk was applied at
+	Line int `json:"line"`
+
+	// Offset is how many lines Line is away from the hunk's header
+	Offset int `json:"offset,omitempty"`
+
+	// Fuzz is the number of context lines ignored at each end
+	Fuzz int `json:"fuzz,omitempty"`
+
+	// Whitespace is set when the hunk only matched ignoring trailing
+	// whitespace
+	Whitespace bool `json:"whitespace,omitempty"`
+}
+
+// Conflict describes a hunk or edit that could not be applied
+type Conflict struct {
+	Hunk   int    `json:"hunk,omitempty"`
+	Edit   int    `json:"edit,omitempty"`
+	Line   int    `json:"line,omitempty"`
+	Reason string `json:"reason"`
+
+	// Expected holds the lines the hunk or edit looked for, and Actual the
+	// lines found where it should apply
+	Expected []string `json:"expected,omitempty"`
+	Actual   []string `json:"actual,omitempty"`
+}
+
+// ApplyError reports the hunks or edits that did not apply. Nothing is
+// applied when any of them conflicts.
+type ApplyError struct {
+	Path      string      `json:"path,omitempty"`
+	Conflicts []*Conflict `json:"conflicts"`
+}
+
+// Error implements the error interface
+func (e *ApplyError) Error() string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "%d change(s) did not apply", len(e.Conflicts))
+	if e.Path != "" {
+		fmt.Fprintf(&b, " to %s", e.Path)
+	}
+	for _, conflict := range e.Conflicts {
+		b.WriteString("\n")
+		switch {
+		case conflict.Hunk > 0:
+			fmt.Fprintf(&b, "hunk %d", conflict.Hunk)
+		case conflict.Edit > 0:
+			fmt.Fprintf(&b, "edit %d", conflict.Edit)
+		}
+		if conflict.Line > 0 {
+			fmt.Fprintf(&b, " at line %d", conflict.Line)
+		}
+		fmt.Fprintf(&b, ": %s", conflict.Reason)
+		if len(conflict.Expected) > 0 {
+			b.WriteString("\nexpected:\n")
+			writeQuoted(&b, conflict.Expected)
+		}
+		if len(conflict.Actual) > 0 {
+			b.WriteString("found:\n")
+			writeQuoted(&b, conflict.Actual)
+		}
+	}
+	return b.String()
+}
+
+// writeQuoted writes lines indented by a bar
+func writeQuoted(b *strings.Builder, lines []string) {
+	for _, line := range lines {
+		b.WriteString("| ")
+		b.WriteString(line)
+		b.WriteString("\n")
+	}
+}
+
+// text is file content split into lines
+type text struct {
+	lines []string
+
+	// newline is set when the last line ends with a newline
+	newline bool
+}
+
+// splitText splits content into lines
+func splitText(content string) *text {
+	t := &text{newline: true}
+	if content == "" {
+		return t
+	}
+	t.newline = strings.HasSuffix(content, "\n")
+	t.lines = strings.Split(strings.TrimSuffix(content, "\n"), "\n")
+	return t
+}
+
+// String joins the lines again
+func (t *text) String() string {
+	if len(t.lines) == 0 {
+		return ""
+	}
+	content := strings.Join(t.lines, "\n")
+	if t.newline {
+		content += "\n"
+	}
+	return content
+}
+
+// Apply applies the hunks of a file diff to content. Like patch(1), a hunk
+// may apply at an offset from the line in its header, and with up to fuzz
+// context lines ignored at each end; as a last resort trailing whitespace
+// is ignored. Hunks that do not apply are reported in an *ApplyError.
+func (f *File) Apply(content string, fuzz int) (string, []*AppliedHunk, error) {
+	if f.Binary {
+		return "", nil, fmt.Errorf("cannot apply a binary diff to %s", f.Path())
+	}
+
+	source := splitText(content)
+	result := &text{newline: source.newline}
+	var applied []*AppliedHunk
+	applyErr := &ApplyError{Path: f.Path()}
+
+	// next is the first source line not yet copied to the result, and delta
+	// how far hunks found so far moved from their headers
+	next, delta := 0, 0
+	for i, hunk := range f.Hunks {
+		old, replacement, oldEOF, newEOF := hunkSides(hunk)
+
+		at, lead, trail := -1, 0, 0
+		var match *AppliedHunk
+		for trim := 0; trim <= fuzz && at < 0; trim++ {
+			lead, trail = contextTrim(hunk, trim)
+			if trim > 0 && (lead+trail == 0 || lead+trail >= len(old)) {
+				// Nothing more to ignore, or nothing left to match
+				break
+			}
+			want := old[lead : len(old)-trail]
+			expected := hunk.OldStart - 1 + lead + delta
+			if len(old) == 0 {
+				// Pure additions come after line OldStart
+				expected = hunk.OldStart + delta
+			}
+			for _, loose := range []bool{false, true} {
+				if pos := findLines(source.lines, want, expected, next, loose); pos >= 0 {
+					at = pos
+					match = &AppliedHunk{Hunk: i + 1, Line: pos - lead + 1, Fuzz: trim, Whitespace: loose}
+					break
+				}
+			}
+		}
+
+		if at < 0 {
+			expected := clamp(hunk.OldStart-1+delta, next, len(source.lines))
+			end := clamp(expected+len(old), expected, len(source.lines))
+			applyErr.Conflicts = append(applyErr.Conflicts, &Conflict{
+				Hunk:     i + 1,
+				Line:     expected + 1,
+				Reason:   "the lines to change were not found",
+				Expected: old,
+				Actual:   source.lines[expected:end],
+			})
+			continue
+		}
+
+		match.Offset = match.Line - hunk.OldStart
+		if len(old) == 0 {
+			match.Offset = at - hunk.OldStart
+		}
+		delta = match.Offset
+		applied = append(applied, match)
+
+		// Keep the source's version of context lines, which may differ in
+		// whitespace
+		old, replacement = old[lead:len(old)-trail], replacement[lead:len(replacement)-trail]
+		result.lines = append(result.lines, source.lines[next:at]...)
+		result.lines = append(result.lines, mergeContext(hunk, lead, trail, source.lines[at:at+len(old)], replacement)...)
+		next = at + len(old)
+
+		if next == len(source.lines) && oldEOF != newEOF {
+			result.newline = !newEOF
+		}
+	}
+
+	if len(applyErr.Conflicts) > 0 {
+		return "", nil, applyErr
+	}
+
+	result.lines = append(result.lines, source.lines[next:]...)
+	return result.String(), applied, nil
+}
+
+// hunkSides returns the old and new lines of a hunk, and whether either
+// side ends without a newline at the end of the file
+func hunkSides(hunk *Hunk) (old, replacement []string, oldNoNewline, newNoNewline bool) {
+	for _, line := range hunk.Lines {
+		if line.Kind != Added {
+			old = append(old, line.Content)
+			oldNoNewline = line.NoNewline
+		}
+		if line.Kind != Deleted {
+			replacement = append(replacement, line.Content)
+			newNoNewline = line.NoNewline
+		}
+	}
+	return old, replacement, oldNoNewline, newNoNewline
+}
+
+// contextTrim returns how many leading and trailing context lines of a
+// hunk can be ignored for a given fuzz. Both sides of a hunk share these
+// lines, so the same counts apply to its old and new lines.
+func contextTrim(hunk *Hunk, fuzz int) (int, int) {
+	lead, trail := 0, 0
+	for lead < fuzz && lead < len(hunk.Lines) && hunk.Lines[lead].Kind == Context {
+		lead++
+	}
+	for trail < fuzz && trail < len(hunk.Lines)-lead && hunk.Lines[len(hunk.Lines)-1-trail].Kind == Context {
+		trail++
+	}
+	return lead, trail
+}
+
+// mergeContext builds the lines replacing source, taking context lines
+// from the source. lead and trail context lines were ignored by fuzz.
+func mergeContext(hunk *Hunk, lead, trail int, source, replacement []string) []string {
+	merged := make([]string, len(replacement))
+	copy(merged, replacement)
+
+	oldIndex, newIndex := 0, 0
+	for _, line := range hunk.Lines[lead : len(hunk.Lines)-trail] {
+		switch line.Kind {
+		case Context:
+			merged[newIndex] = source[oldIndex]
+			oldIndex++
+			newIndex++
+		case Deleted:
+			oldIndex++
+		case Added:
+			newIndex++
+		}
+	}
+	return merged
+}
+
+// findLines finds want in lines at or after from, searching outwards from
+// the expected position. loose compares lines ignoring trailing whitespace.
+func findLines(lines, want []string, expected, from int, loose bool) int {
+	last := len(lines) - len(want)
+	if last < from {
+		return -1
+	}
+	expected = clamp(expected, from, last)
+	for distance := 0; expected-distance >= from || expected+distance <= last; distance++ {
+		if pos := expected - distance; pos >= from && linesEqual(lines[pos:pos+len(want)], want, loose) {
+			return pos
+		}
+		if pos := expected + distance; distance > 0 && pos <= last && linesEqual(lines[pos:pos+len(want)], want, loose) {
+			return pos
+		}
+	}
+	return -1
+}
+
+// linesEqual compares two runs of lines
+func linesEqual(a, b []string, loose bool) bool {
+	for i := range b {
+		if a[i] == b[i] {
+			continue
+		}
+		if !loose || strings.TrimRight(a[i], " \t\r") != strings.TrimRight(b[i], " \t\r") {
+			return false
+		}
+	}
+	return true
+}
+
+// clamp limits value to [low, high]
+func clamp(value, low, high int) int {
+	if value > high {
+		value = high
+	}
+	if value < low {
+		value = low
+	}
+	return value
+}
+
+// Edit replaces text in a file
+type Edit struct {
+	Old string `json:"old_text"`
+	New string `json:"new_text"`
+
+	// All replaces every occurrence; otherwise Old must occur exactly once
+	All bool `json:"replace_all,omitempty"`
+}
+
+// ApplyEdits applies search and replace edits in order. Text that is not
+// found exactly is looked up line by line ignoring trailing whitespace.
+// Edits that do not apply are reported in an *ApplyError.
+func ApplyEdits(content string, edits []*Edit) (string, error) {
+	applyErr := &ApplyError{}
+	for i, edit := range edits {
+		if edit.Old == "" {
+			applyErr.Conflicts = append(applyErr.Conflicts, &Conflict{Edit: i + 1, Reason: "old_text is empty"})
+			continue
+		}
+
+		count := strings.Count(content, edit.Old)
+		switch {
+		case count == 1 || (count > 1 && edit.All):
+			content = strings.ReplaceAll(content, edit.Old, edit.New)
+		case count > 1:
+			applyErr.Conflicts = append(applyErr.Conflicts, &Conflict{
+				Edit:   i + 1,
+				Reason: fmt.Sprintf("old_text occurs %d times; add surrounding lines or set replace_all", count),
+			})
+		default:
+			replaced, ok := replaceLoose(content, edit)
+			if !ok {
+				applyErr.Conflicts = append(applyErr.Conflicts, &Conflict{
+					Edit:     i + 1,
+					Reason:   "old_text was not found",
+					Expected: strings.Split(strings.TrimSuffix(edit.Old, "\n"), "\n"),
+				})
+				continue
+			}
+			content = replaced
+		}
+	}
+
+	if len(applyErr.Conflicts) > 0 {
+		return "", applyErr
+	}
+	return content, nil
+}
+
+// replaceLoose replaces the whole lines matching an edit's old text when
+// trailing whitespace is ignored. It only applies to a single match unless
+// the edit replaces all.
+func replaceLoose(content string, edit *Edit) (string, bool) {
+	source := splitText(content)
+	want := strings.Split(strings.TrimSuffix(edit.Old, "\n"), "\n")
+	replacement := strings.Split(strings.TrimSuffix(edit.New, "\n"), "\n")
+	if edit.New == "" {
+		replacement = nil
+	}
+
+	var matches []int
+	for pos := 0; pos+len(want) <= len(source.lines); pos++ {
+		if linesEqual(source.lines[pos:pos+len(want)], want, true) {
+			matches = append(matches, pos)
+			pos += len(want) - 1
+		}
+	}
+	if len(matches) == 0 || (len(matches) > 1 && !edit.All) {
+		return "", false
+	}
+
+	result := &text{newline: source.newline}
+	next := 0
+	for _, pos := range matches {
+		result.lines = append(result.lines, source.lines[next:pos]...)
+		result.lines = append(result.lines, replacement...)
+		next = pos + len(want)
+	}
+	result.lines = append(result.lines, source.lines[next:]...)
+	return result.String(), true
+}
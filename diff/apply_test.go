@@ -0,0 +1,176 @@
+package diff
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+const applySource = `package main
+
+import "fmt"
+
+func main() {
+	fmt.Println("hello")
+	fmt.Println("world")
+}
+`
+
+// parseOne parses a diff of a single file
+func parseOne(t *testing.T, text string) *File {
+	t.Helper()
+	files, err := Parse(text)
+	if err != nil || len(files) != 1 {
+		t.Fatalf("Failed to parse diff: %v (%d files)", err, len(files))
+	}
+	return files[0]
+}
+
+func TestApply(t *testing.T) {
+	file := parseOne(t, `--- a/main.go
++++ b/main.go
+@@ -5,4 +5,4 @@ import "fmt"
+ func main() {
+-	fmt.Println("hello")
++	fmt.Println("goodbye")
+ 	fmt.Println("world")
+ }
+`)
+
+	result, applied, err := file.Apply(applySource, DefaultFuzz)
+	if err != nil {
+		t.Fatalf("Apply failed: %v", err)
+	}
+	if !strings.Contains(result, `fmt.Println("goodbye")`) || strings.Contains(result, `"hello"`) {
+		t.Errorf("Unexpected result:\n%s", result)
+	}
+	if len(applied) != 1 || applied[0].Line != 5 || applied[0].Offset != 0 || applied[0].Fuzz != 0 {
+		t.Errorf("Unexpected applied hunks: %+v", applied[0])
+	}
+}
+
+func TestApply_OffsetAndFuzz(t *testing.T) {
+	// The header is two lines off and the first context line is stale
+	file := parseOne(t, `--- a/main.go
++++ b/main.go
+@@ -3,4 +3,4 @@
+ func main() { // entry point
+-	fmt.Println("hello")
++	fmt.Println("goodbye")
+ 	fmt.Println("world")
+ }
+`)
+
+	if _, _, err := file.Apply(applySource, 0); err == nil {
+		t.Error("Expected the hunk not to apply without fuzz")
+	}
+
+	result, applied, err := file.Apply(applySource, 1)
+	if err != nil {
+		t.Fatalf("Apply failed: %v", err)
+	}
+	if !strings.Contains(result, "func main() {\n\tfmt.Println(\"goodbye\")") {
+		t.Errorf("Expected the source's context line to be kept:\n%s", result)
+	}
+	if applied[0].Line != 5 || applied[0].Offset != 2 || applied[0].Fuzz != 1 {
+		t.Errorf("Unexpected applied hunk: %+v", applied[0])
+	}
+}
+
+func TestApply_FuzzKeepsOneLine(t *testing.T) {
+	// Ignoring both context lines would leave nothing to match, so the
+	// hunk must not apply anywhere
+	file := parseOne(t, "--- a/list.txt\n+++ b/list.txt\n@@ -2,2 +2,3 @@\n alpha\n+INSERTED\n beta\n")
+
+	_, _, err := file.Apply("x\ny\nz\nw\nq\n", DefaultFuzz)
+	var applyErr *ApplyError
+	if !errors.As(err, &applyErr) || len(applyErr.Conflicts) != 1 {
+		t.Fatalf("Expected a conflict, got %v", err)
+	}
+}
+
+func TestApply_Whitespace(t *testing.T) {
+	file := parseOne(t, "--- a/main.go\n+++ b/main.go\n@@ -6,1 +6,1 @@\n-\tfmt.Println(\"hello\")   \n+\tfmt.Println(\"bye\")\n")
+
+	result, applied, err := file.Apply(applySource, 0)
+	if err != nil {
+		t.Fatalf("Apply failed: %v", err)
+	}
+	if !applied[0].Whitespace || !strings.Contains(result, `"bye"`) {
+		t.Errorf("Expected a whitespace-insensitive match: %+v\n%s", applied[0], result)
+	}
+}
+
+func TestApply_NewlineAtEOF(t *testing.T) {
+	file := parseOne(t, `--- a/notes.txt
++++ b/notes.txt
+@@ -1,2 +1,2 @@
+ one
+-two
+\ No newline at end of file
++two
+`)
+
+	result, _, err := file.Apply("one\ntwo", 0)
+	if err != nil {
+		t.Fatalf("Apply failed: %v", err)
+	}
+	if result != "one\ntwo\n" {
+		t.Errorf("Expected a newline to be added, got %q", result)
+	}
+}
+
+func TestApply_Conflict(t *testing.T) {
+	file := parseOne(t, `--- a/main.go
++++ b/main.go
+@@ -6,2 +6,2 @@
+-	fmt.Println("hi")
+-	fmt.Println("there")
++	fmt.Println("bye")
++	fmt.Println("now")
+@@ -8,1 +8,2 @@
+ }
++// trailing
+`)
+
+	_, _, err := file.Apply(applySource, DefaultFuzz)
+	var applyErr *ApplyError
+	if !errors.As(err, &applyErr) {
+		t.Fatalf("Expected an ApplyError, got %v", err)
+	}
+	if len(applyErr.Conflicts) != 1 || applyErr.Conflicts[0].Hunk != 1 || applyErr.Conflicts[0].Line != 6 {
+		t.Fatalf("Unexpected conflicts: %+v", applyErr.Conflicts)
+	}
+	if got := strings.Join(applyErr.Conflicts[0].Actual, "|"); got != "\tfmt.Println(\"hello\")|\tfmt.Println(\"world\")" {
+		t.Errorf("Unexpected actual lines: %s", got)
+	}
+	if !strings.Contains(err.Error(), "hunk 1 at line 6") {
+		t.Errorf("Unexpected error message: %v", err)
+	}
+}
+
+func TestApplyEdits(t *testing.T) {
+	result, err := ApplyEdits(applySource, []*Edit{
+		{Old: `fmt.Println("hello")`, New: `fmt.Println("hi")`},
+		{Old: "fmt.Println", New: "log.Println", All: true},
+		{Old: "import \"fmt\"  \n", New: "import \"log\"\n"},
+	})
+	if err != nil {
+		t.Fatalf("ApplyEdits failed: %v", err)
+	}
+	if !strings.Contains(result, "import \"log\"\n") || strings.Count(result, "log.Println") != 2 {
+		t.Errorf("Unexpected result:\n%s", result)
+	}
+
+	_, err = ApplyEdits(applySource, []*Edit{
+		{Old: "fmt.Println"},
+		{Old: "missing", New: "x"},
+	})
+	var applyErr *ApplyError
+	if !errors.As(err, &applyErr) || len(applyErr.Conflicts) != 2 {
+		t.Fatalf("Expected two conflicts, got %v", err)
+	}
+	if !strings.Contains(applyErr.Conflicts[0].Reason, "2 times") || applyErr.Conflicts[1].Edit != 2 {
+		t.Errorf("Unexpected conflicts: %+v %+v", applyErr.Conflicts[0], applyErr.Conflicts[1])
+	}
+}
@@ -0,0 +1,302 @@
+// Package diff parses unified diffs into files and hunks, mapping every
+// diff line to its line numbers in the old and new versions of the file,
+// and applies diffs and search and replace edits to file content.
+package diff
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// LineKind identifies the role of a line within a hunk
+type LineKind int
+
+// Line kinds
+const (
+	Context LineKind = iota
+	Added
+	Deleted
+)
+
+// File status values
+const (
+	StatusAdded    = "added"
+	StatusDeleted  = "deleted"
+	StatusRenamed  = "renamed"
+	StatusModified = "modified"
+)
+
+// Line is a single line of a hunk
+type Line struct {
+	Kind    LineKind `json:"kind"`
+	Content string   `json:"content"`
+
+	// OldLine is the line number in the old file, or 0 for added lines
+	OldLine int `json:"old_line,omitempty"`
+
+	// NewLine is the line number in the new file, or 0 for deleted lines
+	NewLine int `json:"new_line,omitempty"`
+
+	// NoNewline is set when the line has no trailing newline
+	NoNewline bool `json:"no_newline,omitempty"`
+}
+
+// Hunk is a contiguous block of changes
+type Hunk struct {
+	OldStart int    `json:"old_start"`
+	OldLines int    `json:"old_lines"`
+	NewStart int    `json:"new_start"`
+	NewLines int    `json:"new_lines"`
+	Section  string `json:"section,omitempty"`
+	Lines    []Line `json:"lines"`
+}
+
+// File is the diff of a single file
+type File struct {
+	OldPath string  `json:"old_path,omitempty"`
+	NewPath string  `json:"new_path,omitempty"`
+	Status  string  `json:"status"`
+	Binary  bool    `json:"binary,omitempty"`
+	Header  string  `json:"-"`
+	Hunks   []*Hunk `json:"hunks,omitempty"`
+
+	// TruncatedLines counts the hunk lines dropped by Truncate
+	TruncatedLines int `json:"truncated_lines,omitempty"`
+}
+
+// Path returns the file's path, preferring the new path
+func (f *File) Path() string {
+	if f.NewPath != "" {
+		return f.NewPath
+	}
+	return f.OldPath
+}
+
+// LineCount returns the number of hunk lines in the file
+func (f *File) LineCount() int {
+	count := 0
+	for _, hunk := range f.Hunks {
+		count += len(hunk.Lines)
+	}
+	return count
+}
+
+// FindLine returns the diff line at a line number of the new file
+// (side RIGHT) or the old file (side LEFT), or nil if the line is not
+// part of the diff. Review comments can only be placed on such lines.
+func (f *File) FindLine(side string, number int) *Line {
+	for _, hunk := range f.Hunks {
+		for i := range hunk.Lines {
+			line := &hunk.Lines[i]
+			switch {
+			case side == "LEFT" && line.Kind != Added && line.OldLine == number:
+				return line
+			case side != "LEFT" && line.Kind != Deleted && line.NewLine == number:
+				return line
+			}
+		}
+	}
+	return nil
+}
+
+// Parse parses a unified diff, as produced by git diff, into files. Text
+// outside file diffs, such as the mail headers of a patch, is skipped.
+func Parse(text string) ([]*File, error) {
+	var files []*File
+	var file *File
+	var hunk *Hunk
+	var oldLine, newLine, oldLeft, newLeft int
+
+	lines := strings.Split(text, "\n")
+	if len(lines) > 0 && lines[len(lines)-1] == "" {
+		lines = lines[:len(lines)-1]
+	}
+
+	for i, raw := range lines {
+		// Lines belonging to the current hunk
+		if hunk != nil && (oldLeft > 0 || newLeft > 0) {
+			if raw == "" {
+				// Some tools strip the space from empty context lines
+				raw = " "
+			}
+			switch raw[0] {
+			case ' ':
+				hunk.Lines = append(hunk.Lines, Line{Kind: Context, Content: raw[1:], OldLine: oldLine, NewLine: newLine})
+				oldLine++
+				newLine++
+				oldLeft--
+				newLeft--
+			case '-':
+				hunk.Lines = append(hunk.Lines, Line{Kind: Deleted, Content: raw[1:], OldLine: oldLine})
+				oldLine++
+				oldLeft--
+			case '+':
+				hunk.Lines = append(hunk.Lines, Line{Kind: Added, Content: raw[1:], NewLine: newLine})
+				newLine++
+				newLeft--
+			case '\\':
+				markNoNewline(hunk)
+			default:
+				return nil, fmt.Errorf("line %d: unexpected line in hunk: %q", i+1, raw)
+			}
+			continue
+		}
+
+		switch {
+		case strings.HasPrefix(raw, "\\"):
+			if hunk != nil {
+				markNoNewline(hunk)
+			}
+		case strings.HasPrefix(raw, "diff --git "):
+			file = &File{Status: StatusModified, Header: raw}
+			file.OldPath, file.NewPath = parseGitPaths(strings.TrimPrefix(raw, "diff --git "))
+			files = append(files, file)
+			hunk = nil
+		case strings.HasPrefix(raw, "--- "):
+			// A plain unified diff starts a new file at its --- header
+			if file == nil || len(file.Hunks) > 0 {
+				file = &File{Status: StatusModified}
+				files = append(files, file)
+			}
+			file.OldPath = parseHeaderPath(strings.TrimPrefix(raw, "--- "))
+			file.Header = appendHeader(file.Header, raw)
+			if file.OldPath == "" {
+				file.Status = StatusAdded
+			}
+			hunk = nil
+		case strings.HasPrefix(raw, "+++ ") && file != nil && len(file.Hunks) == 0:
+			file.NewPath = parseHeaderPath(strings.TrimPrefix(raw, "+++ "))
+			file.Header = appendHeader(file.Header, raw)
+			if file.NewPath == "" {
+				file.Status = StatusDeleted
+			}
+		case strings.HasPrefix(raw, "@@ "):
+			if file == nil {
+				return nil, fmt.Errorf("line %d: hunk without a file header", i+1)
+			}
+			var err error
+			hunk, err = parseHunkHeader(raw)
+			if err != nil {
+				return nil, fmt.Errorf("line %d: %w", i+1, err)
+			}
+			file.Hunks = append(file.Hunks, hunk)
+			oldLine, newLine = hunk.OldStart, hunk.NewStart
+			oldLeft, newLeft = hunk.OldLines, hunk.NewLines
+		case file != nil && len(file.Hunks) == 0:
+			// Extended git headers
+			file.Header = appendHeader(file.Header, raw)
+			switch {
+			case strings.HasPrefix(raw, "new file mode"):
+				file.Status = StatusAdded
+				file.OldPath = ""
+			case strings.HasPrefix(raw, "deleted file mode"):
+				file.Status = StatusDeleted
+				file.NewPath = ""
+			case strings.HasPrefix(raw, "rename from "):
+				file.Status = StatusRenamed
+				file.OldPath = strings.TrimPrefix(raw, "rename from ")
+			case strings.HasPrefix(raw, "rename to "):
+				file.Status = StatusRenamed
+				file.NewPath = strings.TrimPrefix(raw, "rename to ")
+			case strings.HasPrefix(raw, "Binary files ") || raw == "GIT binary patch":
+				file.Binary = true
+			}
+		}
+		// Anything else is text between files, e.g. patch mail headers
+	}
+
+	if hunk != nil && (oldLeft > 0 || newLeft > 0) {
+		return nil, fmt.Errorf("hunk for %s ends early", file.Path())
+	}
+
+	return files, nil
+}
+
+// markNoNewline flags the last line of a hunk as lacking a trailing newline
+func markNoNewline(hunk *Hunk) {
+	if len(hunk.Lines) > 0 {
+		hunk.Lines[len(hunk.Lines)-1].NoNewline = true
+	}
+}
+
+// parseHunkHeader parses "@@ -old,count +new,count @@ section"
+func parseHunkHeader(header string) (*Hunk, error) {
+	end := strings.Index(header[3:], " @@")
+	if end < 0 {
+		return nil, fmt.Errorf("invalid hunk header: %q", header)
+	}
+
+	ranges := strings.Fields(header[3 : 3+end])
+	if len(ranges) != 2 || !strings.HasPrefix(ranges[0], "-") || !strings.HasPrefix(ranges[1], "+") {
+		return nil, fmt.Errorf("invalid hunk header: %q", header)
+	}
+
+	hunk := &Hunk{Section: strings.TrimSpace(header[3+end+3:])}
+
+	var err error
+	if hunk.OldStart, hunk.OldLines, err = parseRange(ranges[0][1:]); err != nil {
+		return nil, fmt.Errorf("invalid hunk header: %q", header)
+	}
+	if hunk.NewStart, hunk.NewLines, err = parseRange(ranges[1][1:]); err != nil {
+		return nil, fmt.Errorf("invalid hunk header: %q", header)
+	}
+
+	return hunk, nil
+}
+
+// parseRange parses "start,count" or "start" (a count of one)
+func parseRange(value string) (int, int, error) {
+	startStr, countStr, hasCount := strings.Cut(value, ",")
+
+	start, err := strconv.Atoi(startStr)
+	if err != nil {
+		return 0, 0, err
+	}
+	if !hasCount {
+		return start, 1, nil
+	}
+
+	count, err := strconv.Atoi(countStr)
+	if err != nil {
+		return 0, 0, err
+	}
+	return start, count, nil
+}
+
+// parseGitPaths extracts the paths from "a/old b/new"
+func parseGitPaths(value string) (string, string) {
+	if strings.HasPrefix(value, "a/") {
+		if i := strings.Index(value, " b/"); i >= 0 {
+			return value[2:i], value[i+3:]
+		}
+	}
+	fields := strings.Fields(value)
+	if len(fields) == 2 {
+		return fields[0], fields[1]
+	}
+	return value, value
+}
+
+// parseHeaderPath extracts the path from a ---/+++ header, returning an
+// empty string for /dev/null
+func parseHeaderPath(value string) string {
+	if i := strings.IndexByte(value, '\t'); i >= 0 {
+		value = value[:i]
+	}
+	if value == "/dev/null" {
+		return ""
+	}
+	if strings.HasPrefix(value, "a/") || strings.HasPrefix(value, "b/") {
+		return value[2:]
+	}
+	return value
+}
+
+// appendHeader adds a line to a file's header
+func appendHeader(header, line string) string {
+	if header == "" {
+		return line
+	}
+	return header + "\n" + line
+}
@@ -0,0 +1,174 @@
+package diff
+
+import (
+	"strings"
+	"testing"
+)
+
+const testDiff = `diff --git a/main.go b/main.go
+index 83db48f..bf269f4 100644
+--- a/main.go
++++ b/main.go
+@@ -1,5 +1,6 @@ package main
+ import "fmt"
+
+-func old() {}
++func newer() {}
++func added() {}
+
+ func main() {
+@@ -40,3 +41,3 @@ func main() {
+ 	a := 1
+-	b := 2
++	b := 3
+ 	c := 4
+\ No newline at end of file
+diff --git a/docs/old.md b/docs/new.md
+similarity index 100%
+rename from docs/old.md
+rename to docs/new.md
+diff --git a/logo.png b/logo.png
+new file mode 100644
+index 0000000..e69de29
+Binary files /dev/null and b/logo.png differ
+diff --git a/gone.txt b/gone.txt
+deleted file mode 100644
+index 3b18e51..0000000
+--- a/gone.txt
++++ /dev/null
+@@ -1 +0,0 @@
+-hello
+`
+
+func TestParse(t *testing.T) {
+	files, err := Parse(testDiff)
+	if err != nil {
+		t.Fatalf("Parse failed: %v", err)
+	}
+	if len(files) != 4 {
+		t.Fatalf("Expected 4 files, got %d", len(files))
+	}
+
+	main := files[0]
+	if main.Path() != "main.go" || main.Status != StatusModified || len(main.Hunks) != 2 {
+		t.Fatalf("Unexpected main.go diff: %+v", main)
+	}
+	if main.Hunks[0].Section != "package main" || main.Hunks[0].NewLines != 6 {
+		t.Errorf("Unexpected first hunk: %+v", main.Hunks[0])
+	}
+	last := main.Hunks[1].Lines[len(main.Hunks[1].Lines)-1]
+	if !last.NoNewline || last.OldLine != 42 || last.NewLine != 43 {
+		t.Errorf("Unexpected last line: %+v", last)
+	}
+
+	if files[1].Status != StatusRenamed || files[1].OldPath != "docs/old.md" || files[1].NewPath != "docs/new.md" {
+		t.Errorf("Unexpected rename: %+v", files[1])
+	}
+	if files[2].Status != StatusAdded || !files[2].Binary {
+		t.Errorf("Unexpected binary file: %+v", files[2])
+	}
+	if files[3].Status != StatusDeleted || files[3].Path() != "gone.txt" || files[3].Hunks[0].Lines[0].OldLine != 1 {
+		t.Errorf("Unexpected deleted file: %+v", files[3])
+	}
+}
+
+func TestFindLine(t *testing.T) {
+	files, err := Parse(testDiff)
+	if err != nil {
+		t.Fatalf("Parse failed: %v", err)
+	}
+	main := files[0]
+
+	tests := []struct {
+		name    string
+		side    string
+		number  int
+		content string
+	}{
+		{name: "added line", side: "RIGHT", number: 4, content: "func added() {}"},
+		{name: "deleted line", side: "LEFT", number: 3, content: "func old() {}"},
+		{name: "context line in second hunk", side: "RIGHT", number: 41, content: "\ta := 1"},
+		{name: "changed line in second hunk", side: "RIGHT", number: 42, content: "\tb := 3"},
+		{name: "line outside the diff", side: "RIGHT", number: 20},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			line := main.FindLine(tt.side, tt.number)
+			if tt.content == "" {
+				if line != nil {
+					t.Errorf("Expected no line, got %+v", line)
+				}
+				return
+			}
+			if line == nil || line.Content != tt.content {
+				t.Errorf("Expected %q, got %+v", tt.content, line)
+			}
+		})
+	}
+}
+
+func TestParse_PlainUnifiedDiff(t *testing.T) {
+	files, err := Parse("--- a.txt\t2024-01-01\n+++ a.txt\t2024-01-02\n@@ -1 +1 @@\n-a\n+b\n--- b.txt\n+++ b.txt\n@@ -2,0 +3 @@\n+c\n")
+	if err != nil {
+		t.Fatalf("Parse failed: %v", err)
+	}
+	if len(files) != 2 || files[0].Path() != "a.txt" || files[1].Path() != "b.txt" {
+		t.Fatalf("Unexpected files: %+v", files)
+	}
+	if line := files[1].FindLine("RIGHT", 3); line == nil || line.Content != "c" {
+		t.Errorf("Expected added line 3 in b.txt, got %+v", line)
+	}
+}
+
+func TestParse_Invalid(t *testing.T) {
+	if _, err := Parse("diff --git a/x b/x\n@@ -1,3 +1,3 @@\n a\n"); err == nil {
+		t.Error("Expected error for a hunk that ends early")
+	}
+	if _, err := Parse("@@ -1 +1 @@\n-a\n+b\n"); err == nil {
+		t.Error("Expected error for a hunk without a file header")
+	}
+}
+
+func TestTruncate(t *testing.T) {
+	files, err := Parse(testDiff)
+	if err != nil {
+		t.Fatalf("Parse failed: %v", err)
+	}
+
+	// Only the first hunk of main.go fits
+	Truncate(files, 7)
+	if len(files[0].Hunks) != 1 || files[0].TruncatedLines != 4 {
+		t.Errorf("Expected second hunk to be dropped, got %d hunks and %d truncated lines", len(files[0].Hunks), files[0].TruncatedLines)
+	}
+
+	// A single large hunk is cut and stays parseable
+	files, _ = Parse(testDiff)
+	Truncate(files, 3)
+	if files[0].TruncatedLines != 8 {
+		t.Errorf("Expected 8 truncated lines, got %d", files[0].TruncatedLines)
+	}
+	reparsed, err := Parse(Format(files))
+	if err != nil {
+		t.Fatalf("Truncated diff does not parse: %v", err)
+	}
+	if hunk := reparsed[0].Hunks[0]; hunk.OldLines != 3 || hunk.NewLines != 2 {
+		t.Errorf("Unexpected cut hunk counts: -%d +%d", hunk.OldLines, hunk.NewLines)
+	}
+
+	if !strings.Contains(FormatAnnotated(files), "[8 more diff lines truncated]") {
+		t.Error("Expected truncation note in annotated output")
+	}
+}
+
+func TestFormat_RoundTrip(t *testing.T) {
+	files, err := Parse(testDiff)
+	if err != nil {
+		t.Fatalf("Parse failed: %v", err)
+	}
+	// Empty context lines are written back with their leading space
+	want := strings.ReplaceAll(testDiff, "\n\n", "\n \n")
+	if got := Format(files); got != want {
+		t.Errorf("Format did not reproduce the diff:\n%s", got)
+	}
+}
@@ -0,0 +1,166 @@
+package diff
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// Truncate limits each file to at most maxLines hunk lines. Whole hunks are
+// kept while they fit; a first hunk larger than the limit is cut short and
+// its line counts adjusted so the result is still a valid diff. The number
+// of dropped lines is recorded in File.TruncatedLines.
+func Truncate(files []*File, maxLines int) {
+	if maxLines <= 0 {
+		return
+	}
+
+	for _, file := range files {
+		total := file.LineCount()
+		if total <= maxLines {
+			continue
+		}
+
+		kept := 0
+		var hunks []*Hunk
+		for _, hunk := range file.Hunks {
+			if kept+len(hunk.Lines) <= maxLines {
+				hunks = append(hunks, hunk)
+				kept += len(hunk.Lines)
+				continue
+			}
+			if len(hunks) == 0 {
+				hunks = append(hunks, cutHunk(hunk, maxLines))
+				kept = maxLines
+			}
+			break
+		}
+
+		file.Hunks = hunks
+		file.TruncatedLines = total - kept
+	}
+}
+
+// cutHunk returns the first n lines of a hunk with matching line counts
+func cutHunk(hunk *Hunk, n int) *Hunk {
+	cut := &Hunk{
+		OldStart: hunk.OldStart,
+		NewStart: hunk.NewStart,
+		Section:  hunk.Section,
+		Lines:    hunk.Lines[:n],
+	}
+	for _, line := range cut.Lines {
+		if line.Kind != Added {
+			cut.OldLines++
+		}
+		if line.Kind != Deleted {
+			cut.NewLines++
+		}
+	}
+	return cut
+}
+
+// Format renders files as a unified diff. Truncated files are followed by
+// a note outside the diff.
+func Format(files []*File) string {
+	var b strings.Builder
+	for _, file := range files {
+		if file.Header != "" {
+			b.WriteString(file.Header)
+			b.WriteByte('\n')
+		}
+
+		for _, hunk := range file.Hunks {
+			b.WriteString(hunk.header())
+			b.WriteByte('\n')
+			for _, line := range hunk.Lines {
+				b.WriteByte(line.prefix())
+				b.WriteString(line.Content)
+				b.WriteByte('\n')
+				if line.NoNewline {
+					b.WriteString("\\ No newline at end of file\n")
+				}
+			}
+		}
+
+		if file.TruncatedLines > 0 {
+			fmt.Fprintf(&b, "[%s: %d more diff lines truncated]\n", file.Path(), file.TruncatedLines)
+		}
+	}
+	return b.String()
+}
+
+// FormatAnnotated renders files with the old and new line number of every
+// diff line, e.g. for choosing the line of a review comment:
+//
+//	41   41  	context
+//	42       -	deleted (comment on side LEFT, line 42)
+//	     42  +	added (comment on side RIGHT, line 42)
+func FormatAnnotated(files []*File) string {
+	var b strings.Builder
+	for i, file := range files {
+		if i > 0 {
+			b.WriteByte('\n')
+		}
+
+		switch file.Status {
+		case StatusRenamed:
+			fmt.Fprintf(&b, "%s → %s (%s)\n", file.OldPath, file.NewPath, file.Status)
+		default:
+			fmt.Fprintf(&b, "%s (%s)\n", file.Path(), file.Status)
+		}
+		if file.Binary {
+			b.WriteString("Binary file not shown\n")
+		}
+
+		for _, hunk := range file.Hunks {
+			b.WriteString(hunk.header())
+			b.WriteByte('\n')
+			for _, line := range hunk.Lines {
+				fmt.Fprintf(&b, "%5s %5s %c\t%s\n", lineNumber(line.OldLine), lineNumber(line.NewLine), line.prefix(), line.Content)
+			}
+		}
+
+		if file.TruncatedLines > 0 {
+			fmt.Fprintf(&b, "[%d more diff lines truncated]\n", file.TruncatedLines)
+		}
+	}
+	return b.String()
+}
+
+// header renders the hunk's @@ line
+func (h *Hunk) header() string {
+	header := fmt.Sprintf("@@ -%s +%s @@", formatRange(h.OldStart, h.OldLines), formatRange(h.NewStart, h.NewLines))
+	if h.Section != "" {
+		header += " " + h.Section
+	}
+	return header
+}
+
+// formatRange renders a hunk range, omitting a count of one as git does
+func formatRange(start, count int) string {
+	if count == 1 {
+		return strconv.Itoa(start)
+	}
+	return fmt.Sprintf("%d,%d", start, count)
+}
+
+// prefix returns the diff marker for the line
+func (l Line) prefix() byte {
+	switch l.Kind {
+	case Added:
+		return '+'
+	case Deleted:
+		return '-'
+	default:
+		return ' '
+	}
+}
+
+// lineNumber formats a line number, leaving missing numbers blank
+func lineNumber(n int) string {
+	if n == 0 {
+		return ""
+	}
+	return strconv.Itoa(n)
+}
@@ -0,0 +1,304 @@
+package github
+
+import (
+	"bytes"
+	"fmt"
+	"math"
+	"sort"
+	"text/tabwriter"
+	"time"
+)
+
+// minTrendSamples is the number of durations needed to compare the older
+// and newer halves of a window
+const minTrendSamples = 4
+
+// JobStats summarizes the runs of one job of a workflow
+type JobStats struct {
+	Name     string `json:"name"`
+	Runs     int    `json:"runs"`
+	Failures int    `json:"failures"`
+
+	// Flaky counts runs where the job failed and passed on a rerun
+	Flaky int `json:"flaky"`
+
+	P50 time.Duration `json:"p50"`
+	P95 time.Duration `json:"p95"`
+
+	// Trend is the relative change of the median duration from the older
+	// to the newer half of the runs, or nil with too few runs
+	Trend *float64 `json:"trend,omitempty"`
+}
+
+// StepStats summarizes the runs of one step of a job
+type StepStats struct {
+	Job      string        `json:"job"`
+	Name     string        `json:"name"`
+	Runs     int           `json:"runs"`
+	Failures int           `json:"failures"`
+	P50      time.Duration `json:"p50"`
+	P95      time.Duration `json:"p95"`
+}
+
+// WorkflowRunStats summarizes failure rates, flakiness and durations over
+// the completed runs of a workflow
+type WorkflowRunStats struct {
+	Runs     int `json:"runs"`
+	Failures int `json:"failures"`
+
+	// Flaky counts runs that passed only after failed jobs were rerun
+	Flaky int `json:"flaky"`
+
+	From  time.Time    `json:"from"`
+	To    time.Time    `json:"to"`
+	Jobs  []*JobStats  `json:"jobs"`
+	Steps []*StepStats `json:"steps"`
+}
+
+// durationSample is a duration measured in a run created at a given time
+type durationSample struct {
+	at       time.Time
+	duration time.Duration
+}
+
+// AnalyzeWorkflowRuns computes per-job and per-step statistics over the
+// completed runs of a workflow. jobs maps each run ID to the jobs of all of
+// its attempts. Failure rates count the last attempt of each job; durations
+// count every attempt that passed or failed.
+func AnalyzeWorkflowRuns(runs []*WorkflowRun, jobs map[int64][]*WorkflowJob) *WorkflowRunStats {
+	stats := &WorkflowRunStats{Jobs: []*JobStats{}, Steps: []*StepStats{}}
+
+	jobStats := make(map[string]*JobStats)
+	jobSamples := make(map[string][]durationSample)
+	stepStats := make(map[[2]string]*StepStats)
+	stepSamples := make(map[[2]string][]time.Duration)
+
+	for _, run := range runs {
+		if run.Status != "completed" {
+			continue
+		}
+		stats.Runs++
+		if stats.From.IsZero() || run.CreatedAt.Before(stats.From) {
+			stats.From = run.CreatedAt
+		}
+		if run.CreatedAt.After(stats.To) {
+			stats.To = run.CreatedAt
+		}
+		runFailed := isFailure(run.Conclusion)
+		if runFailed {
+			stats.Failures++
+		}
+
+		attempts := make(map[string][]*WorkflowJob)
+		for _, job := range jobs[run.ID] {
+			attempts[job.Name] = append(attempts[job.Name], job)
+		}
+
+		rerunFixed := false
+		for name, jobAttempts := range attempts {
+			sort.Slice(jobAttempts, func(i, j int) bool {
+				return jobAttempts[i].RunAttempt < jobAttempts[j].RunAttempt
+			})
+			latest := jobAttempts[len(jobAttempts)-1]
+			if !isFailure(latest.Conclusion) && latest.Conclusion != "success" {
+				continue
+			}
+
+			js := jobStats[name]
+			if js == nil {
+				js = &JobStats{Name: name}
+				jobStats[name] = js
+			}
+			js.Runs++
+			if isFailure(latest.Conclusion) {
+				js.Failures++
+			} else {
+				for _, attempt := range jobAttempts[:len(jobAttempts)-1] {
+					if isFailure(attempt.Conclusion) {
+						js.Flaky++
+						rerunFixed = true
+						break
+					}
+				}
+			}
+
+			for _, attempt := range jobAttempts {
+				if duration, ok := elapsed(attempt.Conclusion, attempt.StartedAt, attempt.CompletedAt); ok {
+					jobSamples[name] = append(jobSamples[name], durationSample{at: run.CreatedAt, duration: duration})
+				}
+				for _, step := range attempt.Steps {
+					key := [2]string{name, step.Name}
+					if attempt == latest && (step.Conclusion == "success" || isFailure(step.Conclusion)) {
+						ss := stepStats[key]
+						if ss == nil {
+							ss = &StepStats{Job: name, Name: step.Name}
+							stepStats[key] = ss
+						}
+						ss.Runs++
+						if isFailure(step.Conclusion) {
+							ss.Failures++
+						}
+					}
+					if duration, ok := elapsed(step.Conclusion, step.StartedAt, step.CompletedAt); ok {
+						stepSamples[key] = append(stepSamples[key], duration)
+					}
+				}
+			}
+		}
+		if rerunFixed && !runFailed {
+			stats.Flaky++
+		}
+	}
+
+	for name, js := range jobStats {
+		samples := jobSamples[name]
+		durations := make([]time.Duration, len(samples))
+		for i, sample := range samples {
+			durations[i] = sample.duration
+		}
+		js.P50 = percentile(durations, 0.50)
+		js.P95 = percentile(durations, 0.95)
+		js.Trend = trend(samples)
+		stats.Jobs = append(stats.Jobs, js)
+	}
+	sort.Slice(stats.Jobs, func(i, j int) bool {
+		return stats.Jobs[i].Name < stats.Jobs[j].Name
+	})
+
+	for key, ss := range stepStats {
+		ss.P50 = percentile(stepSamples[key], 0.50)
+		ss.P95 = percentile(stepSamples[key], 0.95)
+		stats.Steps = append(stats.Steps, ss)
+	}
+	sort.Slice(stats.Steps, func(i, j int) bool {
+		if stats.Steps[i].P95 != stats.Steps[j].P95 {
+			return stats.Steps[i].P95 > stats.Steps[j].P95
+		}
+		if stats.Steps[i].Job != stats.Steps[j].Job {
+			return stats.Steps[i].Job < stats.Steps[j].Job
+		}
+		return stats.Steps[i].Name < stats.Steps[j].Name
+	})
+
+	return stats
+}
+
+// Table formats the statistics as aligned text tables: a summary line, one
+// row per job, and the maxSteps slowest steps by p95. The step table is
+// left out if maxSteps is not positive.
+func (s *WorkflowRunStats) Table(maxSteps int) string {
+	var buf bytes.Buffer
+
+	if s.Runs == 0 {
+		return "No completed runs\n"
+	}
+	fmt.Fprintf(&buf, "Runs: %d (%s to %s), failed %d (%s), flaky %d\n\n",
+		s.Runs, s.From.Format("2006-01-02"), s.To.Format("2006-01-02"),
+		s.Failures, rate(s.Failures, s.Runs), s.Flaky)
+
+	w := tabwriter.NewWriter(&buf, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "JOB\tRUNS\tFAIL%\tFLAKY\tP50\tP95\tTREND")
+	for _, job := range s.Jobs {
+		trendText := "-"
+		if job.Trend != nil {
+			trendText = fmt.Sprintf("%+.0f%%", *job.Trend*100)
+		}
+		fmt.Fprintf(w, "%s\t%d\t%s\t%d\t%s\t%s\t%s\n", job.Name, job.Runs, rate(job.Failures, job.Runs),
+			job.Flaky, formatDuration(job.P50), formatDuration(job.P95), trendText)
+	}
+	w.Flush()
+
+	steps := s.Steps
+	if maxSteps <= 0 {
+		steps = nil
+	} else if len(steps) > maxSteps {
+		steps = steps[:maxSteps]
+	}
+	if len(steps) > 0 {
+		fmt.Fprintf(&buf, "\nSlowest steps by p95 (%d of %d):\n", len(steps), len(s.Steps))
+		w = tabwriter.NewWriter(&buf, 0, 0, 2, ' ', 0)
+		fmt.Fprintln(w, "JOB\tSTEP\tRUNS\tFAIL%\tP50\tP95")
+		for _, step := range steps {
+			fmt.Fprintf(w, "%s\t%s\t%d\t%s\t%s\t%s\n", step.Job, step.Name, step.Runs, rate(step.Failures, step.Runs),
+				formatDuration(step.P50), formatDuration(step.P95))
+		}
+		w.Flush()
+	}
+
+	return buf.String()
+}
+
+// isFailure reports whether a run, job or step conclusion is a failure
+func isFailure(conclusion string) bool {
+	return conclusion == "failure" || conclusion == "timed_out"
+}
+
+// elapsed returns how long a job or step that passed or failed took
+func elapsed(conclusion string, startedAt, completedAt *time.Time) (time.Duration, bool) {
+	if conclusion != "success" && !isFailure(conclusion) {
+		return 0, false
+	}
+	if startedAt == nil || completedAt == nil || completedAt.Before(*startedAt) {
+		return 0, false
+	}
+	return completedAt.Sub(*startedAt), true
+}
+
+// percentile returns the nearest-rank percentile p of durations
+func percentile(durations []time.Duration, p float64) time.Duration {
+	if len(durations) == 0 {
+		return 0
+	}
+	sorted := append([]time.Duration(nil), durations...)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i] < sorted[j] })
+
+	rank := int(math.Ceil(p*float64(len(sorted)))) - 1
+	if rank < 0 {
+		rank = 0
+	}
+	return sorted[rank]
+}
+
+// trend compares the median duration of the newer half of the samples with
+// that of the older half
+func trend(samples []durationSample) *float64 {
+	if len(samples) < minTrendSamples {
+		return nil
+	}
+	sorted := append([]durationSample(nil), samples...)
+	sort.SliceStable(sorted, func(i, j int) bool { return sorted[i].at.Before(sorted[j].at) })
+
+	half := len(sorted) / 2
+	older := make([]time.Duration, 0, half)
+	newer := make([]time.Duration, 0, len(sorted)-half)
+	for i, sample := range sorted {
+		if i < half {
+			older = append(older, sample.duration)
+		} else {
+			newer = append(newer, sample.duration)
+		}
+	}
+
+	base := percentile(older, 0.50)
+	if base == 0 {
+		return nil
+	}
+	change := float64(percentile(newer, 0.50))/float64(base) - 1
+	return &change
+}
+
+// rate formats count/total as a percentage
+func rate(count, total int) string {
+	if total == 0 {
+		return "-"
+	}
+	return fmt.Sprintf("%.1f%%", float64(count)*100/float64(total))
+}
+
+// formatDuration rounds a duration to seconds for display
+func formatDuration(d time.Duration) string {
+	if d == 0 {
+		return "-"
+	}
+	return d.Round(time.Second).String()
+}
@@ -0,0 +1,95 @@
+package github
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+// testJob builds a job attempt that ran for the given duration
+func testJob(name string, attempt int, conclusion string, start time.Time, d time.Duration) *WorkflowJob {
+	end := start.Add(d)
+	return &WorkflowJob{
+		Name:        name,
+		RunAttempt:  attempt,
+		Status:      "completed",
+		Conclusion:  conclusion,
+		StartedAt:   &start,
+		CompletedAt: &end,
+		Steps: []*WorkflowStep{
+			{Number: 1, Name: "Run tests", Conclusion: conclusion, StartedAt: &start, CompletedAt: &end},
+		},
+	}
+}
+
+func TestAnalyzeWorkflowRuns(t *testing.T) {
+	base := time.Date(2024, 5, 1, 10, 0, 0, 0, time.UTC)
+	run := func(id int64, day int, conclusion string) *WorkflowRun {
+		return &WorkflowRun{ID: id, Status: "completed", Conclusion: conclusion, CreatedAt: base.AddDate(0, 0, day)}
+	}
+
+	runs := []*WorkflowRun{
+		run(1, 0, "success"),
+		run(2, 1, "failure"),
+		run(3, 2, "success"),
+		run(4, 3, "success"),
+		{ID: 5, Status: "in_progress", CreatedAt: base.AddDate(0, 0, 4)},
+	}
+	jobs := map[int64][]*WorkflowJob{
+		1: {testJob("test", 1, "success", base, time.Minute)},
+		2: {testJob("test", 1, "failure", base, time.Minute)},
+		3: {
+			testJob("test", 1, "failure", base, 2*time.Minute),
+			testJob("test", 2, "success", base, 2*time.Minute),
+		},
+		4: {testJob("test", 1, "success", base, time.Minute), testJob("lint", 1, "skipped", base, 0)},
+	}
+
+	stats := AnalyzeWorkflowRuns(runs, jobs)
+	if stats.Runs != 4 || stats.Failures != 1 || stats.Flaky != 1 {
+		t.Fatalf("Unexpected run totals: %+v", stats)
+	}
+	if len(stats.Jobs) != 1 {
+		t.Fatalf("Expected only the test job, got %+v", stats.Jobs)
+	}
+
+	job := stats.Jobs[0]
+	if job.Runs != 4 || job.Failures != 1 || job.Flaky != 1 {
+		t.Errorf("Unexpected job counts: %+v", job)
+	}
+	if job.P50 != time.Minute || job.P95 != 2*time.Minute {
+		t.Errorf("Expected p50 1m and p95 2m, got %s and %s", job.P50, job.P95)
+	}
+	if job.Trend == nil || *job.Trend != 1 {
+		t.Errorf("Expected the median to double, got %v", job.Trend)
+	}
+
+	if len(stats.Steps) != 1 || stats.Steps[0].Runs != 4 || stats.Steps[0].Failures != 1 {
+		t.Errorf("Unexpected step stats: %+v", stats.Steps)
+	}
+
+	table := stats.Table(10)
+	for _, want := range []string{"Runs: 4", "flaky 1", "JOB", "test", "25.0%", "+100%", "Run tests"} {
+		if !strings.Contains(table, want) {
+			t.Errorf("Expected table to contain %q:\n%s", want, table)
+		}
+	}
+	for _, maxSteps := range []int{0, -1} {
+		if table := stats.Table(maxSteps); strings.Contains(table, "Run tests") {
+			t.Errorf("Expected no step table for maxSteps %d:\n%s", maxSteps, table)
+		}
+	}
+}
+
+func TestPercentile(t *testing.T) {
+	durations := []time.Duration{5, 1, 4, 2, 3}
+	if got := percentile(durations, 0.5); got != 3 {
+		t.Errorf("Expected p50 3, got %d", got)
+	}
+	if got := percentile(durations, 0.95); got != 5 {
+		t.Errorf("Expected p95 5, got %d", got)
+	}
+	if got := percentile(nil, 0.5); got != 0 {
+		t.Errorf("Expected 0 for no samples, got %d", got)
+	}
+}
@@ -0,0 +1,327 @@
+package github
+
+import (
+	"context"
+	"crypto"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"fmt"
+	"net/http"
+	"os"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	// appJWTLifetime is how long an app JWT is valid (GitHub allows at most 10 minutes)
+	appJWTLifetime = 9 * time.Minute
+	// appJWTClockSkew backdates the issued-at claim to tolerate clock drift
+	appJWTClockSkew = 60 * time.Second
+	// installationTokenRefreshWindow is how long before expiry a cached token is renewed
+	installationTokenRefreshWindow = 5 * time.Minute
+)
+
+// InstallationToken represents a short-lived GitHub App installation token
+type InstallationToken struct {
+	Token     string    `json:"token"`
+	ExpiresAt time.Time `json:"expires_at"`
+}
+
+// AppAuth authorizes requests as a GitHub App installation. Installation
+// tokens are minted on demand, cached, and refreshed shortly before they expire.
+type AppAuth struct {
+	// AppID is the numeric GitHub App ID
+	AppID int64
+
+	// InstallationID is the default installation to act as. When zero, the
+	// installation is looked up from the repository being requested.
+	InstallationID int64
+
+	key    *rsa.PrivateKey
+	client *Client
+	now    func() time.Time
+
+	mu                sync.Mutex
+	tokens            map[int64]*InstallationToken
+	minting           map[int64]*tokenMint
+	repoInstallations map[string]int64
+}
+
+// tokenMint is an installation token request in flight, shared by every
+// caller that needs the token meanwhile
+type tokenMint struct {
+	done  chan struct{}
+	token *InstallationToken
+	err   error
+}
+
+// NewAppAuth creates a new AppAuth from a PEM encoded RSA private key
+func NewAppAuth(appID int64, privateKeyPEM []byte, installationID int64) (*AppAuth, error) {
+	if appID <= 0 {
+		return nil, fmt.Errorf("app ID must be a positive integer")
+	}
+
+	key, err := parseRSAPrivateKey(privateKeyPEM)
+	if err != nil {
+		return nil, err
+	}
+
+	a := &AppAuth{
+		AppID:             appID,
+		InstallationID:    installationID,
+		key:               key,
+		now:               time.Now,
+		tokens:            make(map[int64]*InstallationToken),
+		minting:           make(map[int64]*tokenMint),
+		repoInstallations: make(map[string]int64),
+	}
+	a.client = NewClientWithAuth(appJWTAuth{a})
+
+	return a, nil
+}
+
+// NewAppAuthFromFile creates a new AppAuth from a private key file
+func NewAppAuthFromFile(appID int64, keyPath string, installationID int64) (*AppAuth, error) {
+	data, err := os.ReadFile(keyPath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read app private key: %w", err)
+	}
+
+	return NewAppAuth(appID, data, installationID)
+}
+
+// SetBaseURL sets the API base URL used to mint installation tokens
+func (a *AppAuth) SetBaseURL(baseURL string) {
+	a.client.SetBaseURL(baseURL)
+}
+
+// JWT returns a signed RS256 JSON Web Token identifying the app
+func (a *AppAuth) JWT() (string, error) {
+	now := a.now()
+
+	header := map[string]string{
+		"alg": "RS256",
+		"typ": "JWT",
+	}
+	claims := map[string]interface{}{
+		"iat": now.Add(-appJWTClockSkew).Unix(),
+		"exp": now.Add(appJWTLifetime).Unix(),
+		"iss": fmt.Sprintf("%d", a.AppID),
+	}
+
+	headerJSON, err := json.Marshal(header)
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal JWT header: %w", err)
+	}
+	claimsJSON, err := json.Marshal(claims)
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal JWT claims: %w", err)
+	}
+
+	signingInput := base64.RawURLEncoding.EncodeToString(headerJSON) + "." +
+		base64.RawURLEncoding.EncodeToString(claimsJSON)
+
+	digest := sha256.Sum256([]byte(signingInput))
+	signature, err := rsa.SignPKCS1v15(rand.Reader, a.key, crypto.SHA256, digest[:])
+	if err != nil {
+		return "", fmt.Errorf("failed to sign JWT: %w", err)
+	}
+
+	return signingInput + "." + base64.RawURLEncoding.EncodeToString(signature), nil
+}
+
+// InstallationToken returns a valid token for the installation, minting a
+// new one when none is cached or the cached one is about to expire. The
+// lock is not held while minting, and concurrent callers for the same
+// installation share one request.
+func (a *AppAuth) InstallationToken(ctx context.Context, installationID int64) (string, error) {
+	a.mu.Lock()
+	if token, ok := a.tokens[installationID]; ok && a.now().Add(installationTokenRefreshWindow).Before(token.ExpiresAt) {
+		a.mu.Unlock()
+		return token.Token, nil
+	}
+	mint, inFlight := a.minting[installationID]
+	if !inFlight {
+		mint = &tokenMint{done: make(chan struct{})}
+		a.minting[installationID] = mint
+	}
+	a.mu.Unlock()
+
+	if !inFlight {
+		// Detach from the caller's cancellation, since other callers may
+		// be waiting on this request
+		mint.token, mint.err = a.mintInstallationToken(context.WithoutCancel(ctx), installationID)
+
+		a.mu.Lock()
+		if mint.err == nil {
+			a.tokens[installationID] = mint.token
+		}
+		delete(a.minting, installationID)
+		a.mu.Unlock()
+		close(mint.done)
+	}
+
+	select {
+	case <-mint.done:
+	case <-ctx.Done():
+		return "", ctx.Err()
+	}
+	if mint.err != nil {
+		return "", mint.err
+	}
+	return mint.token.Token, nil
+}
+
+// mintInstallationToken creates a new installation token
+func (a *AppAuth) mintInstallationToken(ctx context.Context, installationID int64) (*InstallationToken, error) {
+	url := fmt.Sprintf("app/installations/%d/access_tokens", installationID)
+
+	req, err := a.client.newRequest(ctx, "POST", url, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := a.client.do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create installation token: %w", err)
+	}
+	defer resp.Body.Close()
+
+	var token InstallationToken
+	if err := json.NewDecoder(resp.Body).Decode(&token); err != nil {
+		return nil, fmt.Errorf("failed to decode response: %w", err)
+	}
+
+	return &token, nil
+}
+
+// RepoInstallationID returns the ID of the app installation that covers a repository
+func (a *AppAuth) RepoInstallationID(ctx context.Context, owner, repo string) (int64, error) {
+	key := strings.ToLower(owner + "/" + repo)
+
+	a.mu.Lock()
+	id, ok := a.repoInstallations[key]
+	a.mu.Unlock()
+	if ok {
+		return id, nil
+	}
+
+	url := fmt.Sprintf("repos/%s/%s/installation", owner, repo)
+
+	req, err := a.client.newRequest(ctx, "GET", url, nil)
+	if err != nil {
+		return 0, err
+	}
+
+	resp, err := a.client.do(req)
+	if err != nil {
+		return 0, fmt.Errorf("failed to find installation for %s/%s: %w", owner, repo, err)
+	}
+	defer resp.Body.Close()
+
+	var installation struct {
+		ID int64 `json:"id"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&installation); err != nil {
+		return 0, fmt.Errorf("failed to decode response: %w", err)
+	}
+
+	a.mu.Lock()
+	a.repoInstallations[key] = installation.ID
+	a.mu.Unlock()
+
+	return installation.ID, nil
+}
+
+// RepoInstallationToken returns a valid installation token for a repository
+func (a *AppAuth) RepoInstallationToken(ctx context.Context, owner, repo string) (string, error) {
+	id, err := a.RepoInstallationID(ctx, owner, repo)
+	if err != nil {
+		return "", err
+	}
+
+	return a.InstallationToken(ctx, id)
+}
+
+// Authorize sets an installation token on the request. The configured
+// installation is used when set, otherwise the installation is resolved
+// from the repository in the request path.
+func (a *AppAuth) Authorize(req *http.Request) error {
+	ctx := req.Context()
+
+	var token string
+	var err error
+	if a.InstallationID != 0 {
+		token, err = a.InstallationToken(ctx, a.InstallationID)
+	} else if owner, repo, ok := repoFromPath(req.URL.Path); ok {
+		token, err = a.RepoInstallationToken(ctx, owner, repo)
+	} else {
+		return fmt.Errorf("an installation ID is required for requests outside a repository")
+	}
+	if err != nil {
+		return err
+	}
+
+	req.Header.Set("Authorization", "token "+token)
+	return nil
+}
+
+// appJWTAuth authorizes requests with the app JWT, as required by the app endpoints
+type appJWTAuth struct {
+	app *AppAuth
+}
+
+// Authorize sets the bearer JWT authorization header
+func (j appJWTAuth) Authorize(req *http.Request) error {
+	jwt, err := j.app.JWT()
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Authorization", "Bearer "+jwt)
+	return nil
+}
+
+// repoFromPath extracts the owner and repository from a repos/{owner}/{repo} API path
+func repoFromPath(p string) (string, string, bool) {
+	idx := strings.Index(p, "/repos/")
+	if idx < 0 {
+		return "", "", false
+	}
+
+	parts := strings.SplitN(p[idx+len("/repos/"):], "/", 3)
+	if len(parts) < 2 || parts[0] == "" || parts[1] == "" {
+		return "", "", false
+	}
+
+	return parts[0], parts[1], true
+}
+
+// parseRSAPrivateKey parses a PKCS#1 or PKCS#8 PEM encoded RSA private key
+func parseRSAPrivateKey(data []byte) (*rsa.PrivateKey, error) {
+	block, _ := pem.Decode(data)
+	if block == nil {
+		return nil, fmt.Errorf("failed to decode app private key: no PEM data found")
+	}
+
+	if key, err := x509.ParsePKCS1PrivateKey(block.Bytes); err == nil {
+		return key, nil
+	}
+
+	parsed, err := x509.ParsePKCS8PrivateKey(block.Bytes)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse app private key: %w", err)
+	}
+
+	key, ok := parsed.(*rsa.PrivateKey)
+	if !ok {
+		return nil, fmt.Errorf("app private key is not an RSA key")
+	}
+
+	return key, nil
+}
@@ -0,0 +1,213 @@
+package github
+
+import (
+	"context"
+	"crypto"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func setupTestApp(t *testing.T, installationID int64) (*AppAuth, *rsa.PrivateKey) {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatalf("Failed to generate key: %v", err)
+	}
+
+	keyPEM := pem.EncodeToMemory(&pem.Block{
+		Type:  "RSA PRIVATE KEY",
+		Bytes: x509.MarshalPKCS1PrivateKey(key),
+	})
+
+	app, err := NewAppAuth(1234, keyPEM, installationID)
+	if err != nil {
+		t.Fatalf("NewAppAuth failed: %v", err)
+	}
+
+	return app, key
+}
+
+func verifyJWT(t *testing.T, jwt string, key *rsa.PrivateKey) map[string]interface{} {
+	parts := strings.Split(jwt, ".")
+	if len(parts) != 3 {
+		t.Fatalf("Expected 3 JWT segments, got %d", len(parts))
+	}
+
+	signature, err := base64.RawURLEncoding.DecodeString(parts[2])
+	if err != nil {
+		t.Fatalf("Failed to decode signature: %v", err)
+	}
+
+	digest := sha256.Sum256([]byte(parts[0] + "." + parts[1]))
+	if err := rsa.VerifyPKCS1v15(&key.PublicKey, crypto.SHA256, digest[:], signature); err != nil {
+		t.Fatalf("JWT signature did not verify: %v", err)
+	}
+
+	claimsJSON, err := base64.RawURLEncoding.DecodeString(parts[1])
+	if err != nil {
+		t.Fatalf("Failed to decode claims: %v", err)
+	}
+
+	var claims map[string]interface{}
+	if err := json.Unmarshal(claimsJSON, &claims); err != nil {
+		t.Fatalf("Failed to unmarshal claims: %v", err)
+	}
+
+	return claims
+}
+
+func TestAppAuth_JWT(t *testing.T) {
+	app, key := setupTestApp(t, 0)
+
+	jwt, err := app.JWT()
+	if err != nil {
+		t.Fatalf("JWT failed: %v", err)
+	}
+
+	claims := verifyJWT(t, jwt, key)
+	if claims["iss"] != "1234" {
+		t.Errorf("Expected iss 1234, got %v", claims["iss"])
+	}
+
+	iat, exp := claims["iat"].(float64), claims["exp"].(float64)
+	if lifetime := time.Duration(exp-iat) * time.Second; lifetime > 10*time.Minute+appJWTClockSkew {
+		t.Errorf("JWT lifetime %v exceeds GitHub's limit", lifetime)
+	}
+}
+
+func TestAppAuth_InstallationTokenCaching(t *testing.T) {
+	app, key := setupTestApp(t, 42)
+
+	var mints int32
+	expiry := time.Now().Add(time.Hour)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != "POST" || r.URL.Path != "/app/installations/42/access_tokens" {
+			http.NotFound(w, r)
+			return
+		}
+		verifyJWT(t, strings.TrimPrefix(r.Header.Get("Authorization"), "Bearer "), key)
+
+		n := atomic.AddInt32(&mints, 1)
+		json.NewEncoder(w).Encode(InstallationToken{
+			Token:     fmt.Sprintf("ghs_%d", n),
+			ExpiresAt: expiry,
+		})
+	}))
+	defer srv.Close()
+	app.SetBaseURL(srv.URL)
+
+	ctx := context.Background()
+	for i := 0; i < 3; i++ {
+		token, err := app.InstallationToken(ctx, 42)
+		if err != nil {
+			t.Fatalf("InstallationToken failed: %v", err)
+		}
+		if token != "ghs_1" {
+			t.Errorf("Expected cached token ghs_1, got %s", token)
+		}
+	}
+
+	// Move the clock into the refresh window
+	app.now = func() time.Time { return expiry.Add(-time.Minute) }
+	token, err := app.InstallationToken(ctx, 42)
+	if err != nil {
+		t.Fatalf("InstallationToken failed: %v", err)
+	}
+	if token != "ghs_2" {
+		t.Errorf("Expected refreshed token ghs_2, got %s", token)
+	}
+}
+
+func TestAppAuth_InstallationTokenConcurrent(t *testing.T) {
+	app, _ := setupTestApp(t, 0)
+
+	// Installation 1 mints slowly; installation 2 must not wait for it
+	var mints int32
+	release := make(chan struct{})
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/app/installations/1/access_tokens" {
+			atomic.AddInt32(&mints, 1)
+			<-release
+		}
+		json.NewEncoder(w).Encode(InstallationToken{Token: "ghs_" + r.URL.Path, ExpiresAt: time.Now().Add(time.Hour)})
+	}))
+	defer srv.Close()
+	app.SetBaseURL(srv.URL)
+
+	ctx := context.Background()
+	errs := make(chan error, 3)
+	for i := 0; i < 3; i++ {
+		go func() {
+			_, err := app.InstallationToken(ctx, 1)
+			errs <- err
+		}()
+	}
+
+	done := make(chan error, 1)
+	go func() {
+		_, err := app.InstallationToken(ctx, 2)
+		done <- err
+	}()
+	select {
+	case err := <-done:
+		if err != nil {
+			t.Fatalf("InstallationToken failed: %v", err)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("Installation 2 waited for installation 1's token")
+	}
+
+	close(release)
+	for i := 0; i < 3; i++ {
+		if err := <-errs; err != nil {
+			t.Errorf("InstallationToken failed: %v", err)
+		}
+	}
+	if n := atomic.LoadInt32(&mints); n != 1 {
+		t.Errorf("Expected concurrent callers to share one mint, got %d", n)
+	}
+}
+
+func TestAppAuth_AuthorizeResolvesRepoInstallation(t *testing.T) {
+	app, _ := setupTestApp(t, 0)
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == "GET" && r.URL.Path == "/repos/octo/hello/installation":
+			fmt.Fprint(w, `{"id": 7}`)
+		case r.Method == "POST" && r.URL.Path == "/app/installations/7/access_tokens":
+			json.NewEncoder(w).Encode(InstallationToken{
+				Token:     "ghs_repo",
+				ExpiresAt: time.Now().Add(time.Hour),
+			})
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer srv.Close()
+	app.SetBaseURL(srv.URL)
+
+	req, _ := http.NewRequest("GET", srv.URL+"/repos/octo/hello/pulls?state=open", nil)
+	if err := app.Authorize(req); err != nil {
+		t.Fatalf("Authorize failed: %v", err)
+	}
+	if got := req.Header.Get("Authorization"); got != "token ghs_repo" {
+		t.Errorf("Expected installation token header, got %q", got)
+	}
+
+	req, _ = http.NewRequest("GET", srv.URL+"/user", nil)
+	if err := app.Authorize(req); err == nil {
+		t.Error("Expected error authorizing a non-repository request without an installation ID")
+	}
+}
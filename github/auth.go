@@ -0,0 +1,109 @@
+package github
+
+import (
+	"context"
+	"net/http"
+)
+
+// AuthProvider authorizes outgoing GitHub API requests
+type AuthProvider interface {
+	// Authorize sets the authentication headers on the request
+	Authorize(req *http.Request) error
+}
+
+// TokenProvider supplies the token to use for a request. An empty token
+// means the provider has no credentials to offer.
+type TokenProvider interface {
+	// Token returns the current token
+	Token(ctx context.Context) (string, error)
+}
+
+// BaseURLProvider is implemented by auth providers whose credentials
+// belong to a specific GitHub host. An empty URL means the client default.
+type BaseURLProvider interface {
+	// BaseURL returns the API base URL to use for the request
+	BaseURL(ctx context.Context) (string, error)
+}
+
+// TokenAuth authorizes requests with a static personal access token
+type TokenAuth string
+
+// Authorize sets the token authorization header. An empty token leaves
+// the request unauthenticated.
+func (t TokenAuth) Authorize(req *http.Request) error {
+	if t != "" {
+		req.Header.Set("Authorization", "token "+string(t))
+	}
+	return nil
+}
+
+// Token returns the static token
+func (t TokenAuth) Token(ctx context.Context) (string, error) {
+	return string(t), nil
+}
+
+// ChainTokenProvider returns the first non-empty token from its providers,
+// in order of precedence
+type ChainTokenProvider []TokenProvider
+
+// Token returns the token of the first provider that has one
+func (c ChainTokenProvider) Token(ctx context.Context) (string, error) {
+	for _, provider := range c {
+		token, err := provider.Token(ctx)
+		if err != nil {
+			return "", err
+		}
+		if token != "" {
+			return token, nil
+		}
+	}
+	return "", nil
+}
+
+// BaseURL returns the base URL of the provider whose token is used
+func (c ChainTokenProvider) BaseURL(ctx context.Context) (string, error) {
+	for _, provider := range c {
+		token, err := provider.Token(ctx)
+		if err != nil {
+			return "", err
+		}
+		if token == "" {
+			continue
+		}
+		if urlProvider, ok := provider.(BaseURLProvider); ok {
+			return urlProvider.BaseURL(ctx)
+		}
+		return "", nil
+	}
+	return "", nil
+}
+
+// TokenProviderAuth authorizes each request with the token currently
+// supplied by Provider, so credential changes take effect immediately.
+// When Provider has no token, Fallback (if set) authorizes the request.
+type TokenProviderAuth struct {
+	Provider TokenProvider
+	Fallback AuthProvider
+}
+
+// Authorize resolves the token for this request and sets the authorization header
+func (a *TokenProviderAuth) Authorize(req *http.Request) error {
+	token, err := a.Provider.Token(req.Context())
+	if err != nil {
+		return err
+	}
+
+	if token == "" && a.Fallback != nil {
+		return a.Fallback.Authorize(req)
+	}
+
+	return TokenAuth(token).Authorize(req)
+}
+
+// BaseURL returns the base URL of the token provider, if it has one
+func (a *TokenProviderAuth) BaseURL(ctx context.Context) (string, error) {
+	if urlProvider, ok := a.Provider.(BaseURLProvider); ok {
+		return urlProvider.BaseURL(ctx)
+	}
+	return "", nil
+}
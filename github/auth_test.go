@@ -0,0 +1,119 @@
+package github
+
+import (
+	"context"
+	"net/http"
+	"testing"
+)
+
+// mutableToken is a TokenProvider whose token can change between requests
+type mutableToken struct {
+	token string
+}
+
+func (m *mutableToken) Token(ctx context.Context) (string, error) {
+	return m.token, nil
+}
+
+func TestChainTokenProvider(t *testing.T) {
+	stored := &mutableToken{}
+	chain := ChainTokenProvider{stored, TokenAuth("flag-token")}
+
+	token, err := chain.Token(context.Background())
+	if err != nil {
+		t.Fatalf("Token failed: %v", err)
+	}
+	if token != "flag-token" {
+		t.Errorf("Expected fallback to flag-token, got %q", token)
+	}
+
+	stored.token = "stored-token"
+	token, _ = chain.Token(context.Background())
+	if token != "stored-token" {
+		t.Errorf("Expected stored-token to take precedence, got %q", token)
+	}
+}
+
+func TestTokenProviderAuth_ResolvesPerRequest(t *testing.T) {
+	stored := &mutableToken{}
+	auth := &TokenProviderAuth{
+		Provider: ChainTokenProvider{stored},
+		Fallback: TokenAuth("app-token"),
+	}
+
+	tests := []struct {
+		name   string
+		stored string
+		want   string
+	}{
+		{name: "fallback when logged out", stored: "", want: "token app-token"},
+		{name: "stored token after login", stored: "ghp_login", want: "token ghp_login"},
+		{name: "fallback after logout", stored: "", want: "token app-token"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			stored.token = tt.stored
+
+			req, _ := http.NewRequest("GET", "https://api.github.com/user", nil)
+			if err := auth.Authorize(req); err != nil {
+				t.Fatalf("Authorize failed: %v", err)
+			}
+			if got := req.Header.Get("Authorization"); got != tt.want {
+				t.Errorf("Expected %q, got %q", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestTokenProviderAuth_Unauthenticated(t *testing.T) {
+	auth := &TokenProviderAuth{Provider: ChainTokenProvider{}}
+
+	req, _ := http.NewRequest("GET", "https://api.github.com/user", nil)
+	if err := auth.Authorize(req); err != nil {
+		t.Fatalf("Authorize failed: %v", err)
+	}
+	if got := req.Header.Get("Authorization"); got != "" {
+		t.Errorf("Expected no authorization header, got %q", got)
+	}
+}
+
+// hostToken is a TokenProvider whose credentials belong to a specific host
+type hostToken struct {
+	mutableToken
+	baseURL string
+}
+
+func (h *hostToken) BaseURL(ctx context.Context) (string, error) {
+	return h.baseURL, nil
+}
+
+func TestClient_BaseURLProvider(t *testing.T) {
+	enterprise := &hostToken{baseURL: "https://ghe.example.com/api/v3"}
+	client := NewClientWithAuth(&TokenProviderAuth{
+		Provider: ChainTokenProvider{enterprise, TokenAuth("flag-token")},
+	})
+
+	tests := []struct {
+		name  string
+		token string
+		want  string
+	}{
+		{name: "default host without a stored token", token: "", want: "https://api.github.com/user"},
+		{name: "provider host with a stored token", token: "ghp_ghe", want: "https://ghe.example.com/api/v3/user"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			enterprise.token = tt.token
+
+			req, err := client.newRequest(context.Background(), "GET", "user", nil)
+			if err != nil {
+				t.Fatalf("newRequest failed: %v", err)
+			}
+			if got := req.URL.String(); got != tt.want {
+				t.Errorf("Expected %q, got %q", tt.want, got)
+			}
+		})
+	}
+}
@@ -0,0 +1,177 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+)
+
+// Branch represents a repository branch
+type Branch struct {
+	Name   string `json:"name"`
+	Commit struct {
+		SHA string `json:"sha"`
+		URL string `json:"url"`
+	} `json:"commit"`
+	Protected bool `json:"protected"`
+}
+
+// ListBranchesOptions represents options for listing branches
+type ListBranchesOptions struct {
+	// Protected only lists protected branches when true
+	Protected bool `json:"protected,omitempty"`
+
+	ListOptions
+}
+
+// ListBranches lists the branches of a repository
+func (c *Client) ListBranches(ctx context.Context, owner, repo string, opts *ListBranchesOptions) ([]*Branch, error) {
+	url := fmt.Sprintf("repos/%s/%s/branches", owner, repo)
+	if opts != nil {
+		if opts.Protected {
+			url += "?protected=true"
+		}
+		url = addListOptions(url, &opts.ListOptions)
+	}
+
+	req, err := c.newRequest(ctx, "GET", url, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := c.do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	var branches []*Branch
+	if err := json.NewDecoder(resp.Body).Decode(&branches); err != nil {
+		return nil, fmt.Errorf("failed to decode response: %w", err)
+	}
+
+	return branches, nil
+}
+
+// GetBranch gets a branch
+func (c *Client) GetBranch(ctx context.Context, owner, repo, branch string) (*Branch, error) {
+	url := fmt.Sprintf("repos/%s/%s/branches/%s", owner, repo, branch)
+
+	req, err := c.newRequest(ctx, "GET", url, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := c.do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	var result Branch
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return nil, fmt.Errorf("failed to decode response: %w", err)
+	}
+
+	return &result, nil
+}
+
+// CreateBranch creates a branch pointing at a commit
+func (c *Client) CreateBranch(ctx context.Context, owner, repo, branch, sha string) (*Reference, error) {
+	return c.CreateRef(ctx, owner, repo, "refs/heads/"+branch, sha)
+}
+
+// DeleteBranch deletes a branch
+func (c *Client) DeleteBranch(ctx context.Context, owner, repo, branch string) error {
+	return c.DeleteRef(ctx, owner, repo, "heads/"+branch)
+}
+
+// RenameBranch renames a branch. GitHub also retargets open pull requests
+// and updates branch protection that names the branch explicitly.
+func (c *Client) RenameBranch(ctx context.Context, owner, repo, branch, newName string) (*Branch, error) {
+	url := fmt.Sprintf("repos/%s/%s/branches/%s/rename", owner, repo, branch)
+	body := map[string]string{"new_name": newName}
+
+	req, err := c.newRequest(ctx, "POST", url, body)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := c.do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	var result Branch
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return nil, fmt.Errorf("failed to decode response: %w", err)
+	}
+
+	return &result, nil
+}
+
+// ResolveCommitSHA resolves a branch, tag or (abbreviated) commit SHA to a
+// full commit SHA
+func (c *Client) ResolveCommitSHA(ctx context.Context, owner, repo, ref string) (string, error) {
+	url := fmt.Sprintf("repos/%s/%s/commits/%s", owner, repo, ref)
+
+	req, err := c.newRequest(ctx, "GET", url, nil)
+	if err != nil {
+		return "", err
+	}
+
+	resp, err := c.do(req)
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+
+	var commit struct {
+		SHA string `json:"sha"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&commit); err != nil {
+		return "", fmt.Errorf("failed to decode response: %w", err)
+	}
+
+	return commit.SHA, nil
+}
+
+// ListOrganizationRepositories lists the repositories of an organization
+func (c *Client) ListOrganizationRepositories(ctx context.Context, org string, opts *ListOptions) ([]*Repository, error) {
+	url := addListOptions(fmt.Sprintf("orgs/%s/repos", org), opts)
+
+	req, err := c.newRequest(ctx, "GET", url, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := c.do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	var repos []*Repository
+	if err := json.NewDecoder(resp.Body).Decode(&repos); err != nil {
+		return nil, fmt.Errorf("failed to decode response: %w", err)
+	}
+
+	return repos, nil
+}
+
+// ListAllOrganizationRepositories lists every repository of an
+// organization, following pagination
+func (c *Client) ListAllOrganizationRepositories(ctx context.Context, org string) ([]*Repository, error) {
+	var repos []*Repository
+	for page := 1; ; page++ {
+		pageRepos, err := c.ListOrganizationRepositories(ctx, org, &ListOptions{Page: page, PerPage: 100})
+		if err != nil {
+			return nil, err
+		}
+		repos = append(repos, pageRepos...)
+		if len(pageRepos) < 100 {
+			return repos, nil
+		}
+	}
+}
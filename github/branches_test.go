@@ -0,0 +1,85 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"testing"
+)
+
+func TestCreateBranch(t *testing.T) {
+	client := setupTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != "POST" || r.URL.Path != "/repos/octo/hello/git/refs" {
+			t.Errorf("Unexpected request %s %s", r.Method, r.URL.Path)
+		}
+		var body map[string]string
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			t.Fatalf("Failed to decode body: %v", err)
+		}
+		if body["ref"] != "refs/heads/feature/login" || body["sha"] != "abc123" {
+			t.Errorf("Unexpected body: %v", body)
+		}
+		w.WriteHeader(http.StatusCreated)
+		fmt.Fprint(w, `{"ref": "refs/heads/feature/login", "object": {"type": "commit", "sha": "abc123"}}`)
+	})
+
+	ref, err := client.CreateBranch(context.Background(), "octo", "hello", "feature/login", "abc123")
+	if err != nil {
+		t.Fatalf("CreateBranch failed: %v", err)
+	}
+	if ref.Object.SHA != "abc123" {
+		t.Errorf("Unexpected ref: %+v", ref)
+	}
+}
+
+func TestRenameBranch(t *testing.T) {
+	client := setupTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != "POST" || r.URL.Path != "/repos/octo/hello/branches/master/rename" {
+			t.Errorf("Unexpected request %s %s", r.Method, r.URL.Path)
+		}
+		w.WriteHeader(http.StatusCreated)
+		fmt.Fprint(w, `{"name": "main", "commit": {"sha": "abc123"}, "protected": true}`)
+	})
+
+	branch, err := client.RenameBranch(context.Background(), "octo", "hello", "master", "main")
+	if err != nil {
+		t.Fatalf("RenameBranch failed: %v", err)
+	}
+	if branch.Name != "main" || branch.Commit.SHA != "abc123" || !branch.Protected {
+		t.Errorf("Unexpected branch: %+v", branch)
+	}
+}
+
+func TestDiffRulesets(t *testing.T) {
+	current := &Ruleset{
+		Name:        "main",
+		Enforcement: "evaluate",
+		Rules: []*RulesetRule{
+			{Type: "deletion"},
+			{Type: "pull_request", Parameters: map[string]interface{}{"required_approving_review_count": 1}},
+		},
+	}
+	desired := &Ruleset{
+		Name:        "main",
+		Enforcement: "active",
+		Rules: []*RulesetRule{
+			{Type: "pull_request", Parameters: map[string]interface{}{"required_approving_review_count": 1}},
+			{Type: "non_fast_forward"},
+		},
+	}
+
+	changes := DiffRulesets(current, desired)
+	want := []string{"enforcement", "rule deletion", "rule non_fast_forward"}
+	if len(changes) != len(want) {
+		t.Fatalf("Unexpected changes: %+v", changes)
+	}
+	for i, change := range changes {
+		if change.Setting != want[i] {
+			t.Errorf("Expected change %d to be %s, got %s", i, want[i], change.Setting)
+		}
+	}
+	if changes[1].Desired != "absent" || changes[2].Current != "absent" {
+		t.Errorf("Expected removed and added rules: %+v %+v", changes[1], changes[2])
+	}
+}
@@ -0,0 +1,188 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	neturl "net/url"
+	"time"
+)
+
+// ActionsCache represents an entry in a repository's Actions cache
+type ActionsCache struct {
+	ID             int64     `json:"id"`
+	Ref            string    `json:"ref"`
+	Key            string    `json:"key"`
+	Version        string    `json:"version"`
+	SizeInBytes    int64     `json:"size_in_bytes"`
+	LastAccessedAt time.Time `json:"last_accessed_at"`
+	CreatedAt      time.Time `json:"created_at"`
+}
+
+// ListCachesOptions represents filters for listing Actions caches
+type ListCachesOptions struct {
+	// Key matches caches whose key starts with it
+	Key string `json:"key,omitempty"`
+
+	// Ref is the full Git reference, e.g. refs/heads/main or
+	// refs/pull/42/merge
+	Ref string `json:"ref,omitempty"`
+
+	// Sort is created_at, last_accessed_at or size_in_bytes
+	Sort      string `json:"sort,omitempty"`
+	Direction string `json:"direction,omitempty"`
+
+	ListOptions
+}
+
+// CacheUsage is the Actions cache usage of a repository
+type CacheUsage struct {
+	FullName                string `json:"full_name"`
+	ActiveCachesSizeInBytes int64  `json:"active_caches_size_in_bytes"`
+	ActiveCachesCount       int    `json:"active_caches_count"`
+}
+
+// ListCaches lists the Actions caches of a repository
+func (c *Client) ListCaches(ctx context.Context, owner, repo string, opts *ListCachesOptions) ([]*ActionsCache, int, error) {
+	url := fmt.Sprintf("repos/%s/%s/actions/caches", owner, repo)
+
+	if opts != nil {
+		params := neturl.Values{}
+		for name, value := range map[string]string{
+			"key":       opts.Key,
+			"ref":       opts.Ref,
+			"sort":      opts.Sort,
+			"direction": opts.Direction,
+		} {
+			if value != "" {
+				params.Set(name, value)
+			}
+		}
+		if len(params) > 0 {
+			url += "?" + params.Encode()
+		}
+		url = addListOptions(url, &opts.ListOptions)
+	}
+
+	req, err := c.newRequest(ctx, "GET", url, nil)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	resp, err := c.do(req)
+	if err != nil {
+		return nil, 0, err
+	}
+	defer resp.Body.Close()
+
+	var response struct {
+		TotalCount    int             `json:"total_count"`
+		ActionsCaches []*ActionsCache `json:"actions_caches"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&response); err != nil {
+		return nil, 0, fmt.Errorf("failed to decode response: %w", err)
+	}
+
+	return response.ActionsCaches, response.TotalCount, nil
+}
+
+// ListAllCaches lists every Actions cache of a repository matching the key
+// prefix and ref, following pagination
+func (c *Client) ListAllCaches(ctx context.Context, owner, repo, key, ref string) ([]*ActionsCache, error) {
+	var caches []*ActionsCache
+	for page := 1; ; page++ {
+		opts := &ListCachesOptions{Key: key, Ref: ref, ListOptions: ListOptions{Page: page, PerPage: 100}}
+		pageCaches, total, err := c.ListCaches(ctx, owner, repo, opts)
+		if err != nil {
+			return nil, err
+		}
+		caches = append(caches, pageCaches...)
+		if len(pageCaches) == 0 || len(caches) >= total {
+			return caches, nil
+		}
+	}
+}
+
+// DeleteCache deletes an Actions cache by ID
+func (c *Client) DeleteCache(ctx context.Context, owner, repo string, cacheID int64) error {
+	url := fmt.Sprintf("repos/%s/%s/actions/caches/%d", owner, repo, cacheID)
+
+	req, err := c.newRequest(ctx, "DELETE", url, nil)
+	if err != nil {
+		return err
+	}
+
+	resp, err := c.do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	return nil
+}
+
+// GetCacheUsage gets the Actions cache usage of a repository
+func (c *Client) GetCacheUsage(ctx context.Context, owner, repo string) (*CacheUsage, error) {
+	url := fmt.Sprintf("repos/%s/%s/actions/cache/usage", owner, repo)
+
+	req, err := c.newRequest(ctx, "GET", url, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := c.do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	var usage CacheUsage
+	if err := json.NewDecoder(resp.Body).Decode(&usage); err != nil {
+		return nil, fmt.Errorf("failed to decode response: %w", err)
+	}
+
+	return &usage, nil
+}
+
+// ListCacheUsageByRepository lists the Actions cache usage of the
+// repositories of an organization that have active caches
+func (c *Client) ListCacheUsageByRepository(ctx context.Context, org string, opts *ListOptions) ([]*CacheUsage, int, error) {
+	url := addListOptions(fmt.Sprintf("orgs/%s/actions/cache/usage-by-repository", org), opts)
+
+	req, err := c.newRequest(ctx, "GET", url, nil)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	resp, err := c.do(req)
+	if err != nil {
+		return nil, 0, err
+	}
+	defer resp.Body.Close()
+
+	var response struct {
+		TotalCount            int           `json:"total_count"`
+		RepositoryCacheUsages []*CacheUsage `json:"repository_cache_usages"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&response); err != nil {
+		return nil, 0, fmt.Errorf("failed to decode response: %w", err)
+	}
+
+	return response.RepositoryCacheUsages, response.TotalCount, nil
+}
+
+// ListAllCacheUsageByRepository lists the cache usage of every repository of
+// an organization with active caches, following pagination
+func (c *Client) ListAllCacheUsageByRepository(ctx context.Context, org string) ([]*CacheUsage, error) {
+	var usages []*CacheUsage
+	for page := 1; ; page++ {
+		pageUsages, total, err := c.ListCacheUsageByRepository(ctx, org, &ListOptions{Page: page, PerPage: 100})
+		if err != nil {
+			return nil, err
+		}
+		usages = append(usages, pageUsages...)
+		if len(pageUsages) == 0 || len(usages) >= total {
+			return usages, nil
+		}
+	}
+}
@@ -0,0 +1,49 @@
+package github
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"testing"
+)
+
+func TestListCaches(t *testing.T) {
+	client := setupTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/repos/octo/hello/actions/caches" {
+			t.Errorf("Unexpected request path %s", r.URL.Path)
+		}
+		query := r.URL.Query()
+		if query.Get("key") != "node-modules-" || query.Get("ref") != "refs/heads/main" || query.Get("per_page") != "100" {
+			t.Errorf("Unexpected query %s", r.URL.RawQuery)
+		}
+		fmt.Fprint(w, `{"total_count": 1, "actions_caches": [{"id": 505, "key": "node-modules-abc", "ref": "refs/heads/main", "size_in_bytes": 1024}]}`)
+	})
+
+	caches, err := client.ListAllCaches(context.Background(), "octo", "hello", "node-modules-", "refs/heads/main")
+	if err != nil {
+		t.Fatalf("ListAllCaches failed: %v", err)
+	}
+	if len(caches) != 1 || caches[0].ID != 505 || caches[0].SizeInBytes != 1024 {
+		t.Errorf("Unexpected caches: %+v", caches)
+	}
+}
+
+func TestListCacheUsageByRepository(t *testing.T) {
+	client := setupTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/orgs/octo-org/actions/cache/usage-by-repository" {
+			t.Errorf("Unexpected request path %s", r.URL.Path)
+		}
+		fmt.Fprint(w, `{"total_count": 2, "repository_cache_usages": [
+			{"full_name": "octo-org/hello", "active_caches_size_in_bytes": 2048, "active_caches_count": 3},
+			{"full_name": "octo-org/world", "active_caches_size_in_bytes": 1024, "active_caches_count": 1}
+		]}`)
+	})
+
+	usages, err := client.ListAllCacheUsageByRepository(context.Background(), "octo-org")
+	if err != nil {
+		t.Fatalf("ListAllCacheUsageByRepository failed: %v", err)
+	}
+	if len(usages) != 2 || usages[0].FullName != "octo-org/hello" || usages[0].ActiveCachesCount != 3 {
+		t.Errorf("Unexpected usages: %+v", usages)
+	}
+}
@@ -0,0 +1,217 @@
+package github
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+)
+
+// FileChange is a change to one file in a commit
+type FileChange struct {
+	Path string
+
+	// Content is the new content of the file, which may be binary. It may be
+	// nil for a rename that keeps the content.
+	Content []byte
+
+	// Delete removes the file
+	Delete bool
+
+	// PreviousPath renames the file from this path
+	PreviousPath string
+
+	// Mode is the git file mode; the mode of the existing file is kept
+	// when empty, and new files default to ModeFile
+	Mode string
+}
+
+// CommitChangesOptions describes a commit of several file changes
+type CommitChangesOptions struct {
+	Branch  string
+	Message string
+	Changes []*FileChange
+
+	// ExpectedHeadSHA makes the commit fail with a StaleHeadError unless
+	// the branch still points at this commit
+	ExpectedHeadSHA string
+
+	// From creates Branch from this branch, tag or commit if it does not
+	// exist yet
+	From string
+
+	// Author is the commit author; the authenticated user when nil
+	Author *CommitAuthor
+}
+
+// StaleHeadError reports that a branch moved away from the commit the
+// changes were made against
+type StaleHeadError struct {
+	Branch   string
+	Expected string
+	Actual   string
+}
+
+// Error implements the error interface
+func (e *StaleHeadError) Error() string {
+	if e.Actual == "" {
+		return fmt.Sprintf("branch %s moved while committing on top of %s", e.Branch, e.Expected)
+	}
+	return fmt.Sprintf("branch %s is at %s, not at the expected %s", e.Branch, e.Actual, e.Expected)
+}
+
+// CommitChanges adds, modifies, deletes and renames files in a single commit
+// on a branch using blobs, trees, commits and refs. The branch is only
+// fast-forwarded, so concurrent pushes make the commit fail with a
+// StaleHeadError rather than being overwritten.
+func (c *Client) CommitChanges(ctx context.Context, owner, repo string, opts *CommitChangesOptions) (*GitCommit, error) {
+	if len(opts.Changes) == 0 {
+		return nil, fmt.Errorf("no changes to commit")
+	}
+
+	// Find the commit to build on
+	newBranch := false
+	var head string
+	ref, err := c.GetRef(ctx, owner, repo, "heads/"+opts.Branch)
+	switch {
+	case err == nil:
+		head = ref.Object.SHA
+	case IsStatus(err, http.StatusNotFound) && opts.From != "":
+		newBranch = true
+		head, err = c.ResolveCommitSHA(ctx, owner, repo, opts.From)
+		if err != nil {
+			return nil, fmt.Errorf("failed to resolve %s: %w", opts.From, err)
+		}
+	default:
+		return nil, fmt.Errorf("failed to get branch %s: %w", opts.Branch, err)
+	}
+	if opts.ExpectedHeadSHA != "" && opts.ExpectedHeadSHA != head {
+		return nil, &StaleHeadError{Branch: opts.Branch, Expected: opts.ExpectedHeadSHA, Actual: head}
+	}
+
+	parent, err := c.GetCommit(ctx, owner, repo, head)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get commit %s: %w", head, err)
+	}
+	base, err := c.GetTree(ctx, owner, repo, parent.Tree.SHA, true)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get tree: %w", err)
+	}
+
+	entries, err := c.treeEntries(ctx, owner, repo, base, opts.Changes)
+	if err != nil {
+		return nil, err
+	}
+
+	tree, err := c.CreateTree(ctx, owner, repo, base.SHA, entries)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create tree: %w", err)
+	}
+	if tree.SHA == base.SHA {
+		return nil, fmt.Errorf("the changes leave the files of %s unchanged", opts.Branch)
+	}
+
+	commit, err := c.CreateCommit(ctx, owner, repo, opts.Message, tree.SHA, []string{head}, opts.Author)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create commit: %w", err)
+	}
+
+	if newBranch {
+		if _, err := c.CreateRef(ctx, owner, repo, "refs/heads/"+opts.Branch, commit.SHA); err != nil {
+			return nil, fmt.Errorf("failed to create branch %s: %w", opts.Branch, err)
+		}
+		return commit, nil
+	}
+
+	if _, err := c.UpdateRef(ctx, owner, repo, "heads/"+opts.Branch, commit.SHA, false); err != nil {
+		if IsStatus(err, http.StatusUnprocessableEntity) || IsStatus(err, http.StatusConflict) {
+			stale := &StaleHeadError{Branch: opts.Branch, Expected: head}
+			if ref, refErr := c.GetRef(ctx, owner, repo, "heads/"+opts.Branch); refErr == nil && ref.Object.SHA != head {
+				stale.Actual = ref.Object.SHA
+			}
+			return nil, stale
+		}
+		return nil, fmt.Errorf("failed to update branch %s: %w", opts.Branch, err)
+	}
+
+	return commit, nil
+}
+
+// treeEntries turns file changes into the tree entries that apply them to
+// base, uploading new content as blobs
+func (c *Client) treeEntries(ctx context.Context, owner, repo string, base *Tree, changes []*FileChange) ([]*TreeEntry, error) {
+	// existing finds a file of the base tree; without a complete listing
+	// a missing file cannot be told apart from a truncated one
+	existing := func(path string) (*TreeEntry, error) {
+		entry := base.Entry(path)
+		if entry == nil && !base.Truncated {
+			return nil, fmt.Errorf("%s does not exist", path)
+		}
+		if entry != nil && entry.Type != "blob" {
+			return nil, fmt.Errorf("%s is not a file", path)
+		}
+		return entry, nil
+	}
+
+	var entries []*TreeEntry
+	for _, change := range changes {
+		if change.Path == "" {
+			return nil, fmt.Errorf("file change without a path")
+		}
+
+		if change.Delete {
+			entry, err := existing(change.Path)
+			if err != nil {
+				return nil, fmt.Errorf("cannot delete: %w", err)
+			}
+			entries = append(entries, deletion(change.Path, entry))
+			continue
+		}
+
+		mode := change.Mode
+		var sha string
+		if change.PreviousPath != "" {
+			entry, err := existing(change.PreviousPath)
+			if err != nil {
+				return nil, fmt.Errorf("cannot rename: %w", err)
+			}
+			if entry == nil && change.Content == nil {
+				return nil, fmt.Errorf("cannot rename %s without its content: the tree listing is truncated", change.PreviousPath)
+			}
+			entries = append(entries, deletion(change.PreviousPath, entry))
+			if entry != nil && entry.SHA != nil {
+				sha = *entry.SHA
+				if mode == "" {
+					mode = entry.Mode
+				}
+			}
+		} else if entry := base.Entry(change.Path); entry != nil && mode == "" {
+			mode = entry.Mode
+		}
+		if mode == "" {
+			mode = ModeFile
+		}
+
+		if change.Content != nil {
+			blob, err := c.CreateBlob(ctx, owner, repo, change.Content)
+			if err != nil {
+				return nil, fmt.Errorf("failed to upload %s: %w", change.Path, err)
+			}
+			sha = blob.SHA
+		} else if sha == "" {
+			return nil, fmt.Errorf("no content given for %s", change.Path)
+		}
+
+		entries = append(entries, &TreeEntry{Path: change.Path, Mode: mode, Type: "blob", SHA: &sha})
+	}
+
+	return entries, nil
+}
+
+// deletion returns the tree entry deleting path
+func deletion(path string, entry *TreeEntry) *TreeEntry {
+	mode := ModeFile
+	if entry != nil {
+		mode = entry.Mode
+	}
+	return &TreeEntry{Path: path, Mode: mode, Type: "blob"}
+}
@@ -0,0 +1,138 @@
+package github
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+)
+
+// gitDataHandler serves a branch "main" at commit c1 with tree t1, which
+// holds a script and a README
+func gitDataHandler(t *testing.T, tree *[]map[string]interface{}, update func(w http.ResponseWriter, body map[string]interface{})) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var body map[string]interface{}
+		if r.Body != nil && r.Method != "GET" {
+			json.NewDecoder(r.Body).Decode(&body)
+		}
+
+		switch r.Method + " " + r.URL.Path {
+		case "GET /repos/octo/hello/git/ref/heads/main":
+			fmt.Fprint(w, `{"ref": "refs/heads/main", "object": {"type": "commit", "sha": "c1"}}`)
+		case "GET /repos/octo/hello/git/commits/c1":
+			fmt.Fprint(w, `{"sha": "c1", "tree": {"sha": "t1"}}`)
+		case "GET /repos/octo/hello/git/trees/t1":
+			fmt.Fprint(w, `{"sha": "t1", "tree": [
+				{"path": "run.sh", "mode": "100755", "type": "blob", "sha": "b-run"},
+				{"path": "README.md", "mode": "100644", "type": "blob", "sha": "b-readme"},
+				{"path": "docs", "mode": "040000", "type": "tree", "sha": "t-docs"}
+			]}`)
+		case "POST /repos/octo/hello/git/blobs":
+			content, _ := base64.StdEncoding.DecodeString(body["content"].(string))
+			fmt.Fprintf(w, `{"sha": "b-%x"}`, content)
+		case "POST /repos/octo/hello/git/trees":
+			if body["base_tree"] != "t1" {
+				t.Errorf("Unexpected base tree: %v", body["base_tree"])
+			}
+			for _, entry := range body["tree"].([]interface{}) {
+				*tree = append(*tree, entry.(map[string]interface{}))
+			}
+			fmt.Fprint(w, `{"sha": "t2"}`)
+		case "POST /repos/octo/hello/git/commits":
+			if body["tree"] != "t2" || fmt.Sprint(body["parents"]) != "[c1]" {
+				t.Errorf("Unexpected commit: %v", body)
+			}
+			fmt.Fprint(w, `{"sha": "c2", "message": "Update"}`)
+		case "PATCH /repos/octo/hello/git/refs/heads/main":
+			update(w, body)
+		default:
+			t.Errorf("Unexpected request %s %s", r.Method, r.URL.Path)
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}
+}
+
+func TestCommitChanges(t *testing.T) {
+	var tree []map[string]interface{}
+	client := setupTestClient(t, gitDataHandler(t, &tree, func(w http.ResponseWriter, body map[string]interface{}) {
+		if body["sha"] != "c2" || body["force"] != false {
+			t.Errorf("Unexpected ref update: %v", body)
+		}
+		fmt.Fprint(w, `{"ref": "refs/heads/main", "object": {"sha": "c2"}}`)
+	}))
+
+	commit, err := client.CommitChanges(context.Background(), "octo", "hello", &CommitChangesOptions{
+		Branch:  "main",
+		Message: "Update",
+		Changes: []*FileChange{
+			{Path: "run.sh", Content: []byte{0x01}},
+			{Path: "logo.png", Content: []byte{0x89, 0x50}},
+			{Path: "docs/README.md", PreviousPath: "README.md"},
+			{Path: "old.txt", Delete: true},
+		},
+	})
+	if err == nil {
+		t.Fatalf("Expected deleting a missing file to fail, got %+v", commit)
+	}
+
+	commit, err = client.CommitChanges(context.Background(), "octo", "hello", &CommitChangesOptions{
+		Branch:          "main",
+		Message:         "Update",
+		ExpectedHeadSHA: "c1",
+		Changes: []*FileChange{
+			{Path: "run.sh", Content: []byte{0x01}},
+			{Path: "logo.png", Content: []byte{0x89, 0x50}},
+			{Path: "docs/README.md", PreviousPath: "README.md"},
+		},
+	})
+	if err != nil {
+		t.Fatalf("CommitChanges failed: %v", err)
+	}
+	if commit.SHA != "c2" {
+		t.Errorf("Unexpected commit: %+v", commit)
+	}
+
+	want := []string{
+		"run.sh 100755 b-01",
+		"logo.png 100644 b-8950",
+		"README.md 100644 <nil>",
+		"docs/README.md 100644 b-readme",
+	}
+	if len(tree) != len(want) {
+		t.Fatalf("Unexpected tree entries: %v", tree)
+	}
+	for i, entry := range tree {
+		if got := fmt.Sprintf("%v %v %v", entry["path"], entry["mode"], entry["sha"]); got != want[i] {
+			t.Errorf("Expected entry %d to be %s, got %s", i, want[i], got)
+		}
+	}
+}
+
+func TestCommitChanges_StaleHead(t *testing.T) {
+	var tree []map[string]interface{}
+	client := setupTestClient(t, gitDataHandler(t, &tree, func(w http.ResponseWriter, body map[string]interface{}) {
+		w.WriteHeader(http.StatusUnprocessableEntity)
+		fmt.Fprint(w, `{"message": "Update is not a fast forward"}`)
+	}))
+
+	opts := &CommitChangesOptions{
+		Branch:          "main",
+		Message:         "Update",
+		ExpectedHeadSHA: "c0",
+		Changes:         []*FileChange{{Path: "run.sh", Content: []byte("echo")}},
+	}
+	_, err := client.CommitChanges(context.Background(), "octo", "hello", opts)
+	var stale *StaleHeadError
+	if !errors.As(err, &stale) || stale.Actual != "c1" {
+		t.Errorf("Expected a stale head error, got %v", err)
+	}
+
+	opts.ExpectedHeadSHA = ""
+	_, err = client.CommitChanges(context.Background(), "octo", "hello", opts)
+	if !errors.As(err, &stale) || stale.Expected != "c1" {
+		t.Errorf("Expected a rejected fast-forward to be a stale head error, got %v", err)
+	}
+}
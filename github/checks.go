@@ -0,0 +1,397 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"time"
+)
+
+// Check states used in summaries
+const (
+	CheckStateSuccess = "success"
+	CheckStateFailure = "failure"
+	CheckStatePending = "pending"
+)
+
+// CommitStatus represents a status reported for a commit
+type CommitStatus struct {
+	Context     string    `json:"context"`
+	State       string    `json:"state"`
+	Description string    `json:"description"`
+	TargetURL   string    `json:"target_url"`
+	CreatedAt   time.Time `json:"created_at"`
+	UpdatedAt   time.Time `json:"updated_at"`
+}
+
+// CombinedStatus represents the combined commit status for a ref
+type CombinedStatus struct {
+	State      string          `json:"state"`
+	SHA        string          `json:"sha"`
+	TotalCount int             `json:"total_count"`
+	Statuses   []*CommitStatus `json:"statuses"`
+}
+
+// CheckApp identifies the app that created a check
+type CheckApp struct {
+	ID   int64  `json:"id"`
+	Slug string `json:"slug"`
+	Name string `json:"name"`
+}
+
+// CheckSuite represents a suite of check runs for a commit
+type CheckSuite struct {
+	ID         int64     `json:"id"`
+	HeadBranch string    `json:"head_branch"`
+	HeadSHA    string    `json:"head_sha"`
+	Status     string    `json:"status"`
+	Conclusion string    `json:"conclusion"`
+	App        CheckApp  `json:"app"`
+	CreatedAt  time.Time `json:"created_at"`
+	UpdatedAt  time.Time `json:"updated_at"`
+}
+
+// CheckRunOutput represents the output of a check run
+type CheckRunOutput struct {
+	Title            string `json:"title"`
+	Summary          string `json:"summary"`
+	AnnotationsCount int    `json:"annotations_count"`
+}
+
+// CheckRun represents a single check run
+type CheckRun struct {
+	ID          int64          `json:"id"`
+	Name        string         `json:"name"`
+	HeadSHA     string         `json:"head_sha"`
+	Status      string         `json:"status"`
+	Conclusion  string         `json:"conclusion"`
+	HTMLURL     string         `json:"html_url"`
+	DetailsURL  string         `json:"details_url"`
+	StartedAt   *time.Time     `json:"started_at,omitempty"`
+	CompletedAt *time.Time     `json:"completed_at,omitempty"`
+	Output      CheckRunOutput `json:"output"`
+	CheckSuite  struct {
+		ID int64 `json:"id"`
+	} `json:"check_suite"`
+	App CheckApp `json:"app"`
+}
+
+// CheckRunAnnotation represents an annotation on a check run
+type CheckRunAnnotation struct {
+	Path            string `json:"path"`
+	StartLine       int    `json:"start_line"`
+	EndLine         int    `json:"end_line"`
+	AnnotationLevel string `json:"annotation_level"`
+	Title           string `json:"title"`
+	Message         string `json:"message"`
+}
+
+// CheckSummary is the normalized state of one commit status or check run
+type CheckSummary struct {
+	Name        string                `json:"name"`
+	Kind        string                `json:"kind"`
+	State       string                `json:"state"`
+	Conclusion  string                `json:"conclusion,omitempty"`
+	Required    bool                  `json:"required"`
+	URL         string                `json:"url,omitempty"`
+	Description string                `json:"description,omitempty"`
+	CheckRunID  int64                 `json:"check_run_id,omitempty"`
+	Annotations []*CheckRunAnnotation `json:"annotations,omitempty"`
+}
+
+// ChecksSummary aggregates the statuses and check runs of a commit
+type ChecksSummary struct {
+	SHA   string `json:"sha"`
+	State string `json:"state"`
+
+	// RequiredFailing lists required checks that failed
+	RequiredFailing []string `json:"required_failing"`
+
+	// RequiredMissing lists required checks that have not reported yet
+	RequiredMissing []string `json:"required_missing"`
+
+	Passed  int             `json:"passed"`
+	Failed  int             `json:"failed"`
+	Pending int             `json:"pending"`
+	Checks  []*CheckSummary `json:"checks"`
+}
+
+// GetCombinedStatus gets the combined commit status for a ref
+func (c *Client) GetCombinedStatus(ctx context.Context, owner, repo, ref string) (*CombinedStatus, error) {
+	url := fmt.Sprintf("repos/%s/%s/commits/%s/status?per_page=100", owner, repo, ref)
+
+	req, err := c.newRequest(ctx, "GET", url, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := c.do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	var status CombinedStatus
+	if err := json.NewDecoder(resp.Body).Decode(&status); err != nil {
+		return nil, fmt.Errorf("failed to decode response: %w", err)
+	}
+
+	return &status, nil
+}
+
+// ListCheckSuites lists the check suites for a ref
+func (c *Client) ListCheckSuites(ctx context.Context, owner, repo, ref string, opts *ListOptions) ([]*CheckSuite, error) {
+	url := addListOptions(fmt.Sprintf("repos/%s/%s/commits/%s/check-suites", owner, repo, ref), opts)
+
+	req, err := c.newRequest(ctx, "GET", url, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := c.do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	var result struct {
+		TotalCount  int           `json:"total_count"`
+		CheckSuites []*CheckSuite `json:"check_suites"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return nil, fmt.Errorf("failed to decode response: %w", err)
+	}
+
+	return result.CheckSuites, nil
+}
+
+// ListCheckRuns lists the check runs for a ref
+func (c *Client) ListCheckRuns(ctx context.Context, owner, repo, ref string, opts *ListOptions) ([]*CheckRun, int, error) {
+	url := addListOptions(fmt.Sprintf("repos/%s/%s/commits/%s/check-runs", owner, repo, ref), opts)
+
+	req, err := c.newRequest(ctx, "GET", url, nil)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	resp, err := c.do(req)
+	if err != nil {
+		return nil, 0, err
+	}
+	defer resp.Body.Close()
+
+	var result struct {
+		TotalCount int         `json:"total_count"`
+		CheckRuns  []*CheckRun `json:"check_runs"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return nil, 0, fmt.Errorf("failed to decode response: %w", err)
+	}
+
+	return result.CheckRuns, result.TotalCount, nil
+}
+
+// ListAllCheckRuns lists every check run for a ref, following pagination
+func (c *Client) ListAllCheckRuns(ctx context.Context, owner, repo, ref string) ([]*CheckRun, error) {
+	var runs []*CheckRun
+	for page := 1; ; page++ {
+		pageRuns, total, err := c.ListCheckRuns(ctx, owner, repo, ref, &ListOptions{Page: page, PerPage: 100})
+		if err != nil {
+			return nil, err
+		}
+		runs = append(runs, pageRuns...)
+		if len(pageRuns) == 0 || len(runs) >= total {
+			return runs, nil
+		}
+	}
+}
+
+// ListCheckRunAnnotations lists the annotations of a check run
+func (c *Client) ListCheckRunAnnotations(ctx context.Context, owner, repo string, checkRunID int64, opts *ListOptions) ([]*CheckRunAnnotation, error) {
+	url := addListOptions(fmt.Sprintf("repos/%s/%s/check-runs/%d/annotations", owner, repo, checkRunID), opts)
+
+	req, err := c.newRequest(ctx, "GET", url, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := c.do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	var annotations []*CheckRunAnnotation
+	if err := json.NewDecoder(resp.Body).Decode(&annotations); err != nil {
+		return nil, fmt.Errorf("failed to decode response: %w", err)
+	}
+
+	return annotations, nil
+}
+
+// GetRequiredStatusChecks gets the status check contexts required by the
+// protection of a branch, or nil if the branch is not protected
+func (c *Client) GetRequiredStatusChecks(ctx context.Context, owner, repo, branch string) ([]string, error) {
+	url := fmt.Sprintf("repos/%s/%s/branches/%s", owner, repo, branch)
+
+	req, err := c.newRequest(ctx, "GET", url, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := c.do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	var result struct {
+		Protection struct {
+			RequiredStatusChecks struct {
+				Contexts []string `json:"contexts"`
+				Checks   []struct {
+					Context string `json:"context"`
+				} `json:"checks"`
+			} `json:"required_status_checks"`
+		} `json:"protection"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return nil, fmt.Errorf("failed to decode response: %w", err)
+	}
+
+	seen := make(map[string]bool)
+	var required []string
+	for _, context := range result.Protection.RequiredStatusChecks.Contexts {
+		if !seen[context] {
+			required = append(required, context)
+			seen[context] = true
+		}
+	}
+	for _, check := range result.Protection.RequiredStatusChecks.Checks {
+		if !seen[check.Context] {
+			required = append(required, check.Context)
+			seen[check.Context] = true
+		}
+	}
+
+	return required, nil
+}
+
+// SummarizeChecks aggregates commit statuses and check runs into a single
+// pass, fail or pending state. Required checks that have not reported count
+// as pending.
+func SummarizeChecks(sha string, status *CombinedStatus, runs []*CheckRun, required []string) *ChecksSummary {
+	summary := &ChecksSummary{
+		SHA:             sha,
+		RequiredFailing: []string{},
+		RequiredMissing: []string{},
+		Checks:          []*CheckSummary{},
+	}
+
+	isRequired := make(map[string]bool)
+	for _, name := range required {
+		isRequired[name] = true
+	}
+
+	if status != nil {
+		for _, s := range status.Statuses {
+			summary.Checks = append(summary.Checks, &CheckSummary{
+				Name:        s.Context,
+				Kind:        "status",
+				State:       statusState(s.State),
+				Required:    isRequired[s.Context],
+				URL:         s.TargetURL,
+				Description: s.Description,
+			})
+		}
+	}
+
+	// A re-run check appears several times; only the latest run counts
+	latest := make(map[string]*CheckRun)
+	for _, run := range runs {
+		if prev, ok := latest[run.Name]; !ok || run.ID > prev.ID {
+			latest[run.Name] = run
+		}
+	}
+	for _, run := range latest {
+		url := run.DetailsURL
+		if url == "" {
+			url = run.HTMLURL
+		}
+		summary.Checks = append(summary.Checks, &CheckSummary{
+			Name:        run.Name,
+			Kind:        "check_run",
+			State:       checkRunState(run),
+			Conclusion:  run.Conclusion,
+			Required:    isRequired[run.Name],
+			URL:         url,
+			Description: run.Output.Title,
+			CheckRunID:  run.ID,
+		})
+	}
+
+	sort.Slice(summary.Checks, func(i, j int) bool {
+		if summary.Checks[i].Name != summary.Checks[j].Name {
+			return summary.Checks[i].Name < summary.Checks[j].Name
+		}
+		return summary.Checks[i].Kind < summary.Checks[j].Kind
+	})
+
+	reported := make(map[string]bool)
+	for _, check := range summary.Checks {
+		reported[check.Name] = true
+		switch check.State {
+		case CheckStateFailure:
+			summary.Failed++
+			if check.Required {
+				summary.RequiredFailing = append(summary.RequiredFailing, check.Name)
+			}
+		case CheckStatePending:
+			summary.Pending++
+		default:
+			summary.Passed++
+		}
+	}
+	for _, name := range required {
+		if !reported[name] {
+			summary.RequiredMissing = append(summary.RequiredMissing, name)
+		}
+	}
+
+	switch {
+	case summary.Failed > 0:
+		summary.State = CheckStateFailure
+	case summary.Pending > 0 || len(summary.RequiredMissing) > 0:
+		summary.State = CheckStatePending
+	default:
+		summary.State = CheckStateSuccess
+	}
+
+	return summary
+}
+
+// statusState normalizes a commit status state
+func statusState(state string) string {
+	switch state {
+	case "success":
+		return CheckStateSuccess
+	case "failure", "error":
+		return CheckStateFailure
+	default:
+		return CheckStatePending
+	}
+}
+
+// checkRunState normalizes a check run's status and conclusion
+func checkRunState(run *CheckRun) string {
+	if run.Status != "completed" {
+		return CheckStatePending
+	}
+	switch run.Conclusion {
+	case "success", "neutral", "skipped":
+		return CheckStateSuccess
+	default:
+		return CheckStateFailure
+	}
+}
@@ -0,0 +1,118 @@
+package github
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"reflect"
+	"testing"
+)
+
+func TestListAllCheckRuns(t *testing.T) {
+	client := setupTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/repos/octo/hello/commits/abc123/check-runs" {
+			t.Errorf("Unexpected request path %s", r.URL.Path)
+		}
+		switch r.URL.Query().Get("page") {
+		case "1":
+			fmt.Fprint(w, `{"total_count": 2, "check_runs": [{"id": 1, "name": "build"}]}`)
+		case "2":
+			fmt.Fprint(w, `{"total_count": 2, "check_runs": [{"id": 2, "name": "test"}]}`)
+		default:
+			t.Errorf("Unexpected page %q", r.URL.Query().Get("page"))
+		}
+	})
+
+	runs, err := client.ListAllCheckRuns(context.Background(), "octo", "hello", "abc123")
+	if err != nil {
+		t.Fatalf("ListAllCheckRuns failed: %v", err)
+	}
+	if len(runs) != 2 || runs[1].Name != "test" {
+		t.Errorf("Unexpected check runs: %+v", runs)
+	}
+}
+
+func TestGetRequiredStatusChecks(t *testing.T) {
+	client := setupTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"name": "main", "protection": {"enabled": true, "required_status_checks": {
+			"contexts": ["ci/build", "test"],
+			"checks": [{"context": "test", "app_id": 15368}, {"context": "lint", "app_id": null}]
+		}}}`)
+	})
+
+	required, err := client.GetRequiredStatusChecks(context.Background(), "octo", "hello", "main")
+	if err != nil {
+		t.Fatalf("GetRequiredStatusChecks failed: %v", err)
+	}
+	if want := []string{"ci/build", "test", "lint"}; !reflect.DeepEqual(required, want) {
+		t.Errorf("Expected %v, got %v", want, required)
+	}
+}
+
+func TestSummarizeChecks(t *testing.T) {
+	status := &CombinedStatus{Statuses: []*CommitStatus{
+		{Context: "ci/build", State: "success"},
+		{Context: "coverage", State: "error", TargetURL: "https://ci.example.com/1"},
+	}}
+	runs := []*CheckRun{
+		{ID: 1, Name: "test", Status: "completed", Conclusion: "failure", DetailsURL: "https://github.com/octo/hello/runs/1"},
+		{ID: 2, Name: "test", Status: "completed", Conclusion: "success"},
+		{ID: 3, Name: "lint", Status: "completed", Conclusion: "timed_out", HTMLURL: "https://github.com/octo/hello/runs/3"},
+		{ID: 4, Name: "docs", Status: "completed", Conclusion: "skipped"},
+	}
+
+	tests := []struct {
+		name     string
+		runs     []*CheckRun
+		required []string
+		state    string
+		failing  []string
+		missing  []string
+	}{
+		{
+			name:     "required check failed",
+			runs:     runs,
+			required: []string{"lint", "test"},
+			state:    CheckStateFailure,
+			failing:  []string{"lint"},
+			missing:  []string{},
+		},
+		{
+			name:     "required check not reported",
+			runs:     runs[1:2],
+			required: []string{"test", "e2e"},
+			state:    CheckStateFailure,
+			failing:  []string{},
+			missing:  []string{"e2e"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			summary := SummarizeChecks("abc123", status, tt.runs, tt.required)
+			if summary.State != tt.state {
+				t.Errorf("Expected state %s, got %s", tt.state, summary.State)
+			}
+			if !reflect.DeepEqual(summary.RequiredFailing, tt.failing) {
+				t.Errorf("Expected failing %v, got %v", tt.failing, summary.RequiredFailing)
+			}
+			if !reflect.DeepEqual(summary.RequiredMissing, tt.missing) {
+				t.Errorf("Expected missing %v, got %v", tt.missing, summary.RequiredMissing)
+			}
+		})
+	}
+
+	// The re-run of test supersedes its failure
+	summary := SummarizeChecks("abc123", nil, runs, nil)
+	if summary.Failed != 1 || summary.Passed != 2 {
+		t.Errorf("Expected 1 failed and 2 passed, got %+v", summary)
+	}
+	if summary.Checks[0].Name != "docs" || summary.Checks[1].URL != "https://github.com/octo/hello/runs/3" {
+		t.Errorf("Unexpected checks: %+v %+v", summary.Checks[0], summary.Checks[1])
+	}
+
+	pending := SummarizeChecks("abc123", nil, []*CheckRun{{ID: 5, Name: "test", Status: "in_progress"}}, []string{"test"})
+	if pending.State != CheckStatePending {
+		t.Errorf("Expected pending, got %s", pending.State)
+	}
+}
@@ -4,10 +4,13 @@ import (
 	"bytes"
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"net/http"
 	"path"
+	"strconv"
+	"strings"
 )
 
 const (
@@ -16,23 +19,58 @@ const (
 
 // Client represents a GitHub API client
 type Client struct {
-	token      string
+	auth       AuthProvider
 	baseURL    string
 	httpClient *http.Client
 }
 
-// NewClient creates a new GitHub API client
+// NewClient creates a new GitHub API client authenticated with a personal access token
 func NewClient(token string) *Client {
+	return NewClientWithAuth(TokenAuth(token))
+}
+
+// NewClientWithAuth creates a new GitHub API client using the given auth provider
+func NewClientWithAuth(auth AuthProvider) *Client {
 	return &Client{
-		token:      token,
+		auth:       auth,
 		baseURL:    apiBaseURL,
 		httpClient: http.DefaultClient,
 	}
 }
 
+// NewClientWithTokenProvider creates a new GitHub API client that resolves
+// its token from the provider on every request
+func NewClientWithTokenProvider(provider TokenProvider) *Client {
+	return NewClientWithAuth(&TokenProviderAuth{Provider: provider})
+}
+
+// SetBaseURL sets the API base URL, e.g. for GitHub Enterprise Server
+func (c *Client) SetBaseURL(baseURL string) {
+	if !strings.HasSuffix(baseURL, "/") {
+		baseURL += "/"
+	}
+	c.baseURL = baseURL
+}
+
 // newRequest creates a new HTTP request with appropriate headers and base URL
 func (c *Client) newRequest(ctx context.Context, method, path string, body interface{}) (*http.Request, error) {
-	url := c.baseURL + path
+	baseURL := c.baseURL
+	if urlProvider, ok := c.auth.(BaseURLProvider); ok {
+		providerURL, err := urlProvider.BaseURL(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("failed to resolve base URL: %w", err)
+		}
+		if providerURL != "" {
+			if !strings.HasSuffix(providerURL, "/") {
+				providerURL += "/"
+			}
+			baseURL = providerURL
+		}
+	}
+	url := baseURL + path
+	if path == graphQLPath {
+		url = graphQLURL(baseURL)
+	}
 
 	var bodyReader io.Reader
 	if body != nil {
@@ -49,7 +87,11 @@ func (c *Client) newRequest(ctx context.Context, method, path string, body inter
 	}
 
 	// Set headers
-	req.Header.Set("Authorization", "token "+c.token)
+	if c.auth != nil {
+		if err := c.auth.Authorize(req); err != nil {
+			return nil, fmt.Errorf("failed to authorize request: %w", err)
+		}
+	}
 	req.Header.Set("Accept", "application/vnd.github.v3+json")
 	if body != nil {
 		req.Header.Set("Content-Type", "application/json")
@@ -68,18 +110,63 @@ func (c *Client) do(req *http.Request) (*http.Response, error) {
 	// Check for error status codes
 	if resp.StatusCode >= 400 {
 		defer resp.Body.Close()
+		apiErr := &APIError{StatusCode: resp.StatusCode}
 		var errResp struct {
 			Message string `json:"message"`
 		}
 		if err := json.NewDecoder(resp.Body).Decode(&errResp); err == nil {
-			return nil, fmt.Errorf("GitHub API error: %s", errResp.Message)
+			apiErr.Message = errResp.Message
 		}
-		return nil, fmt.Errorf("GitHub API error: status code %d", resp.StatusCode)
+		return nil, apiErr
 	}
 
 	return resp, nil
 }
 
+// APIError represents an error response from the GitHub API
+type APIError struct {
+	StatusCode int
+	Message    string
+}
+
+// Error implements the error interface
+func (e *APIError) Error() string {
+	if e.Message != "" {
+		return fmt.Sprintf("GitHub API error: %s", e.Message)
+	}
+	return fmt.Sprintf("GitHub API error: status code %d", e.StatusCode)
+}
+
+// IsStatus reports whether err is an APIError with the given status code
+func IsStatus(err error, statusCode int) bool {
+	var apiErr *APIError
+	return errors.As(err, &apiErr) && apiErr.StatusCode == statusCode
+}
+
+// addListOptions appends pagination parameters to a URL
+func addListOptions(url string, opts *ListOptions) string {
+	if opts == nil {
+		return url
+	}
+
+	params := make([]string, 0)
+	if opts.Page > 0 {
+		params = append(params, "page="+strconv.Itoa(opts.Page))
+	}
+	if opts.PerPage > 0 {
+		params = append(params, "per_page="+strconv.Itoa(opts.PerPage))
+	}
+	if len(params) == 0 {
+		return url
+	}
+
+	separator := "?"
+	if strings.Contains(url, "?") {
+		separator = "&"
+	}
+	return url + separator + strings.Join(params, "&")
+}
+
 // buildURL builds a URL by joining the base URL and path components
 func (c *Client) buildURL(pathComponents ...string) string {
 	components := append([]string{c.baseURL}, pathComponents...)
@@ -0,0 +1,17 @@
+package github
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+// setupTestClient returns a client talking to a stand-in API server
+func setupTestClient(t *testing.T, handler http.HandlerFunc) *Client {
+	srv := httptest.NewServer(handler)
+	t.Cleanup(srv.Close)
+
+	client := NewClient("test-token")
+	client.SetBaseURL(srv.URL)
+	return client
+}
@@ -1,14 +1,34 @@
 package github
 
 import (
+	"bytes"
 	"context"
 	"encoding/base64"
 	"encoding/json"
 	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"path"
+	"strings"
+	"unicode/utf8"
 )
 
 // GetContent gets the content of a file
 func (c *Client) GetContent(ctx context.Context, owner, repo, path, ref string) (*FileContent, error) {
+	file, _, err := c.GetContents(ctx, owner, repo, path, ref)
+	if err != nil {
+		return nil, err
+	}
+	if file == nil {
+		return nil, fmt.Errorf("%s is a directory", path)
+	}
+	return file, nil
+}
+
+// GetContents gets the content of a file or the entries of a directory.
+// Exactly one of the file and the directory entries is returned.
+func (c *Client) GetContents(ctx context.Context, owner, repo, path, ref string) (*FileContent, []*FileContent, error) {
 	url := fmt.Sprintf("repos/%s/%s/contents/%s", owner, repo, path)
 	if ref != "" {
 		url += fmt.Sprintf("?ref=%s", ref)
@@ -16,30 +36,141 @@ func (c *Client) GetContent(ctx context.Context, owner, repo, path, ref string)
 
 	req, err := c.newRequest(ctx, "GET", url, nil)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 
 	resp, err := c.do(req)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 	defer resp.Body.Close()
 
+	var raw json.RawMessage
+	if err := json.NewDecoder(resp.Body).Decode(&raw); err != nil {
+		return nil, nil, fmt.Errorf("failed to decode response: %w", err)
+	}
+
+	// Directories are returned as an array of entries
+	if trimmed := bytes.TrimSpace(raw); len(trimmed) > 0 && trimmed[0] == '[' {
+		var entries []*FileContent
+		if err := json.Unmarshal(raw, &entries); err != nil {
+			return nil, nil, fmt.Errorf("failed to decode response: %w", err)
+		}
+		return nil, entries, nil
+	}
+
 	var content FileContent
-	if err := json.NewDecoder(resp.Body).Decode(&content); err != nil {
-		return nil, fmt.Errorf("failed to decode response: %w", err)
+	if err := json.Unmarshal(raw, &content); err != nil {
+		return nil, nil, fmt.Errorf("failed to decode response: %w", err)
 	}
 
 	// Decode base64 content if present
 	if content.Content != "" && content.Encoding == "base64" {
 		decoded, err := base64.StdEncoding.DecodeString(content.Content)
 		if err != nil {
-			return nil, fmt.Errorf("failed to decode content: %w", err)
+			return nil, nil, fmt.Errorf("failed to decode content: %w", err)
 		}
 		content.Content = string(decoded)
 	}
 
-	return &content, nil
+	return &content, nil, nil
+}
+
+// MediaTypeRaw requests the raw bytes of a file from the contents API
+const MediaTypeRaw = "application/vnd.github.raw"
+
+// MaxInlineContentSize is the size up to which the contents API returns
+// file content inline
+const MaxInlineContentSize = 1 << 20
+
+// maxRawContentSize bounds GetRawContent downloads; GitHub serves files of
+// up to 100 MB
+const maxRawContentSize = 100 << 20
+
+// GetRawContent gets the raw bytes of a file of any size. When length is
+// positive only that many bytes starting at offset are read, using a range
+// request where GitHub supports one.
+func (c *Client) GetRawContent(ctx context.Context, owner, repo, path, ref string, offset, length int64) ([]byte, error) {
+	url := fmt.Sprintf("repos/%s/%s/contents/%s", owner, repo, path)
+	if ref != "" {
+		url += fmt.Sprintf("?ref=%s", ref)
+	}
+
+	req, err := c.newRequest(ctx, "GET", url, nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Accept", MediaTypeRaw)
+	if length > 0 {
+		req.Header.Set("Range", fmt.Sprintf("bytes=%d-%d", offset, offset+length-1))
+	}
+
+	resp, err := c.do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	// Skip to offset ourselves if the range was ignored
+	if resp.StatusCode != http.StatusPartialContent && offset > 0 {
+		if _, err := io.CopyN(io.Discard, resp.Body, offset); err != nil && err != io.EOF {
+			return nil, fmt.Errorf("failed to read content: %w", err)
+		}
+	}
+
+	limit := int64(maxRawContentSize)
+	if length > 0 {
+		limit = length
+	}
+	data, err := io.ReadAll(io.LimitReader(resp.Body, limit+1))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read content: %w", err)
+	}
+	if int64(len(data)) > limit {
+		if length > 0 {
+			return data[:limit], nil
+		}
+		return nil, fmt.Errorf("content exceeds %d bytes", limit)
+	}
+
+	return data, nil
+}
+
+// DetectContentType guesses the MIME type of a file from its name and
+// content, and reports whether the content is binary rather than UTF-8
+// text
+func DetectContentType(name string, data []byte) (string, bool) {
+	sample := data
+	if len(sample) > 8000 {
+		sample = sample[:8000]
+	}
+	binary := bytes.IndexByte(sample, 0) >= 0 || !utf8.Valid(trimIncompleteRunes(sample))
+
+	mimeType := mime.TypeByExtension(path.Ext(name))
+	if mimeType == "" {
+		mimeType = http.DetectContentType(sample)
+	}
+	if !binary && strings.HasPrefix(mimeType, "application/octet-stream") {
+		mimeType = "text/plain; charset=utf-8"
+	}
+	return mimeType, binary
+}
+
+// trimIncompleteRunes drops UTF-8 sequences cut off at either end of a
+// sample, as a byte range may start or end inside a rune
+func trimIncompleteRunes(data []byte) []byte {
+	for i := 0; i < utf8.UTFMax-1 && len(data) > 0 && !utf8.RuneStart(data[0]); i++ {
+		data = data[1:]
+	}
+	for i := 1; i < utf8.UTFMax && i <= len(data); i++ {
+		if utf8.RuneStart(data[len(data)-i]) {
+			if !utf8.FullRune(data[len(data)-i:]) {
+				return data[:len(data)-i]
+			}
+			break
+		}
+	}
+	return data
 }
 
 // CreateFile creates a new file
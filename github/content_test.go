@@ -0,0 +1,71 @@
+package github
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+func TestGetRawContent_Range(t *testing.T) {
+	client := setupTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Accept") != MediaTypeRaw {
+			t.Errorf("Expected raw media type, got %s", r.Header.Get("Accept"))
+		}
+		if r.Header.Get("Range") != "bytes=4-7" {
+			t.Errorf("Unexpected range: %s", r.Header.Get("Range"))
+		}
+		w.WriteHeader(http.StatusPartialContent)
+		fmt.Fprint(w, "4567")
+	})
+
+	data, err := client.GetRawContent(context.Background(), "octo", "hello", "data.bin", "", 4, 4)
+	if err != nil {
+		t.Fatalf("GetRawContent failed: %v", err)
+	}
+	if string(data) != "4567" {
+		t.Errorf("Unexpected content: %q", data)
+	}
+}
+
+func TestGetRawContent_RangeIgnored(t *testing.T) {
+	client := setupTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, "0123456789")
+	})
+
+	data, err := client.GetRawContent(context.Background(), "octo", "hello", "data.bin", "main", 4, 4)
+	if err != nil {
+		t.Fatalf("GetRawContent failed: %v", err)
+	}
+	if string(data) != "4567" {
+		t.Errorf("Expected the range to be cut from the full content, got %q", data)
+	}
+
+	data, err = client.GetRawContent(context.Background(), "octo", "hello", "data.bin", "main", 0, 0)
+	if err != nil || string(data) != "0123456789" {
+		t.Errorf("Expected the full content, got %q (%v)", data, err)
+	}
+}
+
+func TestDetectContentType(t *testing.T) {
+	tests := []struct {
+		name     string
+		data     []byte
+		mimeType string
+		binary   bool
+	}{
+		{"logo.png", []byte("\x89PNG\r\n\x1a\n\x00\x00\x00\rIHDR"), "image/png", true},
+		{"README", []byte("# Hello\n"), "text/plain", false},
+		{"data.bin", []byte{0xff, 0xfe, 0x00, 0x01}, "application/octet-stream", true},
+		{"notes.txt", []byte(strings.Repeat("aé", 3000)), "text/plain", false},
+		{"range", []byte("中文 text 中文")[2:], "text/plain", false},
+	}
+
+	for _, tt := range tests {
+		mimeType, binary := DetectContentType(tt.name, tt.data)
+		if !strings.HasPrefix(mimeType, tt.mimeType) || binary != tt.binary {
+			t.Errorf("DetectContentType(%s) = %s, %v; want %s, %v", tt.name, mimeType, binary, tt.mimeType, tt.binary)
+		}
+	}
+}
@@ -0,0 +1,299 @@
+package github
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+)
+
+// DeleteRef deletes a git reference, e.g. "heads/feature" for a branch
+func (c *Client) DeleteRef(ctx context.Context, owner, repo, ref string) error {
+	url := fmt.Sprintf("repos/%s/%s/git/refs/%s", owner, repo, ref)
+
+	req, err := c.newRequest(ctx, "DELETE", url, nil)
+	if err != nil {
+		return err
+	}
+
+	resp, err := c.do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	return nil
+}
+
+// Reference represents a git reference
+type Reference struct {
+	Ref    string `json:"ref"`
+	Object struct {
+		Type string `json:"type"`
+		SHA  string `json:"sha"`
+	} `json:"object"`
+}
+
+// GetRef gets a git reference, e.g. "heads/main" or "tags/v1.0.0"
+func (c *Client) GetRef(ctx context.Context, owner, repo, ref string) (*Reference, error) {
+	url := fmt.Sprintf("repos/%s/%s/git/ref/%s", owner, repo, ref)
+
+	req, err := c.newRequest(ctx, "GET", url, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := c.do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	var reference Reference
+	if err := json.NewDecoder(resp.Body).Decode(&reference); err != nil {
+		return nil, fmt.Errorf("failed to decode response: %w", err)
+	}
+
+	return &reference, nil
+}
+
+// CreateRef creates a git reference pointing at a commit. ref is the fully
+// qualified name, e.g. "refs/heads/feature".
+func (c *Client) CreateRef(ctx context.Context, owner, repo, ref, sha string) (*Reference, error) {
+	url := fmt.Sprintf("repos/%s/%s/git/refs", owner, repo)
+	body := map[string]string{"ref": ref, "sha": sha}
+
+	req, err := c.newRequest(ctx, "POST", url, body)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := c.do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	var reference Reference
+	if err := json.NewDecoder(resp.Body).Decode(&reference); err != nil {
+		return nil, fmt.Errorf("failed to decode response: %w", err)
+	}
+
+	return &reference, nil
+}
+
+// UpdateRef points a git reference, e.g. "heads/main", at a commit. Unless
+// force is set GitHub only accepts fast-forward updates.
+func (c *Client) UpdateRef(ctx context.Context, owner, repo, ref, sha string, force bool) (*Reference, error) {
+	url := fmt.Sprintf("repos/%s/%s/git/refs/%s", owner, repo, ref)
+	body := map[string]interface{}{"sha": sha, "force": force}
+
+	req, err := c.newRequest(ctx, "PATCH", url, body)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := c.do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	var reference Reference
+	if err := json.NewDecoder(resp.Body).Decode(&reference); err != nil {
+		return nil, fmt.Errorf("failed to decode response: %w", err)
+	}
+
+	return &reference, nil
+}
+
+// Blob represents a git blob
+type Blob struct {
+	SHA      string `json:"sha"`
+	Size     int    `json:"size,omitempty"`
+	Content  string `json:"content,omitempty"`
+	Encoding string `json:"encoding,omitempty"`
+}
+
+// CreateBlob stores content, which may be binary, as a blob
+func (c *Client) CreateBlob(ctx context.Context, owner, repo string, content []byte) (*Blob, error) {
+	url := fmt.Sprintf("repos/%s/%s/git/blobs", owner, repo)
+	body := &Blob{Content: base64.StdEncoding.EncodeToString(content), Encoding: "base64"}
+
+	req, err := c.newRequest(ctx, "POST", url, body)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := c.do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	var blob Blob
+	if err := json.NewDecoder(resp.Body).Decode(&blob); err != nil {
+		return nil, fmt.Errorf("failed to decode response: %w", err)
+	}
+
+	return &blob, nil
+}
+
+// Git file modes for tree entries
+const (
+	ModeFile       = "100644"
+	ModeExecutable = "100755"
+	ModeSymlink    = "120000"
+)
+
+// TreeEntry is an entry of a git tree. When creating a tree, an entry with
+// a nil SHA deletes the path from the base tree.
+type TreeEntry struct {
+	Path string  `json:"path"`
+	Mode string  `json:"mode"`
+	Type string  `json:"type"`
+	SHA  *string `json:"sha"`
+	Size int     `json:"size,omitempty"`
+}
+
+// Tree represents a git tree
+type Tree struct {
+	SHA     string       `json:"sha"`
+	Entries []*TreeEntry `json:"tree"`
+
+	// Truncated is set when a recursive listing exceeded GitHub's limits
+	Truncated bool `json:"truncated"`
+}
+
+// Entry finds the entry with the given path
+func (t *Tree) Entry(path string) *TreeEntry {
+	for _, entry := range t.Entries {
+		if entry.Path == path {
+			return entry
+		}
+	}
+	return nil
+}
+
+// GetTree gets a git tree by SHA or by a branch, tag or commit it belongs to.
+// A recursive tree lists every entry below it.
+func (c *Client) GetTree(ctx context.Context, owner, repo, sha string, recursive bool) (*Tree, error) {
+	url := fmt.Sprintf("repos/%s/%s/git/trees/%s", owner, repo, sha)
+	if recursive {
+		url += "?recursive=1"
+	}
+
+	req, err := c.newRequest(ctx, "GET", url, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := c.do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	var tree Tree
+	if err := json.NewDecoder(resp.Body).Decode(&tree); err != nil {
+		return nil, fmt.Errorf("failed to decode response: %w", err)
+	}
+
+	return &tree, nil
+}
+
+// CreateTree creates a tree from the entries changed on top of baseTree
+func (c *Client) CreateTree(ctx context.Context, owner, repo, baseTree string, entries []*TreeEntry) (*Tree, error) {
+	url := fmt.Sprintf("repos/%s/%s/git/trees", owner, repo)
+	body := map[string]interface{}{"base_tree": baseTree, "tree": entries}
+
+	req, err := c.newRequest(ctx, "POST", url, body)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := c.do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	var tree Tree
+	if err := json.NewDecoder(resp.Body).Decode(&tree); err != nil {
+		return nil, fmt.Errorf("failed to decode response: %w", err)
+	}
+
+	return &tree, nil
+}
+
+// CommitAuthor identifies the author or committer of a commit
+type CommitAuthor struct {
+	Name  string `json:"name"`
+	Email string `json:"email"`
+	Date  string `json:"date,omitempty"`
+}
+
+// GitCommit represents a git commit object
+type GitCommit struct {
+	SHA     string        `json:"sha,omitempty"`
+	HTMLURL string        `json:"html_url,omitempty"`
+	Message string        `json:"message"`
+	Author  *CommitAuthor `json:"author,omitempty"`
+	Tree    struct {
+		SHA string `json:"sha"`
+	} `json:"tree"`
+	Parents []struct {
+		SHA string `json:"sha"`
+	} `json:"parents"`
+}
+
+// GetCommit gets a git commit object
+func (c *Client) GetCommit(ctx context.Context, owner, repo, sha string) (*GitCommit, error) {
+	url := fmt.Sprintf("repos/%s/%s/git/commits/%s", owner, repo, sha)
+
+	req, err := c.newRequest(ctx, "GET", url, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := c.do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	var commit GitCommit
+	if err := json.NewDecoder(resp.Body).Decode(&commit); err != nil {
+		return nil, fmt.Errorf("failed to decode response: %w", err)
+	}
+
+	return &commit, nil
+}
+
+// CreateCommit creates a commit of a tree. author may be nil to commit as
+// the authenticated user.
+func (c *Client) CreateCommit(ctx context.Context, owner, repo, message, tree string, parents []string, author *CommitAuthor) (*GitCommit, error) {
+	url := fmt.Sprintf("repos/%s/%s/git/commits", owner, repo)
+	body := map[string]interface{}{"message": message, "tree": tree, "parents": parents}
+	if author != nil {
+		body["author"] = author
+	}
+
+	req, err := c.newRequest(ctx, "POST", url, body)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := c.do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	var commit GitCommit
+	if err := json.NewDecoder(resp.Body).Decode(&commit); err != nil {
+		return nil, fmt.Errorf("failed to decode response: %w", err)
+	}
+
+	return &commit, nil
+}
@@ -0,0 +1,76 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+// graphQLPath is the request path of the GraphQL endpoint
+const graphQLPath = "graphql"
+
+// GraphQLError represents an error returned by the GraphQL API
+type GraphQLError struct {
+	Type    string `json:"type"`
+	Message string `json:"message"`
+}
+
+// GraphQLErrors is the list of errors of a failed GraphQL request
+type GraphQLErrors []GraphQLError
+
+// Error implements the error interface
+func (e GraphQLErrors) Error() string {
+	messages := make([]string, len(e))
+	for i, err := range e {
+		messages[i] = err.Message
+	}
+	return fmt.Sprintf("GitHub GraphQL error: %s", strings.Join(messages, "; "))
+}
+
+// GraphQL runs a GraphQL query or mutation and decodes its data into result
+func (c *Client) GraphQL(ctx context.Context, query string, variables map[string]interface{}, result interface{}) error {
+	body := map[string]interface{}{
+		"query":     query,
+		"variables": variables,
+	}
+
+	req, err := c.newRequest(ctx, "POST", graphQLPath, body)
+	if err != nil {
+		return err
+	}
+
+	resp, err := c.do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	var response struct {
+		Data   json.RawMessage `json:"data"`
+		Errors GraphQLErrors   `json:"errors"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&response); err != nil {
+		return fmt.Errorf("failed to decode response: %w", err)
+	}
+	if len(response.Errors) > 0 {
+		return response.Errors
+	}
+
+	if result != nil && len(response.Data) > 0 {
+		if err := json.Unmarshal(response.Data, result); err != nil {
+			return fmt.Errorf("failed to decode response data: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// graphQLURL returns the GraphQL endpoint for a REST base URL. GitHub
+// Enterprise Server serves it at /api/graphql next to /api/v3.
+func graphQLURL(baseURL string) string {
+	if strings.HasSuffix(baseURL, "/api/v3/") {
+		return strings.TrimSuffix(baseURL, "v3/") + graphQLPath
+	}
+	return baseURL + graphQLPath
+}
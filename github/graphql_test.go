@@ -0,0 +1,88 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+func TestGraphQLURL(t *testing.T) {
+	tests := map[string]string{
+		"https://api.github.com/":         "https://api.github.com/graphql",
+		"https://ghe.example.com/api/v3/": "https://ghe.example.com/api/graphql",
+		"http://127.0.0.1:8080/":          "http://127.0.0.1:8080/graphql",
+	}
+	for baseURL, want := range tests {
+		if got := graphQLURL(baseURL); got != want {
+			t.Errorf("graphQLURL(%q) = %q, want %q", baseURL, got, want)
+		}
+	}
+}
+
+func TestEnableAutoMerge(t *testing.T) {
+	client := setupTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != "POST" || r.URL.Path != "/graphql" {
+			t.Errorf("Unexpected request %s %s", r.Method, r.URL.Path)
+		}
+
+		var body struct {
+			Query     string                 `json:"query"`
+			Variables map[string]interface{} `json:"variables"`
+		}
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			t.Fatalf("Failed to decode body: %v", err)
+		}
+		if !strings.Contains(body.Query, "enablePullRequestAutoMerge") {
+			t.Errorf("Unexpected query: %s", body.Query)
+		}
+		if body.Variables["method"] != "SQUASH" {
+			t.Errorf("Expected SQUASH merge method, got %v", body.Variables["method"])
+		}
+		if body.Variables["id"] == "PR_clean" {
+			fmt.Fprint(w, `{"data": null, "errors": [{"type": "UNPROCESSABLE", "message": "Pull request is in clean status"}]}`)
+			return
+		}
+		fmt.Fprint(w, `{"data": {"enablePullRequestAutoMerge": {"pullRequest": {"autoMergeRequest": {"mergeMethod": "SQUASH"}}}}}`)
+	})
+
+	opts := &MergePullRequestOptions{MergeMethod: "squash"}
+	if err := client.EnableAutoMerge(context.Background(), "PR_kwDO", opts); err != nil {
+		t.Fatalf("EnableAutoMerge failed: %v", err)
+	}
+
+	err := client.EnableAutoMerge(context.Background(), "PR_clean", opts)
+	if _, ok := err.(GraphQLErrors); !ok || !strings.Contains(err.Error(), "clean status") {
+		t.Errorf("Expected GraphQL error, got %v", err)
+	}
+}
+
+func TestRequestReviewers(t *testing.T) {
+	client := setupTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != "POST" || r.URL.Path != "/repos/octo/hello/pulls/7/requested_reviewers" {
+			t.Errorf("Unexpected request %s %s", r.Method, r.URL.Path)
+		}
+
+		var body ReviewersRequest
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			t.Fatalf("Failed to decode body: %v", err)
+		}
+		if len(body.Reviewers) != 1 || len(body.TeamReviewers) != 1 || body.TeamReviewers[0] != "core" {
+			t.Errorf("Unexpected reviewers: %+v", body)
+		}
+		fmt.Fprint(w, `{"number": 7, "requested_reviewers": [{"login": "mona"}], "requested_teams": [{"slug": "core"}]}`)
+	})
+
+	pr, err := client.RequestReviewers(context.Background(), "octo", "hello", 7, &ReviewersRequest{
+		Reviewers:     []string{"mona"},
+		TeamReviewers: []string{"core"},
+	})
+	if err != nil {
+		t.Fatalf("RequestReviewers failed: %v", err)
+	}
+	if len(pr.RequestedTeams) != 1 || pr.RequestedTeams[0].Slug != "core" || pr.RequestedReviewers[0].Login != "mona" {
+		t.Errorf("Unexpected pull request: %+v", pr)
+	}
+}
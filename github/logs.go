@@ -0,0 +1,329 @@
+package github
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"path"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// maxLogSize limits the size of a downloaded log or log archive
+const maxLogSize = 64 << 20
+
+// logTimestamp matches the timestamp GitHub Actions puts before each log line
+var logTimestamp = regexp.MustCompile(`^\d{4}-\d{2}-\d{2}T\d{2}:\d{2}:\d{2}(\.\d+)?Z `)
+
+// RunLogs holds the logs of a workflow run, split per job
+type RunLogs struct {
+	Jobs []*JobLog `json:"jobs"`
+}
+
+// JobLog holds the log of a job and, when the archive has them, its steps
+type JobLog struct {
+	Name    string     `json:"name"`
+	Content string     `json:"content,omitempty"`
+	Steps   []*StepLog `json:"steps,omitempty"`
+}
+
+// StepLog holds the log of a single step
+type StepLog struct {
+	Number  int    `json:"number"`
+	Name    string `json:"name"`
+	Content string `json:"content"`
+}
+
+// LogExcerpt is the interesting part of a log: its error annotations and
+// its last lines
+type LogExcerpt struct {
+	Errors []string `json:"errors"`
+	Tail   []string `json:"tail"`
+
+	// OmittedLines counts the lines before the tail that were left out
+	OmittedLines int `json:"omitted_lines"`
+}
+
+// ListWorkflowJobs lists the jobs of the latest attempt of a workflow run
+func (c *Client) ListWorkflowJobs(ctx context.Context, owner, repo string, runID int64, opts *ListOptions) ([]*WorkflowJob, int, error) {
+	return c.listWorkflowJobs(ctx, addListOptions(fmt.Sprintf("repos/%s/%s/actions/runs/%d/jobs", owner, repo, runID), opts))
+}
+
+// ListWorkflowJobAttempts lists the jobs of every attempt of a workflow run
+func (c *Client) ListWorkflowJobAttempts(ctx context.Context, owner, repo string, runID int64, opts *ListOptions) ([]*WorkflowJob, int, error) {
+	return c.listWorkflowJobs(ctx, addListOptions(fmt.Sprintf("repos/%s/%s/actions/runs/%d/jobs?filter=all", owner, repo, runID), opts))
+}
+
+// listWorkflowJobs lists a page of workflow jobs
+func (c *Client) listWorkflowJobs(ctx context.Context, url string) ([]*WorkflowJob, int, error) {
+	req, err := c.newRequest(ctx, "GET", url, nil)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	resp, err := c.do(req)
+	if err != nil {
+		return nil, 0, err
+	}
+	defer resp.Body.Close()
+
+	var response struct {
+		TotalCount int            `json:"total_count"`
+		Jobs       []*WorkflowJob `json:"jobs"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&response); err != nil {
+		return nil, 0, fmt.Errorf("failed to decode response: %w", err)
+	}
+
+	return response.Jobs, response.TotalCount, nil
+}
+
+// ListAllWorkflowJobs lists every job of a workflow run, following pagination
+func (c *Client) ListAllWorkflowJobs(ctx context.Context, owner, repo string, runID int64) ([]*WorkflowJob, error) {
+	var jobs []*WorkflowJob
+	for page := 1; ; page++ {
+		pageJobs, total, err := c.ListWorkflowJobs(ctx, owner, repo, runID, &ListOptions{Page: page, PerPage: 100})
+		if err != nil {
+			return nil, err
+		}
+		jobs = append(jobs, pageJobs...)
+		if len(pageJobs) == 0 || len(jobs) >= total {
+			return jobs, nil
+		}
+	}
+}
+
+// ListAllWorkflowJobAttempts lists the jobs of every attempt of a workflow
+// run, following pagination
+func (c *Client) ListAllWorkflowJobAttempts(ctx context.Context, owner, repo string, runID int64) ([]*WorkflowJob, error) {
+	var jobs []*WorkflowJob
+	for page := 1; ; page++ {
+		pageJobs, total, err := c.ListWorkflowJobAttempts(ctx, owner, repo, runID, &ListOptions{Page: page, PerPage: 100})
+		if err != nil {
+			return nil, err
+		}
+		jobs = append(jobs, pageJobs...)
+		if len(pageJobs) == 0 || len(jobs) >= total {
+			return jobs, nil
+		}
+	}
+}
+
+// DownloadWorkflowRunLogs downloads the zip archive with the logs of a
+// workflow run
+func (c *Client) DownloadWorkflowRunLogs(ctx context.Context, owner, repo string, runID int64) ([]byte, error) {
+	return c.download(ctx, fmt.Sprintf("repos/%s/%s/actions/runs/%d/logs", owner, repo, runID), maxLogSize)
+}
+
+// DownloadJobLogs downloads the plain text log of a workflow job
+func (c *Client) DownloadJobLogs(ctx context.Context, owner, repo string, jobID int64) (string, error) {
+	data, err := c.download(ctx, fmt.Sprintf("repos/%s/%s/actions/jobs/%d/logs", owner, repo, jobID), maxLogSize)
+	if err != nil {
+		return "", err
+	}
+	return string(data), nil
+}
+
+// download reads a response body of at most maxSize bytes. GitHub answers
+// download endpoints with a redirect to short-lived storage, which the HTTP
+// client follows without forwarding the token.
+func (c *Client) download(ctx context.Context, url string, maxSize int64) ([]byte, error) {
+	req, err := c.newRequest(ctx, "GET", url, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := c.do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	data, err := io.ReadAll(io.LimitReader(resp.Body, maxSize+1))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read download: %w", err)
+	}
+	if int64(len(data)) > maxSize {
+		return nil, fmt.Errorf("download exceeds %d bytes", maxSize)
+	}
+
+	return data, nil
+}
+
+// ParseRunLogs splits a run log archive into jobs and steps. The archive
+// holds one "<n>_<job>.txt" file per job and, for most runs, a "<job>/"
+// directory with one "<number>_<step>.txt" file per step. The logs may
+// not exceed maxLogSize in total once decompressed.
+func ParseRunLogs(data []byte) (*RunLogs, error) {
+	return parseRunLogs(data, maxLogSize)
+}
+
+// parseRunLogs parses a run log archive holding at most maxSize bytes of
+// logs
+func parseRunLogs(data []byte, maxSize int64) (*RunLogs, error) {
+	archive, err := zip.NewReader(bytes.NewReader(data), int64(len(data)))
+	if err != nil {
+		return nil, fmt.Errorf("failed to open log archive: %w", err)
+	}
+
+	jobs := make(map[string]*JobLog)
+	job := func(name string) *JobLog {
+		if jobs[name] == nil {
+			jobs[name] = &JobLog{Name: name}
+		}
+		return jobs[name]
+	}
+
+	for _, file := range archive.File {
+		if file.FileInfo().IsDir() || !strings.HasSuffix(file.Name, ".txt") {
+			continue
+		}
+
+		content, err := readZipFile(file, maxSize)
+		if err != nil {
+			return nil, err
+		}
+		maxSize -= int64(len(content))
+
+		dir, base := path.Split(file.Name)
+		number, name := splitLogFileName(base)
+		if dir == "" {
+			job(name).Content = content
+			continue
+		}
+		jobLog := job(strings.TrimSuffix(dir, "/"))
+		jobLog.Steps = append(jobLog.Steps, &StepLog{Number: number, Name: name, Content: content})
+	}
+
+	logs := &RunLogs{}
+	for _, jobLog := range jobs {
+		sort.Slice(jobLog.Steps, func(i, j int) bool {
+			return jobLog.Steps[i].Number < jobLog.Steps[j].Number
+		})
+		logs.Jobs = append(logs.Jobs, jobLog)
+	}
+	sort.Slice(logs.Jobs, func(i, j int) bool {
+		return logs.Jobs[i].Name < logs.Jobs[j].Name
+	})
+
+	return logs, nil
+}
+
+// Job returns the log of the job with the given name, or nil. File names in
+// the archive replace characters that are not allowed in paths.
+func (l *RunLogs) Job(name string) *JobLog {
+	sanitized := sanitizeLogName(name)
+	for _, job := range l.Jobs {
+		if job.Name == name || job.Name == sanitized {
+			return job
+		}
+	}
+	return nil
+}
+
+// Step returns the log of the step with the given number, or nil
+func (j *JobLog) Step(number int) *StepLog {
+	for _, step := range j.Steps {
+		if step.Number == number {
+			return step
+		}
+	}
+	return nil
+}
+
+// ExcerptLog extracts the ##[error] lines and the last tailLines lines of
+// a log, without timestamps, within maxBytes. Errors get at most half of
+// the budget; the tail is cut from the front to fit the rest.
+func ExcerptLog(content string, tailLines, maxBytes int) *LogExcerpt {
+	excerpt := &LogExcerpt{Errors: []string{}, Tail: []string{}}
+
+	var lines []string
+	for _, line := range strings.Split(strings.TrimRight(content, "\n"), "\n") {
+		line = strings.TrimRight(logTimestamp.ReplaceAllString(line, ""), "\r")
+		if line == "##[endgroup]" {
+			continue
+		}
+		lines = append(lines, line)
+	}
+
+	used := 0
+	for _, line := range lines {
+		i := strings.Index(line, "##[error]")
+		if i < 0 {
+			continue
+		}
+		message := line[i+len("##[error]"):]
+		if used+len(message) > maxBytes/2 {
+			break
+		}
+		excerpt.Errors = append(excerpt.Errors, message)
+		used += len(message)
+	}
+
+	start := len(lines) - tailLines
+	if start < 0 {
+		start = 0
+	}
+	if start > len(lines) {
+		start = len(lines)
+	}
+	for start < len(lines) && used+linesSize(lines[start:]) > maxBytes {
+		start++
+	}
+	excerpt.Tail = append(excerpt.Tail, lines[start:]...)
+	excerpt.OmittedLines = start
+
+	return excerpt
+}
+
+// linesSize returns the number of bytes of lines joined by newlines
+func linesSize(lines []string) int {
+	size := 0
+	for _, line := range lines {
+		size += len(line) + 1
+	}
+	return size
+}
+
+// readZipFile reads a file from a zip archive, failing if it holds more
+// than maxSize bytes
+func readZipFile(file *zip.File, maxSize int64) (string, error) {
+	reader, err := file.Open()
+	if err != nil {
+		return "", fmt.Errorf("failed to open %s: %w", file.Name, err)
+	}
+	defer reader.Close()
+
+	data, err := io.ReadAll(io.LimitReader(reader, maxSize+1))
+	if err != nil {
+		return "", fmt.Errorf("failed to read %s: %w", file.Name, err)
+	}
+	if int64(len(data)) > maxSize {
+		return "", fmt.Errorf("log archive exceeds the size limit when decompressed")
+	}
+	return string(data), nil
+}
+
+// splitLogFileName splits "<number>_<name>.txt" into its number and name
+func splitLogFileName(base string) (int, string) {
+	base = strings.TrimSuffix(base, ".txt")
+	prefix, name, ok := strings.Cut(base, "_")
+	if !ok {
+		return 0, base
+	}
+	number, err := strconv.Atoi(prefix)
+	if err != nil {
+		return 0, base
+	}
+	return number, name
+}
+
+// sanitizeLogName replaces the characters GitHub strips from log file names
+func sanitizeLogName(name string) string {
+	return strings.NewReplacer("/", "", ":", "", "<", "", ">", "", "|", "", "*", "", "?", "", "\"", "").Replace(name)
+}
@@ -0,0 +1,138 @@
+package github
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+// buildLogArchive creates a run log archive from file names and contents
+func buildLogArchive(t *testing.T, files map[string]string) []byte {
+	t.Helper()
+
+	var buf bytes.Buffer
+	w := zip.NewWriter(&buf)
+	for name, content := range files {
+		f, err := w.Create(name)
+		if err != nil {
+			t.Fatalf("Failed to create %s: %v", name, err)
+		}
+		if _, err := f.Write([]byte(content)); err != nil {
+			t.Fatalf("Failed to write %s: %v", name, err)
+		}
+	}
+	if err := w.Close(); err != nil {
+		t.Fatalf("Failed to close archive: %v", err)
+	}
+	return buf.Bytes()
+}
+
+func TestParseRunLogs(t *testing.T) {
+	data := buildLogArchive(t, map[string]string{
+		"0_build.txt":              "whole build log\n",
+		"build/1_Set up job.txt":   "setting up\n",
+		"build/10_Run go test.txt": "2024-05-01T10:00:00.1234567Z --- FAIL: TestX\n",
+		"build/2_Checkout.txt":     "checking out\n",
+		"1_lint (ubuntu).txt":      "lint log\n",
+	})
+
+	logs, err := ParseRunLogs(data)
+	if err != nil {
+		t.Fatalf("ParseRunLogs failed: %v", err)
+	}
+	if len(logs.Jobs) != 2 {
+		t.Fatalf("Expected 2 jobs, got %d", len(logs.Jobs))
+	}
+
+	build := logs.Job("build")
+	if build == nil || build.Content != "whole build log\n" || len(build.Steps) != 3 {
+		t.Fatalf("Unexpected build job: %+v", build)
+	}
+	if build.Steps[2].Number != 10 || build.Steps[2].Name != "Run go test" {
+		t.Errorf("Expected steps sorted by number, got %+v", build.Steps[2])
+	}
+	if step := build.Step(2); step == nil || step.Content != "checking out\n" {
+		t.Errorf("Unexpected step 2: %+v", step)
+	}
+	if lint := logs.Job("lint (ubuntu)"); lint == nil || len(lint.Steps) != 0 {
+		t.Errorf("Unexpected lint job: %+v", lint)
+	}
+}
+
+func TestParseRunLogs_SizeLimit(t *testing.T) {
+	// Each file fits the budget on its own, but not together
+	data := buildLogArchive(t, map[string]string{
+		"0_build.txt":   strings.Repeat("a", 600),
+		"1_lint.txt":    strings.Repeat("b", 600),
+		"build/1_x.txt": strings.Repeat("c", 600),
+	})
+
+	if _, err := parseRunLogs(data, 2000); err != nil {
+		t.Fatalf("Expected the logs to fit, got %v", err)
+	}
+	if _, err := parseRunLogs(data, 1000); err == nil || !strings.Contains(err.Error(), "size limit") {
+		t.Errorf("Expected the total size limit to be enforced, got %v", err)
+	}
+}
+
+func TestExcerptLog(t *testing.T) {
+	var lines []string
+	for i := 0; i < 100; i++ {
+		lines = append(lines, "2024-05-01T10:00:00.1234567Z line")
+	}
+	lines[40] = "2024-05-01T10:00:00.1234567Z ##[error]main.go:3: undefined: x"
+	lines = append(lines, "##[endgroup]", "##[error]Process completed with exit code 1.")
+	content := strings.Join(lines, "\n") + "\n"
+
+	excerpt := ExcerptLog(content, 10, 1000)
+	if len(excerpt.Errors) != 2 || excerpt.Errors[0] != "main.go:3: undefined: x" {
+		t.Errorf("Unexpected errors: %q", excerpt.Errors)
+	}
+	if len(excerpt.Tail) != 10 || excerpt.OmittedLines != 91 || excerpt.Tail[0] != "line" {
+		t.Errorf("Unexpected tail: %d lines, %d omitted, first %q", len(excerpt.Tail), excerpt.OmittedLines, excerpt.Tail[0])
+	}
+
+	// A small budget shortens the tail from the front
+	excerpt = ExcerptLog(content, 10, 100)
+	if len(excerpt.Errors) != 1 {
+		t.Errorf("Expected errors to be limited to half the budget, got %q", excerpt.Errors)
+	}
+	if size := linesSize(excerpt.Tail) + len(excerpt.Errors[0]); size > 100 {
+		t.Errorf("Excerpt exceeds budget: %d bytes", size)
+	}
+	if excerpt.Tail[len(excerpt.Tail)-1] != "##[error]Process completed with exit code 1." {
+		t.Errorf("Expected the tail to end with the last line, got %q", excerpt.Tail)
+	}
+
+	// A negative tail is treated as no tail
+	excerpt = ExcerptLog("one\ntwo\n", -3, 1000)
+	if len(excerpt.Tail) != 0 || excerpt.OmittedLines != 2 {
+		t.Errorf("Expected an empty tail, got %q with %d omitted", excerpt.Tail, excerpt.OmittedLines)
+	}
+}
+
+func TestDownloadWorkflowRunLogs(t *testing.T) {
+	archive := buildLogArchive(t, map[string]string{"0_build.txt": "log\n"})
+
+	client := setupTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/repos/octo/hello/actions/runs/42/logs":
+			http.Redirect(w, r, "/blob/logs.zip", http.StatusFound)
+		case "/blob/logs.zip":
+			w.Write(archive)
+		default:
+			t.Errorf("Unexpected request path %s", r.URL.Path)
+		}
+	})
+
+	data, err := client.DownloadWorkflowRunLogs(context.Background(), "octo", "hello", 42)
+	if err != nil {
+		t.Fatalf("DownloadWorkflowRunLogs failed: %v", err)
+	}
+	if !bytes.Equal(data, archive) {
+		t.Error("Downloaded archive does not match")
+	}
+}
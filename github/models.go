@@ -10,6 +10,7 @@ type Repository struct {
 	Description   string    `json:"description"`
 	Private       bool      `json:"private"`
 	Fork          bool      `json:"fork"`
+	Archived      bool      `json:"archived"`
 	HTMLURL       string    `json:"html_url"`
 	CloneURL      string    `json:"clone_url"`
 	DefaultBranch string    `json:"default_branch"`
@@ -86,6 +87,7 @@ type PullRequestBranch struct {
 // PullRequest represents a GitHub pull request
 type PullRequest struct {
 	ID        int64             `json:"id"`
+	NodeID    string            `json:"node_id"`
 	Number    int               `json:"number"`
 	Title     string            `json:"title"`
 	State     string            `json:"state"`
@@ -97,6 +99,54 @@ type PullRequest struct {
 	MergedAt  *time.Time        `json:"merged_at,omitempty"`
 	CreatedAt time.Time         `json:"created_at"`
 	UpdatedAt time.Time         `json:"updated_at"`
+
+	// Draft is true for draft pull requests
+	Draft bool `json:"draft"`
+
+	// Mergeable is nil while GitHub is still computing mergeability
+	Mergeable *bool `json:"mergeable"`
+
+	// MergeableState summarizes whether the pull request can be merged
+	// (clean, unstable, blocked, behind, dirty, draft or unknown)
+	MergeableState string `json:"mergeable_state"`
+
+	// AutoMerge is set while auto-merge is enabled
+	AutoMerge *AutoMerge `json:"auto_merge,omitempty"`
+
+	RequestedReviewers []User          `json:"requested_reviewers,omitempty"`
+	RequestedTeams     []RequestedTeam `json:"requested_teams,omitempty"`
+}
+
+// RequestedTeam is a team requested to review a pull request
+type RequestedTeam struct {
+	ID   int64  `json:"id"`
+	Name string `json:"name"`
+	Slug string `json:"slug"`
+}
+
+// AutoMerge describes the auto-merge settings of a pull request
+type AutoMerge struct {
+	EnabledBy     User   `json:"enabled_by"`
+	MergeMethod   string `json:"merge_method"`
+	CommitTitle   string `json:"commit_title"`
+	CommitMessage string `json:"commit_message"`
+}
+
+// MergePullRequestOptions represents parameters for merging a pull request
+type MergePullRequestOptions struct {
+	CommitTitle   string `json:"commit_title,omitempty"`
+	CommitMessage string `json:"commit_message,omitempty"`
+	MergeMethod   string `json:"merge_method,omitempty"`
+
+	// SHA must match the pull request head for the merge to succeed
+	SHA string `json:"sha,omitempty"`
+}
+
+// MergeResult represents the result of merging a pull request
+type MergeResult struct {
+	SHA     string `json:"sha"`
+	Merged  bool   `json:"merged"`
+	Message string `json:"message"`
 }
 
 // User represents a GitHub user
@@ -136,6 +186,7 @@ type WorkflowRun struct {
 	HeadBranch  string     `json:"head_branch"`
 	HeadSHA     string     `json:"head_sha"`
 	RunNumber   int        `json:"run_number"`
+	RunAttempt  int        `json:"run_attempt"`
 	Event       string     `json:"event"`
 	HTMLURL     string     `json:"html_url"`
 	CreatedAt   time.Time  `json:"created_at"`
@@ -143,6 +194,48 @@ type WorkflowRun struct {
 	CompletedAt *time.Time `json:"completed_at,omitempty"`
 }
 
+// WorkflowJob represents a job of a workflow run
+type WorkflowJob struct {
+	ID          int64           `json:"id"`
+	RunID       int64           `json:"run_id"`
+	RunAttempt  int             `json:"run_attempt"`
+	Name        string          `json:"name"`
+	Status      string          `json:"status"`
+	Conclusion  string          `json:"conclusion"`
+	HTMLURL     string          `json:"html_url"`
+	RunnerName  string          `json:"runner_name"`
+	StartedAt   *time.Time      `json:"started_at,omitempty"`
+	CompletedAt *time.Time      `json:"completed_at,omitempty"`
+	Steps       []*WorkflowStep `json:"steps"`
+}
+
+// WorkflowStep represents a step of a workflow job
+type WorkflowStep struct {
+	Number      int        `json:"number"`
+	Name        string     `json:"name"`
+	Status      string     `json:"status"`
+	Conclusion  string     `json:"conclusion"`
+	StartedAt   *time.Time `json:"started_at,omitempty"`
+	CompletedAt *time.Time `json:"completed_at,omitempty"`
+}
+
+// Artifact represents a workflow run artifact
+type Artifact struct {
+	ID                 int64      `json:"id"`
+	Name               string     `json:"name"`
+	SizeInBytes        int64      `json:"size_in_bytes"`
+	Expired            bool       `json:"expired"`
+	ArchiveDownloadURL string     `json:"archive_download_url"`
+	CreatedAt          time.Time  `json:"created_at"`
+	ExpiresAt          *time.Time `json:"expires_at,omitempty"`
+}
+
+// ListOptions represents pagination options for list endpoints
+type ListOptions struct {
+	Page    int `json:"page,omitempty"`
+	PerPage int `json:"per_page,omitempty"`
+}
+
 // ListIssuesOptions represents options for listing issues
 type ListIssuesOptions struct {
 	State   string   `json:"state,omitempty"`
@@ -151,6 +244,19 @@ type ListIssuesOptions struct {
 	PerPage int      `json:"per_page,omitempty"`
 }
 
+// ListWorkflowRunsOptions represents filters for listing workflow runs
+type ListWorkflowRunsOptions struct {
+	Actor  string `json:"actor,omitempty"`
+	Branch string `json:"branch,omitempty"`
+	Event  string `json:"event,omitempty"`
+	Status string `json:"status,omitempty"`
+
+	// Created filters by creation date, e.g. ">=2024-05-01T10:00:00Z"
+	Created string `json:"created,omitempty"`
+
+	ListOptions
+}
+
 // ListPullRequestsOptions represents options for listing pull requests
 type ListPullRequestsOptions struct {
 	State   string `json:"state,omitempty"`
@@ -0,0 +1,486 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// BranchProtection holds the classic protection settings of a branch. It
+// marshals to the body of the update endpoint; nil sections are disabled.
+type BranchProtection struct {
+	RequiredStatusChecks *RequiredStatusChecks `json:"required_status_checks"`
+	RequiredReviews      *RequiredReviews      `json:"required_pull_request_reviews"`
+	Restrictions         *PushRestrictions     `json:"restrictions"`
+
+	EnforceAdmins                 bool `json:"enforce_admins"`
+	RequireLinearHistory          bool `json:"required_linear_history"`
+	AllowForcePushes              bool `json:"allow_force_pushes"`
+	AllowDeletions                bool `json:"allow_deletions"`
+	RequireConversationResolution bool `json:"required_conversation_resolution"`
+	LockBranch                    bool `json:"lock_branch"`
+	BlockCreations                bool `json:"block_creations"`
+	AllowForkSyncing              bool `json:"allow_fork_syncing"`
+}
+
+// RequiredStatusChecks lists the checks that must pass before merging
+type RequiredStatusChecks struct {
+	// Strict requires branches to be up to date with the base before merging
+	Strict   bool     `json:"strict"`
+	Contexts []string `json:"contexts"`
+
+	// Checks pins contexts to the GitHub App that must report them
+	Checks []*StatusCheck `json:"checks,omitempty"`
+}
+
+// StatusCheck is a required check, optionally pinned to a GitHub App
+type StatusCheck struct {
+	Context string `json:"context"`
+	AppID   *int64 `json:"app_id,omitempty"`
+}
+
+// RequiredReviews describes the reviews required before merging
+type RequiredReviews struct {
+	RequiredApprovingReviewCount int  `json:"required_approving_review_count"`
+	DismissStaleReviews          bool `json:"dismiss_stale_reviews"`
+	RequireCodeOwnerReviews      bool `json:"require_code_owner_reviews"`
+	RequireLastPushApproval      bool `json:"require_last_push_approval"`
+
+	// DismissalRestrictions limits who can dismiss reviews, and
+	// BypassAllowances who can merge without them
+	DismissalRestrictions *Actors `json:"dismissal_restrictions,omitempty"`
+	BypassAllowances      *Actors `json:"bypass_pull_request_allowances,omitempty"`
+}
+
+// Actors lists users by login and teams and apps by slug
+type Actors struct {
+	Users []string `json:"users"`
+	Teams []string `json:"teams"`
+	Apps  []string `json:"apps"`
+}
+
+// PushRestrictions limits who can push to a branch
+type PushRestrictions = Actors
+
+// ProtectionChange is a setting that differs between two protections
+type ProtectionChange struct {
+	Setting string `json:"setting"`
+	Current string `json:"current"`
+	Desired string `json:"desired"`
+}
+
+// branchProtectionResponse is the shape GitHub returns protection in
+type branchProtectionResponse struct {
+	RequiredStatusChecks *struct {
+		Strict   bool           `json:"strict"`
+		Contexts []string       `json:"contexts"`
+		Checks   []*StatusCheck `json:"checks"`
+	} `json:"required_status_checks"`
+	RequiredReviews *struct {
+		RequiredReviews
+		DismissalRestrictions *actorsResponse `json:"dismissal_restrictions"`
+		BypassAllowances      *actorsResponse `json:"bypass_pull_request_allowances"`
+	} `json:"required_pull_request_reviews"`
+	Restrictions                  *actorsResponse `json:"restrictions"`
+	EnforceAdmins                 enabledSetting  `json:"enforce_admins"`
+	RequireLinearHistory          enabledSetting  `json:"required_linear_history"`
+	AllowForcePushes              enabledSetting  `json:"allow_force_pushes"`
+	AllowDeletions                enabledSetting  `json:"allow_deletions"`
+	RequireConversationResolution enabledSetting  `json:"required_conversation_resolution"`
+	LockBranch                    enabledSetting  `json:"lock_branch"`
+	BlockCreations                enabledSetting  `json:"block_creations"`
+	AllowForkSyncing              enabledSetting  `json:"allow_fork_syncing"`
+}
+
+// actorsResponse is the shape GitHub returns users, teams and apps in
+type actorsResponse struct {
+	Users []struct {
+		Login string `json:"login"`
+	} `json:"users"`
+	Teams []struct {
+		Slug string `json:"slug"`
+	} `json:"teams"`
+	Apps []struct {
+		Slug string `json:"slug"`
+	} `json:"apps"`
+}
+
+// actors converts an actor list response to logins and slugs
+func (r *actorsResponse) actors() *Actors {
+	if r == nil {
+		return nil
+	}
+	actors := &Actors{Users: []string{}, Teams: []string{}, Apps: []string{}}
+	for _, user := range r.Users {
+		actors.Users = append(actors.Users, user.Login)
+	}
+	for _, team := range r.Teams {
+		actors.Teams = append(actors.Teams, team.Slug)
+	}
+	for _, app := range r.Apps {
+		actors.Apps = append(actors.Apps, app.Slug)
+	}
+	return actors
+}
+
+// protectionRequest is the body of the update endpoint. Status checks are
+// sent as checks, which keep the app each one is pinned to.
+type protectionRequest struct {
+	*BranchProtection
+	RequiredStatusChecks *statusChecksRequest `json:"required_status_checks"`
+}
+
+// statusChecksRequest is the status check section of an update
+type statusChecksRequest struct {
+	Strict bool           `json:"strict"`
+	Checks []*StatusCheck `json:"checks"`
+}
+
+// appIDs maps the contexts pinned to an app to the app's ID
+func (c *RequiredStatusChecks) appIDs() map[string]*int64 {
+	apps := make(map[string]*int64)
+	for _, check := range c.Checks {
+		apps[check.Context] = check.AppID
+	}
+	return apps
+}
+
+// request builds the update body for a protection
+func (p *BranchProtection) request() *protectionRequest {
+	request := &protectionRequest{BranchProtection: p}
+	if checks := p.RequiredStatusChecks; checks != nil {
+		apps := checks.appIDs()
+		request.RequiredStatusChecks = &statusChecksRequest{Strict: checks.Strict, Checks: []*StatusCheck{}}
+		for _, context := range checks.Contexts {
+			request.RequiredStatusChecks.Checks = append(request.RequiredStatusChecks.Checks, &StatusCheck{Context: context, AppID: apps[context]})
+		}
+	}
+	return request
+}
+
+// enabledSetting is a protection setting returned as {"enabled": bool}
+type enabledSetting struct {
+	Enabled bool `json:"enabled"`
+}
+
+// GetBranchProtection gets the protection of a branch. GitHub answers 404
+// for branches that are not protected.
+func (c *Client) GetBranchProtection(ctx context.Context, owner, repo, branch string) (*BranchProtection, error) {
+	url := fmt.Sprintf("repos/%s/%s/branches/%s/protection", owner, repo, branch)
+
+	req, err := c.newRequest(ctx, "GET", url, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := c.do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	var response branchProtectionResponse
+	if err := json.NewDecoder(resp.Body).Decode(&response); err != nil {
+		return nil, fmt.Errorf("failed to decode response: %w", err)
+	}
+
+	return response.normalize(), nil
+}
+
+// UpdateBranchProtection replaces the protection of a branch
+func (c *Client) UpdateBranchProtection(ctx context.Context, owner, repo, branch string, protection *BranchProtection) (*BranchProtection, error) {
+	url := fmt.Sprintf("repos/%s/%s/branches/%s/protection", owner, repo, branch)
+
+	req, err := c.newRequest(ctx, "PUT", url, protection.request())
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := c.do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	var response branchProtectionResponse
+	if err := json.NewDecoder(resp.Body).Decode(&response); err != nil {
+		return nil, fmt.Errorf("failed to decode response: %w", err)
+	}
+
+	return response.normalize(), nil
+}
+
+// normalize converts a protection response to the update shape
+func (r *branchProtectionResponse) normalize() *BranchProtection {
+	protection := &BranchProtection{
+		Restrictions:                  r.Restrictions.actors(),
+		EnforceAdmins:                 r.EnforceAdmins.Enabled,
+		RequireLinearHistory:          r.RequireLinearHistory.Enabled,
+		AllowForcePushes:              r.AllowForcePushes.Enabled,
+		AllowDeletions:                r.AllowDeletions.Enabled,
+		RequireConversationResolution: r.RequireConversationResolution.Enabled,
+		LockBranch:                    r.LockBranch.Enabled,
+		BlockCreations:                r.BlockCreations.Enabled,
+		AllowForkSyncing:              r.AllowForkSyncing.Enabled,
+	}
+
+	if reviews := r.RequiredReviews; reviews != nil {
+		protection.RequiredReviews = &reviews.RequiredReviews
+		protection.RequiredReviews.DismissalRestrictions = reviews.DismissalRestrictions.actors()
+		protection.RequiredReviews.BypassAllowances = reviews.BypassAllowances.actors()
+	}
+
+	if checks := r.RequiredStatusChecks; checks != nil {
+		protection.RequiredStatusChecks = &RequiredStatusChecks{Strict: checks.Strict, Contexts: []string{}}
+		seen := make(map[string]bool)
+		for _, context := range checks.Contexts {
+			if !seen[context] {
+				protection.RequiredStatusChecks.Contexts = append(protection.RequiredStatusChecks.Contexts, context)
+				seen[context] = true
+			}
+		}
+		for _, check := range checks.Checks {
+			if !seen[check.Context] {
+				protection.RequiredStatusChecks.Contexts = append(protection.RequiredStatusChecks.Contexts, check.Context)
+				seen[check.Context] = true
+			}
+			if check.AppID != nil {
+				protection.RequiredStatusChecks.Checks = append(protection.RequiredStatusChecks.Checks, check)
+			}
+		}
+	}
+
+	return protection
+}
+
+// DiffBranchProtection lists the settings that differ between the current
+// and the desired protection of a branch. A nil protection means the branch
+// is not protected.
+func DiffBranchProtection(current, desired *BranchProtection) []*ProtectionChange {
+	return diffSettings(current.settings(), desired.settings())
+}
+
+// diffSettings lists the settings whose values differ, by setting name. A
+// setting missing from one side is shown as "absent".
+func diffSettings(current, desired map[string]string) []*ProtectionChange {
+	names := make([]string, 0, len(current))
+	for name := range current {
+		names = append(names, name)
+	}
+	for name := range desired {
+		if _, ok := current[name]; !ok {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+
+	changes := []*ProtectionChange{}
+	for _, name := range names {
+		currentValue, ok := current[name]
+		if !ok {
+			currentValue = "absent"
+		}
+		desiredValue, ok := desired[name]
+		if !ok {
+			desiredValue = "absent"
+		}
+		if currentValue != desiredValue {
+			changes = append(changes, &ProtectionChange{Setting: name, Current: currentValue, Desired: desiredValue})
+		}
+	}
+	return changes
+}
+
+// settings flattens a protection into comparable values; every protection,
+// including nil, has the same keys
+func (p *BranchProtection) settings() map[string]string {
+	protected := p != nil
+	if p == nil {
+		p = &BranchProtection{}
+	}
+
+	settings := map[string]string{
+		"protected":                        strconv.FormatBool(protected),
+		"enforce_admins":                   strconv.FormatBool(p.EnforceAdmins),
+		"required_linear_history":          strconv.FormatBool(p.RequireLinearHistory),
+		"allow_force_pushes":               strconv.FormatBool(p.AllowForcePushes),
+		"allow_deletions":                  strconv.FormatBool(p.AllowDeletions),
+		"required_conversation_resolution": strconv.FormatBool(p.RequireConversationResolution),
+		"lock_branch":                      strconv.FormatBool(p.LockBranch),
+		"block_creations":                  strconv.FormatBool(p.BlockCreations),
+		"allow_fork_syncing":               strconv.FormatBool(p.AllowForkSyncing),
+		"required_reviews":                 "off",
+		"required_status_checks":           "off",
+		"push_restrictions":                "off",
+	}
+
+	if reviews := p.RequiredReviews; reviews != nil {
+		settings["required_reviews"] = fmt.Sprintf("%d approvals, dismiss stale %t, code owners %t, last push approval %t",
+			reviews.RequiredApprovingReviewCount, reviews.DismissStaleReviews,
+			reviews.RequireCodeOwnerReviews, reviews.RequireLastPushApproval)
+		if reviews.DismissalRestrictions != nil {
+			settings["required_reviews"] += ", dismissal by " + reviews.DismissalRestrictions.String()
+		}
+		if reviews.BypassAllowances != nil {
+			settings["required_reviews"] += ", bypass by " + reviews.BypassAllowances.String()
+		}
+	}
+	if checks := p.RequiredStatusChecks; checks != nil {
+		contexts := make([]string, 0, len(checks.Contexts))
+		apps := checks.appIDs()
+		for _, context := range checks.Contexts {
+			if app := apps[context]; app != nil {
+				context = fmt.Sprintf("%s (app %d)", context, *app)
+			}
+			contexts = append(contexts, context)
+		}
+		settings["required_status_checks"] = fmt.Sprintf("[%s], strict %t", sortedList(contexts), checks.Strict)
+	}
+	if restrictions := p.Restrictions; restrictions != nil {
+		settings["push_restrictions"] = restrictions.String()
+	}
+
+	return settings
+}
+
+// String lists the actors for comparison and display
+func (a *Actors) String() string {
+	return fmt.Sprintf("users [%s], teams [%s], apps [%s]", sortedList(a.Users), sortedList(a.Teams), sortedList(a.Apps))
+}
+
+// sortedList joins a sorted copy of values with commas
+func sortedList(values []string) string {
+	sorted := append([]string(nil), values...)
+	sort.Strings(sorted)
+	return strings.Join(sorted, ", ")
+}
+
+// ProtectionPolicy is the protection an audit expects of a branch
+type ProtectionPolicy struct {
+	MinApprovals            int  `json:"min_approvals"`
+	RequireStatusChecks     bool `json:"require_status_checks"`
+	RequireCodeOwnerReviews bool `json:"require_code_owner_reviews"`
+	RequireEnforceAdmins    bool `json:"require_enforce_admins"`
+}
+
+// BranchAudit is the effective protection of a branch, combining classic
+// branch protection with the ruleset rules in force on it
+type BranchAudit struct {
+	Repository           string   `json:"repository"`
+	Branch               string   `json:"branch"`
+	Protected            bool     `json:"protected"`
+	RequiredApprovals    int      `json:"required_approvals"`
+	CodeOwnerReviews     bool     `json:"code_owner_reviews"`
+	DismissStaleReviews  bool     `json:"dismiss_stale_reviews"`
+	RequiredStatusChecks []string `json:"required_status_checks"`
+	EnforceAdmins        bool     `json:"enforce_admins"`
+	ForcePushesBlocked   bool     `json:"force_pushes_blocked"`
+	DeletionsBlocked     bool     `json:"deletions_blocked"`
+	PushRestricted       bool     `json:"push_restricted"`
+	RulesetRules         []string `json:"ruleset_rules,omitempty"`
+
+	// Issues lists how the branch falls short of the policy
+	Issues []string `json:"issues"`
+
+	// Error is set when the branch could not be audited
+	Error string `json:"error,omitempty"`
+}
+
+// AuditBranchProtection computes the effective protection of a branch and
+// checks it against a policy. protection is nil for branches without
+// classic protection.
+func AuditBranchProtection(repository, branch string, protection *BranchProtection, rules []*BranchRule, policy *ProtectionPolicy) *BranchAudit {
+	audit := &BranchAudit{
+		Repository:           repository,
+		Branch:               branch,
+		RequiredStatusChecks: []string{},
+		Issues:               []string{},
+	}
+	checks := make(map[string]bool)
+	addCheck := func(context string) {
+		if context != "" && !checks[context] {
+			checks[context] = true
+			audit.RequiredStatusChecks = append(audit.RequiredStatusChecks, context)
+		}
+	}
+
+	if protection != nil {
+		audit.Protected = true
+		audit.EnforceAdmins = protection.EnforceAdmins
+		audit.ForcePushesBlocked = !protection.AllowForcePushes
+		audit.DeletionsBlocked = !protection.AllowDeletions
+		audit.PushRestricted = protection.Restrictions != nil
+		if reviews := protection.RequiredReviews; reviews != nil {
+			audit.RequiredApprovals = reviews.RequiredApprovingReviewCount
+			audit.CodeOwnerReviews = reviews.RequireCodeOwnerReviews
+			audit.DismissStaleReviews = reviews.DismissStaleReviews
+		}
+		if protection.RequiredStatusChecks != nil {
+			for _, context := range protection.RequiredStatusChecks.Contexts {
+				addCheck(context)
+			}
+		}
+	}
+
+	for _, rule := range rules {
+		audit.Protected = true
+		audit.RulesetRules = append(audit.RulesetRules, rule.Type)
+		switch rule.Type {
+		case "non_fast_forward":
+			audit.ForcePushesBlocked = true
+		case "deletion":
+			audit.DeletionsBlocked = true
+		case "update":
+			audit.PushRestricted = true
+		case "pull_request":
+			if count, ok := rule.Parameters["required_approving_review_count"].(float64); ok && int(count) > audit.RequiredApprovals {
+				audit.RequiredApprovals = int(count)
+			}
+			if required, _ := rule.Parameters["require_code_owner_review"].(bool); required {
+				audit.CodeOwnerReviews = true
+			}
+			if dismiss, _ := rule.Parameters["dismiss_stale_reviews_on_push"].(bool); dismiss {
+				audit.DismissStaleReviews = true
+			}
+		case "required_status_checks":
+			required, _ := rule.Parameters["required_status_checks"].([]interface{})
+			for _, check := range required {
+				if check, ok := check.(map[string]interface{}); ok {
+					context, _ := check["context"].(string)
+					addCheck(context)
+				}
+			}
+		}
+	}
+
+	if !audit.Protected {
+		audit.Issues = append(audit.Issues, "branch is not protected")
+		return audit
+	}
+	if policy == nil {
+		return audit
+	}
+	if audit.RequiredApprovals < policy.MinApprovals {
+		audit.Issues = append(audit.Issues, fmt.Sprintf("requires %d approvals, policy requires %d", audit.RequiredApprovals, policy.MinApprovals))
+	}
+	if policy.RequireStatusChecks && len(audit.RequiredStatusChecks) == 0 {
+		audit.Issues = append(audit.Issues, "no required status checks")
+	}
+	if policy.RequireCodeOwnerReviews && !audit.CodeOwnerReviews {
+		audit.Issues = append(audit.Issues, "code owner reviews are not required")
+	}
+	if policy.RequireEnforceAdmins && !audit.EnforceAdmins {
+		audit.Issues = append(audit.Issues, "administrators can bypass protection")
+	}
+	if !audit.ForcePushesBlocked {
+		audit.Issues = append(audit.Issues, "force pushes are allowed")
+	}
+	if !audit.DeletionsBlocked {
+		audit.Issues = append(audit.Issues, "branch can be deleted")
+	}
+
+	return audit
+}
@@ -0,0 +1,187 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+func TestGetBranchProtection(t *testing.T) {
+	client := setupTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/repos/octo/hello/branches/main/protection" {
+			t.Errorf("Unexpected request path %s", r.URL.Path)
+		}
+		fmt.Fprint(w, `{
+			"required_status_checks": {"strict": true, "contexts": ["ci/build"], "checks": [{"context": "ci/build"}, {"context": "lint"}]},
+			"required_pull_request_reviews": {"required_approving_review_count": 2, "require_code_owner_reviews": true},
+			"restrictions": {"users": [{"login": "mona"}], "teams": [{"slug": "core"}], "apps": []},
+			"enforce_admins": {"enabled": true},
+			"allow_force_pushes": {"enabled": false}
+		}`)
+	})
+
+	protection, err := client.GetBranchProtection(context.Background(), "octo", "hello", "main")
+	if err != nil {
+		t.Fatalf("GetBranchProtection failed: %v", err)
+	}
+	if strings.Join(protection.RequiredStatusChecks.Contexts, ",") != "ci/build,lint" || !protection.RequiredStatusChecks.Strict {
+		t.Errorf("Unexpected status checks: %+v", protection.RequiredStatusChecks)
+	}
+	if protection.RequiredReviews.RequiredApprovingReviewCount != 2 || !protection.EnforceAdmins {
+		t.Errorf("Unexpected protection: %+v", protection)
+	}
+	if protection.Restrictions.Users[0] != "mona" || protection.Restrictions.Teams[0] != "core" {
+		t.Errorf("Unexpected restrictions: %+v", protection.Restrictions)
+	}
+}
+
+func TestUpdateBranchProtection_Body(t *testing.T) {
+	client := setupTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != "PUT" {
+			t.Errorf("Expected PUT, got %s", r.Method)
+		}
+		var body map[string]interface{}
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			t.Fatalf("Failed to decode body: %v", err)
+		}
+		if body["restrictions"] != nil || body["required_status_checks"] != nil {
+			t.Errorf("Expected null sections to be sent as null: %v", body)
+		}
+		if body["enforce_admins"] != true {
+			t.Errorf("Expected enforce_admins to be sent: %v", body)
+		}
+		fmt.Fprint(w, `{"enforce_admins": {"enabled": true}}`)
+	})
+
+	_, err := client.UpdateBranchProtection(context.Background(), "octo", "hello", "main", &BranchProtection{EnforceAdmins: true})
+	if err != nil {
+		t.Errorf("UpdateBranchProtection failed: %v", err)
+	}
+}
+
+func TestBranchProtection_RoundTrip(t *testing.T) {
+	var put map[string]interface{}
+	client := setupTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == "PUT" {
+			if err := json.NewDecoder(r.Body).Decode(&put); err != nil {
+				t.Fatalf("Failed to decode body: %v", err)
+			}
+		}
+		fmt.Fprint(w, `{
+			"required_status_checks": {"strict": true, "contexts": ["build", "lint"], "checks": [{"context": "build", "app_id": 15368}, {"context": "lint", "app_id": null}]},
+			"required_pull_request_reviews": {
+				"required_approving_review_count": 1,
+				"dismissal_restrictions": {"users": [{"login": "mona"}], "teams": [], "apps": []},
+				"bypass_pull_request_allowances": {"users": [], "teams": [{"slug": "release"}], "apps": [{"slug": "bot"}]}
+			},
+			"enforce_admins": {"enabled": true},
+			"lock_branch": {"enabled": true},
+			"block_creations": {"enabled": true},
+			"allow_fork_syncing": {"enabled": true}
+		}`)
+	})
+
+	ctx := context.Background()
+	protection, err := client.GetBranchProtection(ctx, "octo", "hello", "main")
+	if err != nil {
+		t.Fatalf("GetBranchProtection failed: %v", err)
+	}
+	if _, err := client.UpdateBranchProtection(ctx, "octo", "hello", "main", protection); err != nil {
+		t.Fatalf("UpdateBranchProtection failed: %v", err)
+	}
+
+	want := `{
+		"required_status_checks": {"strict": true, "checks": [{"context": "build", "app_id": 15368}, {"context": "lint"}]},
+		"required_pull_request_reviews": {
+			"required_approving_review_count": 1,
+			"dismiss_stale_reviews": false,
+			"require_code_owner_reviews": false,
+			"require_last_push_approval": false,
+			"dismissal_restrictions": {"users": ["mona"], "teams": [], "apps": []},
+			"bypass_pull_request_allowances": {"users": [], "teams": ["release"], "apps": ["bot"]}
+		},
+		"restrictions": null,
+		"enforce_admins": true,
+		"required_linear_history": false,
+		"allow_force_pushes": false,
+		"allow_deletions": false,
+		"required_conversation_resolution": false,
+		"lock_branch": true,
+		"block_creations": true,
+		"allow_fork_syncing": true
+	}`
+	var expected map[string]interface{}
+	if err := json.Unmarshal([]byte(want), &expected); err != nil {
+		t.Fatalf("Invalid expected body: %v", err)
+	}
+	got, _ := json.Marshal(put)
+	wantJSON, _ := json.Marshal(expected)
+	if string(got) != string(wantJSON) {
+		t.Errorf("Expected the update to keep every setting\n got: %s\nwant: %s", got, wantJSON)
+	}
+}
+
+func TestDiffBranchProtection(t *testing.T) {
+	current := &BranchProtection{
+		RequiredReviews: &RequiredReviews{RequiredApprovingReviewCount: 1},
+		RequiredStatusChecks: &RequiredStatusChecks{
+			Contexts: []string{"lint", "build"},
+		},
+	}
+	desired := &BranchProtection{
+		RequiredReviews: &RequiredReviews{RequiredApprovingReviewCount: 2},
+		RequiredStatusChecks: &RequiredStatusChecks{
+			Contexts: []string{"build", "lint"},
+		},
+		EnforceAdmins: true,
+	}
+
+	changes := DiffBranchProtection(current, desired)
+	if len(changes) != 2 || changes[0].Setting != "enforce_admins" || changes[1].Setting != "required_reviews" {
+		t.Fatalf("Unexpected changes: %+v", changes)
+	}
+
+	changes = DiffBranchProtection(nil, desired)
+	if changes[len(changes)-1].Setting != "required_status_checks" {
+		t.Errorf("Expected status checks to be added: %+v", changes)
+	}
+	found := false
+	for _, change := range changes {
+		if change.Setting == "protected" && change.Current == "false" && change.Desired == "true" {
+			found = true
+		}
+	}
+	if !found {
+		t.Errorf("Expected protected to change: %+v", changes)
+	}
+}
+
+func TestAuditBranchProtection(t *testing.T) {
+	policy := &ProtectionPolicy{MinApprovals: 2, RequireStatusChecks: true}
+
+	audit := AuditBranchProtection("octo/hello", "main", nil, nil, policy)
+	if audit.Protected || len(audit.Issues) != 1 {
+		t.Errorf("Expected an unprotected branch: %+v", audit)
+	}
+
+	rules := []*BranchRule{
+		{RulesetRule: RulesetRule{Type: "pull_request", Parameters: map[string]interface{}{"required_approving_review_count": float64(2)}}},
+		{RulesetRule: RulesetRule{Type: "required_status_checks", Parameters: map[string]interface{}{
+			"required_status_checks": []interface{}{map[string]interface{}{"context": "ci"}},
+		}}},
+		{RulesetRule: RulesetRule{Type: "non_fast_forward"}},
+		{RulesetRule: RulesetRule{Type: "deletion"}},
+	}
+	protection := &BranchProtection{RequiredReviews: &RequiredReviews{RequiredApprovingReviewCount: 1}, AllowForcePushes: true}
+
+	audit = AuditBranchProtection("octo/hello", "main", protection, rules, policy)
+	if audit.RequiredApprovals != 2 || !audit.ForcePushesBlocked || len(audit.RequiredStatusChecks) != 1 {
+		t.Errorf("Expected ruleset rules to tighten protection: %+v", audit)
+	}
+	if len(audit.Issues) != 0 {
+		t.Errorf("Expected no issues, got %v", audit.Issues)
+	}
+}
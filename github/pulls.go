@@ -4,7 +4,15 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"io"
 	"strconv"
+	"strings"
+)
+
+// Media types for requesting a pull request as a diff or patch
+const (
+	MediaTypeDiff  = "application/vnd.github.v3.diff"
+	MediaTypePatch = "application/vnd.github.v3.patch"
 )
 
 // GetPullRequest gets a pull request by number
@@ -124,28 +132,30 @@ func (c *Client) UpdatePullRequest(ctx context.Context, owner, repo string, numb
 }
 
 // MergePullRequest merges a pull request
-func (c *Client) MergePullRequest(ctx context.Context, owner, repo string, number int, message string, mergeMethod string) error {
+func (c *Client) MergePullRequest(ctx context.Context, owner, repo string, number int, opts *MergePullRequestOptions) (*MergeResult, error) {
 	url := fmt.Sprintf("repos/%s/%s/pulls/%d/merge", owner, repo, number)
 
-	merge := map[string]string{
-		"merge_method": mergeMethod,
-	}
-	if message != "" {
-		merge["commit_message"] = message
+	if opts == nil {
+		opts = &MergePullRequestOptions{}
 	}
 
-	request, err := c.newRequest(ctx, "PUT", url, merge)
+	request, err := c.newRequest(ctx, "PUT", url, opts)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
 	resp, err := c.do(request)
 	if err != nil {
-		return err
+		return nil, err
 	}
 	defer resp.Body.Close()
 
-	return nil
+	var result MergeResult
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return nil, fmt.Errorf("failed to decode response: %w", err)
+	}
+
+	return &result, nil
 }
 
 // GetPullRequestFiles gets the files changed in a pull request
@@ -170,3 +180,146 @@ func (c *Client) GetPullRequestFiles(ctx context.Context, owner, repo string, nu
 
 	return files, nil
 }
+
+// GetPullRequestDiff gets the full diff of a pull request in the given
+// media type (MediaTypeDiff or MediaTypePatch)
+func (c *Client) GetPullRequestDiff(ctx context.Context, owner, repo string, number int, mediaType string) (string, error) {
+	url := fmt.Sprintf("repos/%s/%s/pulls/%d", owner, repo, number)
+
+	req, err := c.newRequest(ctx, "GET", url, nil)
+	if err != nil {
+		return "", err
+	}
+	req.Header.Set("Accept", mediaType)
+
+	resp, err := c.do(req)
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return "", fmt.Errorf("failed to read response: %w", err)
+	}
+
+	return string(data), nil
+}
+
+// ReviewersRequest lists the users and teams to request reviews from
+type ReviewersRequest struct {
+	Reviewers     []string `json:"reviewers,omitempty"`
+	TeamReviewers []string `json:"team_reviewers,omitempty"`
+}
+
+// RequestReviewers requests reviews on a pull request from users and teams
+func (c *Client) RequestReviewers(ctx context.Context, owner, repo string, number int, reviewers *ReviewersRequest) (*PullRequest, error) {
+	url := fmt.Sprintf("repos/%s/%s/pulls/%d/requested_reviewers", owner, repo, number)
+
+	request, err := c.newRequest(ctx, "POST", url, reviewers)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := c.do(request)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	var pr PullRequest
+	if err := json.NewDecoder(resp.Body).Decode(&pr); err != nil {
+		return nil, fmt.Errorf("failed to decode response: %w", err)
+	}
+
+	return &pr, nil
+}
+
+// UpdatePullRequestBranch merges the base branch into the head branch of a
+// pull request. GitHub performs the update asynchronously.
+func (c *Client) UpdatePullRequestBranch(ctx context.Context, owner, repo string, number int, expectedHeadSHA string) (string, error) {
+	url := fmt.Sprintf("repos/%s/%s/pulls/%d/update-branch", owner, repo, number)
+
+	body := map[string]string{}
+	if expectedHeadSHA != "" {
+		body["expected_head_sha"] = expectedHeadSHA
+	}
+
+	request, err := c.newRequest(ctx, "PUT", url, body)
+	if err != nil {
+		return "", err
+	}
+
+	resp, err := c.do(request)
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+
+	var result struct {
+		Message string `json:"message"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return "", fmt.Errorf("failed to decode response: %w", err)
+	}
+
+	return result.Message, nil
+}
+
+// SetPullRequestState closes or reopens a pull request
+func (c *Client) SetPullRequestState(ctx context.Context, owner, repo string, number int, state string) (*PullRequest, error) {
+	return c.UpdatePullRequest(ctx, owner, repo, number, map[string]interface{}{"state": state})
+}
+
+// ConvertPullRequestToDraft converts a pull request to a draft. Drafts can
+// only be toggled through the GraphQL API.
+func (c *Client) ConvertPullRequestToDraft(ctx context.Context, nodeID string) error {
+	const mutation = `mutation($id: ID!) {
+	convertPullRequestToDraft(input: {pullRequestId: $id}) { pullRequest { isDraft } }
+}`
+	return c.GraphQL(ctx, mutation, map[string]interface{}{"id": nodeID}, nil)
+}
+
+// MarkPullRequestReadyForReview marks a draft pull request as ready for review
+func (c *Client) MarkPullRequestReadyForReview(ctx context.Context, nodeID string) error {
+	const mutation = `mutation($id: ID!) {
+	markPullRequestReadyForReview(input: {pullRequestId: $id}) { pullRequest { isDraft } }
+}`
+	return c.GraphQL(ctx, mutation, map[string]interface{}{"id": nodeID}, nil)
+}
+
+// EnableAutoMerge queues a pull request to merge once its requirements are
+// met. The merge method is merge, squash or rebase.
+func (c *Client) EnableAutoMerge(ctx context.Context, nodeID string, opts *MergePullRequestOptions) error {
+	const mutation = `mutation($id: ID!, $method: PullRequestMergeMethod, $headline: String, $body: String, $sha: GitObjectID) {
+	enablePullRequestAutoMerge(input: {pullRequestId: $id, mergeMethod: $method, commitHeadline: $headline, commitBody: $body, expectedHeadOid: $sha}) {
+		pullRequest { autoMergeRequest { mergeMethod } }
+	}
+}`
+
+	variables := map[string]interface{}{"id": nodeID}
+	if opts != nil {
+		if opts.MergeMethod != "" {
+			variables["method"] = strings.ToUpper(opts.MergeMethod)
+		}
+		if opts.CommitTitle != "" {
+			variables["headline"] = opts.CommitTitle
+		}
+		if opts.CommitMessage != "" {
+			variables["body"] = opts.CommitMessage
+		}
+		if opts.SHA != "" {
+			variables["sha"] = opts.SHA
+		}
+	}
+
+	return c.GraphQL(ctx, mutation, variables, nil)
+}
+
+// DisableAutoMerge cancels auto-merge for a pull request
+func (c *Client) DisableAutoMerge(ctx context.Context, nodeID string) error {
+	const mutation = `mutation($id: ID!) {
+	disablePullRequestAutoMerge(input: {pullRequestId: $id}) { pullRequest { number } }
+}`
+	return c.GraphQL(ctx, mutation, map[string]interface{}{"id": nodeID}, nil)
+}
@@ -0,0 +1,66 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"testing"
+)
+
+func TestMergePullRequest(t *testing.T) {
+	client := setupTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != "PUT" || r.URL.Path != "/repos/octo/hello/pulls/7/merge" {
+			t.Errorf("Unexpected request %s %s", r.Method, r.URL.Path)
+		}
+
+		var body map[string]string
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			t.Fatalf("Failed to decode body: %v", err)
+		}
+		if body["sha"] != "abc123" {
+			w.WriteHeader(http.StatusConflict)
+			fmt.Fprint(w, `{"message": "Head branch was modified. Review and try the merge again."}`)
+			return
+		}
+		if body["merge_method"] != "squash" || body["commit_title"] != "Add feature (#7)" {
+			t.Errorf("Unexpected merge body: %v", body)
+		}
+		fmt.Fprint(w, `{"sha": "def456", "merged": true, "message": "Pull Request successfully merged"}`)
+	})
+
+	opts := &MergePullRequestOptions{
+		CommitTitle: "Add feature (#7)",
+		MergeMethod: "squash",
+		SHA:         "abc123",
+	}
+	result, err := client.MergePullRequest(context.Background(), "octo", "hello", 7, opts)
+	if err != nil {
+		t.Fatalf("MergePullRequest failed: %v", err)
+	}
+	if !result.Merged || result.SHA != "def456" {
+		t.Errorf("Unexpected merge result: %+v", result)
+	}
+
+	opts.SHA = "moved"
+	if _, err := client.MergePullRequest(context.Background(), "octo", "hello", 7, opts); !IsStatus(err, http.StatusConflict) {
+		t.Errorf("Expected conflict for a moved head, got %v", err)
+	}
+}
+
+func TestGetPullRequestDiff(t *testing.T) {
+	client := setupTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if got := r.Header.Get("Accept"); got != MediaTypeDiff {
+			t.Errorf("Expected diff media type, got %q", got)
+		}
+		fmt.Fprint(w, "diff --git a/a.txt b/a.txt\n")
+	})
+
+	text, err := client.GetPullRequestDiff(context.Background(), "octo", "hello", 7, MediaTypeDiff)
+	if err != nil {
+		t.Fatalf("GetPullRequestDiff failed: %v", err)
+	}
+	if text != "diff --git a/a.txt b/a.txt\n" {
+		t.Errorf("Unexpected diff %q", text)
+	}
+}
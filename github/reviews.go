@@ -0,0 +1,204 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+)
+
+// Review events for submitting a pull request review
+const (
+	ReviewEventApprove        = "APPROVE"
+	ReviewEventRequestChanges = "REQUEST_CHANGES"
+	ReviewEventComment        = "COMMENT"
+)
+
+// PullRequestReview represents a review of a pull request
+type PullRequestReview struct {
+	ID          int64      `json:"id"`
+	User        User       `json:"user"`
+	Body        string     `json:"body"`
+	State       string     `json:"state"`
+	CommitID    string     `json:"commit_id"`
+	HTMLURL     string     `json:"html_url"`
+	SubmittedAt *time.Time `json:"submitted_at,omitempty"`
+}
+
+// ReviewComment represents an inline comment on a pull request diff
+type ReviewComment struct {
+	ID                  int64     `json:"id"`
+	PullRequestReviewID int64     `json:"pull_request_review_id"`
+	InReplyToID         int64     `json:"in_reply_to_id,omitempty"`
+	User                User      `json:"user"`
+	Body                string    `json:"body"`
+	Path                string    `json:"path"`
+	CommitID            string    `json:"commit_id"`
+	DiffHunk            string    `json:"diff_hunk"`
+	Line                int       `json:"line,omitempty"`
+	Side                string    `json:"side,omitempty"`
+	StartLine           int       `json:"start_line,omitempty"`
+	StartSide           string    `json:"start_side,omitempty"`
+	HTMLURL             string    `json:"html_url"`
+	CreatedAt           time.Time `json:"created_at"`
+	UpdatedAt           time.Time `json:"updated_at"`
+}
+
+// DraftReviewComment represents an inline comment added with a review.
+// Line is the last line of the comment; StartLine is set for comments
+// spanning several lines. Side is LEFT for deleted lines and RIGHT otherwise.
+type DraftReviewComment struct {
+	Path      string `json:"path"`
+	Body      string `json:"body"`
+	Line      int    `json:"line"`
+	Side      string `json:"side,omitempty"`
+	StartLine int    `json:"start_line,omitempty"`
+	StartSide string `json:"start_side,omitempty"`
+}
+
+// CreateReviewRequest represents parameters for creating a review. A review
+// without an event stays pending until it is submitted.
+type CreateReviewRequest struct {
+	CommitID string                `json:"commit_id,omitempty"`
+	Body     string                `json:"body,omitempty"`
+	Event    string                `json:"event,omitempty"`
+	Comments []*DraftReviewComment `json:"comments,omitempty"`
+}
+
+// ListReviews lists the reviews of a pull request
+func (c *Client) ListReviews(ctx context.Context, owner, repo string, number int, opts *ListOptions) ([]*PullRequestReview, error) {
+	url := addListOptions(fmt.Sprintf("repos/%s/%s/pulls/%d/reviews", owner, repo, number), opts)
+
+	req, err := c.newRequest(ctx, "GET", url, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := c.do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	var reviews []*PullRequestReview
+	if err := json.NewDecoder(resp.Body).Decode(&reviews); err != nil {
+		return nil, fmt.Errorf("failed to decode response: %w", err)
+	}
+
+	return reviews, nil
+}
+
+// CreateReview creates a review on a pull request, pending unless an event
+// is given
+func (c *Client) CreateReview(ctx context.Context, owner, repo string, number int, review *CreateReviewRequest) (*PullRequestReview, error) {
+	url := fmt.Sprintf("repos/%s/%s/pulls/%d/reviews", owner, repo, number)
+
+	req, err := c.newRequest(ctx, "POST", url, review)
+	if err != nil {
+		return nil, err
+	}
+
+	return c.doReview(req)
+}
+
+// SubmitReview submits a pending review with the given event
+func (c *Client) SubmitReview(ctx context.Context, owner, repo string, number int, reviewID int64, event, body string) (*PullRequestReview, error) {
+	url := fmt.Sprintf("repos/%s/%s/pulls/%d/reviews/%d/events", owner, repo, number, reviewID)
+
+	submit := map[string]string{
+		"event": event,
+	}
+	if body != "" {
+		submit["body"] = body
+	}
+
+	req, err := c.newRequest(ctx, "POST", url, submit)
+	if err != nil {
+		return nil, err
+	}
+
+	return c.doReview(req)
+}
+
+// DismissReview dismisses a submitted review
+func (c *Client) DismissReview(ctx context.Context, owner, repo string, number int, reviewID int64, message string) (*PullRequestReview, error) {
+	url := fmt.Sprintf("repos/%s/%s/pulls/%d/reviews/%d/dismissals", owner, repo, number, reviewID)
+
+	dismissal := map[string]string{
+		"message": message,
+	}
+
+	req, err := c.newRequest(ctx, "PUT", url, dismissal)
+	if err != nil {
+		return nil, err
+	}
+
+	return c.doReview(req)
+}
+
+// ListReviewComments lists the inline review comments of a pull request
+func (c *Client) ListReviewComments(ctx context.Context, owner, repo string, number int, opts *ListOptions) ([]*ReviewComment, error) {
+	url := addListOptions(fmt.Sprintf("repos/%s/%s/pulls/%d/comments", owner, repo, number), opts)
+
+	req, err := c.newRequest(ctx, "GET", url, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := c.do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	var comments []*ReviewComment
+	if err := json.NewDecoder(resp.Body).Decode(&comments); err != nil {
+		return nil, fmt.Errorf("failed to decode response: %w", err)
+	}
+
+	return comments, nil
+}
+
+// ReplyToReviewComment replies to the thread of a review comment
+func (c *Client) ReplyToReviewComment(ctx context.Context, owner, repo string, number int, commentID int64, body string) (*ReviewComment, error) {
+	url := fmt.Sprintf("repos/%s/%s/pulls/%d/comments/%d/replies", owner, repo, number, commentID)
+
+	reply := map[string]string{
+		"body": body,
+	}
+
+	req, err := c.newRequest(ctx, "POST", url, reply)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := c.do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	var comment ReviewComment
+	if err := json.NewDecoder(resp.Body).Decode(&comment); err != nil {
+		return nil, fmt.Errorf("failed to decode response: %w", err)
+	}
+
+	return &comment, nil
+}
+
+// doReview executes a request that returns a review
+func (c *Client) doReview(req *http.Request) (*PullRequestReview, error) {
+	resp, err := c.do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	var review PullRequestReview
+	if err := json.NewDecoder(resp.Body).Decode(&review); err != nil {
+		return nil, fmt.Errorf("failed to decode response: %w", err)
+	}
+
+	return &review, nil
+}
@@ -0,0 +1,61 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"testing"
+)
+
+func TestCreateReview_Pending(t *testing.T) {
+	client := setupTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != "POST" || r.URL.Path != "/repos/octo/hello/pulls/7/reviews" {
+			t.Errorf("Unexpected request %s %s", r.Method, r.URL.Path)
+		}
+
+		var body map[string]interface{}
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			t.Fatalf("Failed to decode body: %v", err)
+		}
+		if _, ok := body["event"]; ok {
+			t.Error("Pending reviews must not send an event")
+		}
+		comments := body["comments"].([]interface{})
+		comment := comments[0].(map[string]interface{})
+		if comment["start_line"] != float64(10) || comment["line"] != float64(12) || comment["side"] != "RIGHT" {
+			t.Errorf("Unexpected multi-line comment: %v", comment)
+		}
+
+		fmt.Fprint(w, `{"id": 80, "state": "PENDING"}`)
+	})
+
+	review, err := client.CreateReview(context.Background(), "octo", "hello", 7, &CreateReviewRequest{
+		Comments: []*DraftReviewComment{
+			{Path: "main.go", Body: "Extract this", StartLine: 10, Line: 12, Side: "RIGHT", StartSide: "RIGHT"},
+		},
+	})
+	if err != nil {
+		t.Fatalf("CreateReview failed: %v", err)
+	}
+	if review.ID != 80 || review.State != "PENDING" {
+		t.Errorf("Unexpected review: %+v", review)
+	}
+}
+
+func TestListReviewComments_Pagination(t *testing.T) {
+	client := setupTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if got := r.URL.RawQuery; got != "page=2&per_page=50" {
+			t.Errorf("Unexpected query %q", got)
+		}
+		fmt.Fprint(w, `[{"id": 1, "body": "nit", "path": "a.go", "line": 3}, {"id": 2, "in_reply_to_id": 1, "body": "done"}]`)
+	})
+
+	comments, err := client.ListReviewComments(context.Background(), "octo", "hello", 7, &ListOptions{Page: 2, PerPage: 50})
+	if err != nil {
+		t.Fatalf("ListReviewComments failed: %v", err)
+	}
+	if len(comments) != 2 || comments[1].InReplyToID != 1 {
+		t.Errorf("Unexpected comments: %+v", comments)
+	}
+}
@@ -0,0 +1,167 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+)
+
+// RulesetRule is a rule of a repository ruleset, e.g. pull_request,
+// required_status_checks, non_fast_forward or deletion
+type RulesetRule struct {
+	Type       string                 `json:"type"`
+	Parameters map[string]interface{} `json:"parameters,omitempty"`
+}
+
+// RulesetBypassActor is an actor allowed to bypass a ruleset
+type RulesetBypassActor struct {
+	ActorID    int64  `json:"actor_id"`
+	ActorType  string `json:"actor_type"`
+	BypassMode string `json:"bypass_mode"`
+}
+
+// Ruleset represents a repository ruleset
+type Ruleset struct {
+	ID           int64                  `json:"id,omitempty"`
+	Name         string                 `json:"name"`
+	Target       string                 `json:"target,omitempty"`
+	SourceType   string                 `json:"source_type,omitempty"`
+	Source       string                 `json:"source,omitempty"`
+	Enforcement  string                 `json:"enforcement"`
+	BypassActors []*RulesetBypassActor  `json:"bypass_actors,omitempty"`
+	Conditions   map[string]interface{} `json:"conditions,omitempty"`
+	Rules        []*RulesetRule         `json:"rules,omitempty"`
+}
+
+// BranchRule is a rule in force on a branch and the ruleset it comes from
+type BranchRule struct {
+	RulesetRule
+	RulesetSourceType string `json:"ruleset_source_type"`
+	RulesetSource     string `json:"ruleset_source"`
+	RulesetID         int64  `json:"ruleset_id"`
+}
+
+// ListRulesets lists the rulesets of a repository, including those
+// inherited from its organization
+func (c *Client) ListRulesets(ctx context.Context, owner, repo string, opts *ListOptions) ([]*Ruleset, error) {
+	url := addListOptions(fmt.Sprintf("repos/%s/%s/rulesets", owner, repo), opts)
+
+	req, err := c.newRequest(ctx, "GET", url, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := c.do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	var rulesets []*Ruleset
+	if err := json.NewDecoder(resp.Body).Decode(&rulesets); err != nil {
+		return nil, fmt.Errorf("failed to decode response: %w", err)
+	}
+
+	return rulesets, nil
+}
+
+// GetRuleset gets a repository ruleset with its rules
+func (c *Client) GetRuleset(ctx context.Context, owner, repo string, rulesetID int64) (*Ruleset, error) {
+	url := fmt.Sprintf("repos/%s/%s/rulesets/%d", owner, repo, rulesetID)
+	return c.sendRuleset(ctx, "GET", url, nil)
+}
+
+// CreateRuleset creates a repository ruleset
+func (c *Client) CreateRuleset(ctx context.Context, owner, repo string, ruleset *Ruleset) (*Ruleset, error) {
+	url := fmt.Sprintf("repos/%s/%s/rulesets", owner, repo)
+	return c.sendRuleset(ctx, "POST", url, ruleset)
+}
+
+// UpdateRuleset replaces a repository ruleset
+func (c *Client) UpdateRuleset(ctx context.Context, owner, repo string, rulesetID int64, ruleset *Ruleset) (*Ruleset, error) {
+	url := fmt.Sprintf("repos/%s/%s/rulesets/%d", owner, repo, rulesetID)
+	return c.sendRuleset(ctx, "PUT", url, ruleset)
+}
+
+// sendRuleset sends a ruleset request and decodes the ruleset returned
+func (c *Client) sendRuleset(ctx context.Context, method, url string, body interface{}) (*Ruleset, error) {
+	req, err := c.newRequest(ctx, method, url, body)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := c.do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	var ruleset Ruleset
+	if err := json.NewDecoder(resp.Body).Decode(&ruleset); err != nil {
+		return nil, fmt.Errorf("failed to decode response: %w", err)
+	}
+
+	return &ruleset, nil
+}
+
+// GetBranchRules lists the ruleset rules in force on a branch, from both
+// repository and organization rulesets
+func (c *Client) GetBranchRules(ctx context.Context, owner, repo, branch string) ([]*BranchRule, error) {
+	url := fmt.Sprintf("repos/%s/%s/rules/branches/%s", owner, repo, branch)
+
+	req, err := c.newRequest(ctx, "GET", url, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := c.do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	var rules []*BranchRule
+	if err := json.NewDecoder(resp.Body).Decode(&rules); err != nil {
+		return nil, fmt.Errorf("failed to decode response: %w", err)
+	}
+
+	return rules, nil
+}
+
+// DiffRulesets lists the settings and rules that differ between the current
+// and the desired version of a ruleset. A nil current ruleset does not
+// exist yet.
+func DiffRulesets(current, desired *Ruleset) []*ProtectionChange {
+	return diffSettings(current.settings(), desired.settings())
+}
+
+// settings flattens a ruleset into comparable values, one per rule type
+func (r *Ruleset) settings() map[string]string {
+	if r == nil {
+		return map[string]string{}
+	}
+
+	settings := map[string]string{
+		"enforcement":   r.Enforcement,
+		"target":        r.Target,
+		"conditions":    jsonSetting(r.Conditions),
+		"bypass_actors": jsonSetting(r.BypassActors),
+	}
+	if settings["target"] == "" {
+		settings["target"] = "branch"
+	}
+	for _, rule := range r.Rules {
+		settings["rule "+rule.Type] = jsonSetting(rule.Parameters)
+	}
+	return settings
+}
+
+// jsonSetting renders a setting as JSON; maps marshal with sorted keys, so
+// equal settings render equally
+func jsonSetting(value interface{}) string {
+	data, err := json.Marshal(value)
+	if err != nil || string(data) == "null" || string(data) == "{}" || string(data) == "[]" {
+		return "none"
+	}
+	return string(data)
+}
@@ -0,0 +1,207 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// Runner statuses
+const (
+	RunnerStatusOnline  = "online"
+	RunnerStatusOffline = "offline"
+)
+
+// RunnerLabel is a label of a self-hosted runner
+type RunnerLabel struct {
+	ID   int64  `json:"id"`
+	Name string `json:"name"`
+
+	// Type is read-only for labels GitHub assigns and custom otherwise
+	Type string `json:"type"`
+}
+
+// Runner represents a self-hosted runner
+type Runner struct {
+	ID     int64          `json:"id"`
+	Name   string         `json:"name"`
+	OS     string         `json:"os"`
+	Status string         `json:"status"`
+	Busy   bool           `json:"busy"`
+	Labels []*RunnerLabel `json:"labels"`
+}
+
+// HasLabel reports whether the runner has a label, ignoring case
+func (r *Runner) HasLabel(name string) bool {
+	for _, label := range r.Labels {
+		if strings.EqualFold(label.Name, name) {
+			return true
+		}
+	}
+	return false
+}
+
+// RunnerGroup represents an organization runner group
+type RunnerGroup struct {
+	ID                       int64    `json:"id"`
+	Name                     string   `json:"name"`
+	Visibility               string   `json:"visibility"`
+	Default                  bool     `json:"default"`
+	Inherited                bool     `json:"inherited"`
+	AllowsPublicRepositories bool     `json:"allows_public_repositories"`
+	RestrictedToWorkflows    bool     `json:"restricted_to_workflows"`
+	SelectedWorkflows        []string `json:"selected_workflows,omitempty"`
+}
+
+// RunnerToken is a short-lived token for registering or removing a runner
+type RunnerToken struct {
+	Token     string    `json:"token"`
+	ExpiresAt time.Time `json:"expires_at"`
+}
+
+// ListRunners lists the self-hosted runners of a repository or organization
+func (c *Client) ListRunners(ctx context.Context, scope ActionsScope, opts *ListOptions) ([]*Runner, int, error) {
+	url := addListOptions(scope.path()+"/runners", opts)
+
+	req, err := c.newRequest(ctx, "GET", url, nil)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	resp, err := c.do(req)
+	if err != nil {
+		return nil, 0, err
+	}
+	defer resp.Body.Close()
+
+	var response struct {
+		TotalCount int       `json:"total_count"`
+		Runners    []*Runner `json:"runners"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&response); err != nil {
+		return nil, 0, fmt.Errorf("failed to decode response: %w", err)
+	}
+
+	return response.Runners, response.TotalCount, nil
+}
+
+// ListAllRunners lists every self-hosted runner of a repository or
+// organization, following pagination
+func (c *Client) ListAllRunners(ctx context.Context, scope ActionsScope) ([]*Runner, error) {
+	var runners []*Runner
+	for page := 1; ; page++ {
+		pageRunners, total, err := c.ListRunners(ctx, scope, &ListOptions{Page: page, PerPage: 100})
+		if err != nil {
+			return nil, err
+		}
+		runners = append(runners, pageRunners...)
+		if len(pageRunners) == 0 || len(runners) >= total {
+			return runners, nil
+		}
+	}
+}
+
+// ListRunnerGroups lists the runner groups of an organization
+func (c *Client) ListRunnerGroups(ctx context.Context, org string, opts *ListOptions) ([]*RunnerGroup, int, error) {
+	url := addListOptions(fmt.Sprintf("orgs/%s/actions/runner-groups", org), opts)
+
+	req, err := c.newRequest(ctx, "GET", url, nil)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	resp, err := c.do(req)
+	if err != nil {
+		return nil, 0, err
+	}
+	defer resp.Body.Close()
+
+	var response struct {
+		TotalCount   int            `json:"total_count"`
+		RunnerGroups []*RunnerGroup `json:"runner_groups"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&response); err != nil {
+		return nil, 0, fmt.Errorf("failed to decode response: %w", err)
+	}
+
+	return response.RunnerGroups, response.TotalCount, nil
+}
+
+// ListRunnerGroupRunners lists the runners in an organization runner group
+func (c *Client) ListRunnerGroupRunners(ctx context.Context, org string, groupID int64, opts *ListOptions) ([]*Runner, int, error) {
+	url := addListOptions(fmt.Sprintf("orgs/%s/actions/runner-groups/%d/runners", org, groupID), opts)
+
+	req, err := c.newRequest(ctx, "GET", url, nil)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	resp, err := c.do(req)
+	if err != nil {
+		return nil, 0, err
+	}
+	defer resp.Body.Close()
+
+	var response struct {
+		TotalCount int       `json:"total_count"`
+		Runners    []*Runner `json:"runners"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&response); err != nil {
+		return nil, 0, fmt.Errorf("failed to decode response: %w", err)
+	}
+
+	return response.Runners, response.TotalCount, nil
+}
+
+// CreateRunnerRegistrationToken creates a token for registering a runner
+// with config.sh
+func (c *Client) CreateRunnerRegistrationToken(ctx context.Context, scope ActionsScope) (*RunnerToken, error) {
+	return c.createRunnerToken(ctx, scope.path()+"/runners/registration-token")
+}
+
+// CreateRunnerRemoveToken creates a token for removing a runner with
+// config.sh remove
+func (c *Client) CreateRunnerRemoveToken(ctx context.Context, scope ActionsScope) (*RunnerToken, error) {
+	return c.createRunnerToken(ctx, scope.path()+"/runners/remove-token")
+}
+
+// createRunnerToken creates a registration or removal token
+func (c *Client) createRunnerToken(ctx context.Context, url string) (*RunnerToken, error) {
+	req, err := c.newRequest(ctx, "POST", url, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := c.do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	var token RunnerToken
+	if err := json.NewDecoder(resp.Body).Decode(&token); err != nil {
+		return nil, fmt.Errorf("failed to decode response: %w", err)
+	}
+
+	return &token, nil
+}
+
+// DeleteRunner removes a self-hosted runner
+func (c *Client) DeleteRunner(ctx context.Context, scope ActionsScope, runnerID int64) error {
+	url := fmt.Sprintf("%s/runners/%d", scope.path(), runnerID)
+
+	req, err := c.newRequest(ctx, "DELETE", url, nil)
+	if err != nil {
+		return err
+	}
+
+	resp, err := c.do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	return nil
+}
@@ -0,0 +1,50 @@
+package github
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"testing"
+)
+
+func TestListAllRunners(t *testing.T) {
+	client := setupTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/orgs/octo-org/actions/runners" {
+			t.Errorf("Unexpected request path %s", r.URL.Path)
+		}
+		if r.URL.Query().Get("page") == "1" {
+			fmt.Fprint(w, `{"total_count": 2, "runners": [{"id": 1, "name": "linux-1", "status": "online", "labels": [{"name": "self-hosted"}, {"name": "GPU"}]}]}`)
+			return
+		}
+		fmt.Fprint(w, `{"total_count": 2, "runners": [{"id": 2, "name": "linux-2", "status": "offline", "busy": false}]}`)
+	})
+
+	runners, err := client.ListAllRunners(context.Background(), ActionsScope{Org: "octo-org"})
+	if err != nil {
+		t.Fatalf("ListAllRunners failed: %v", err)
+	}
+	if len(runners) != 2 || runners[1].Status != RunnerStatusOffline {
+		t.Fatalf("Unexpected runners: %+v", runners)
+	}
+	if !runners[0].HasLabel("gpu") || runners[1].HasLabel("gpu") {
+		t.Error("Expected only the first runner to have the gpu label")
+	}
+}
+
+func TestCreateRunnerRegistrationToken(t *testing.T) {
+	client := setupTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != "POST" || r.URL.Path != "/repos/octo/hello/actions/runners/registration-token" {
+			t.Errorf("Unexpected request %s %s", r.Method, r.URL.Path)
+		}
+		w.WriteHeader(http.StatusCreated)
+		fmt.Fprint(w, `{"token": "LLBF3JGZDX3P5PMEXLND6TS6FCWO6", "expires_at": "2024-01-22T12:13:35.123-08:00"}`)
+	})
+
+	token, err := client.CreateRunnerRegistrationToken(context.Background(), ActionsScope{Owner: "octo", Repo: "hello"})
+	if err != nil {
+		t.Fatalf("CreateRunnerRegistrationToken failed: %v", err)
+	}
+	if token.Token != "LLBF3JGZDX3P5PMEXLND6TS6FCWO6" || token.ExpiresAt.IsZero() {
+		t.Errorf("Unexpected token: %+v", token)
+	}
+}
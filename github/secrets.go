@@ -0,0 +1,283 @@
+package github
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	neturl "net/url"
+	"time"
+
+	"golang.org/x/crypto/nacl/box"
+)
+
+// ActionsScope identifies where Actions secrets, variables and runners
+// live: a repository, one of its environments, or an organization
+type ActionsScope struct {
+	Owner       string
+	Repo        string
+	Environment string
+	Org         string
+}
+
+// String describes the scope for messages
+func (s ActionsScope) String() string {
+	switch {
+	case s.Org != "":
+		return "organization " + s.Org
+	case s.Environment != "":
+		return fmt.Sprintf("environment %s of %s/%s", s.Environment, s.Owner, s.Repo)
+	default:
+		return s.Owner + "/" + s.Repo
+	}
+}
+
+// path returns the API path under which the scope's Actions resources are
+// listed
+func (s ActionsScope) path() string {
+	switch {
+	case s.Org != "":
+		return fmt.Sprintf("orgs/%s/actions", s.Org)
+	case s.Environment != "":
+		return fmt.Sprintf("repos/%s/%s/environments/%s", s.Owner, s.Repo, neturl.PathEscape(s.Environment))
+	default:
+		return fmt.Sprintf("repos/%s/%s/actions", s.Owner, s.Repo)
+	}
+}
+
+// SecretsPublicKey is the key secret values are encrypted with before they
+// are sent to GitHub
+type SecretsPublicKey struct {
+	KeyID string `json:"key_id"`
+
+	// Key is the base64 encoded Curve25519 public key
+	Key string `json:"key"`
+}
+
+// Secret describes an Actions secret. GitHub never returns secret values.
+type Secret struct {
+	Name       string    `json:"name"`
+	Visibility string    `json:"visibility,omitempty"`
+	CreatedAt  time.Time `json:"created_at"`
+	UpdatedAt  time.Time `json:"updated_at"`
+}
+
+// EncryptedSecret represents parameters for creating or updating a secret
+type EncryptedSecret struct {
+	EncryptedValue string `json:"encrypted_value"`
+	KeyID          string `json:"key_id"`
+
+	// Visibility applies to organization secrets only (all, private or
+	// selected)
+	Visibility            string  `json:"visibility,omitempty"`
+	SelectedRepositoryIDs []int64 `json:"selected_repository_ids,omitempty"`
+}
+
+// Variable describes an Actions variable
+type Variable struct {
+	Name       string    `json:"name"`
+	Value      string    `json:"value"`
+	Visibility string    `json:"visibility,omitempty"`
+	CreatedAt  time.Time `json:"created_at"`
+	UpdatedAt  time.Time `json:"updated_at"`
+}
+
+// VariableRequest represents parameters for creating or updating a variable
+type VariableRequest struct {
+	Name  string `json:"name"`
+	Value string `json:"value"`
+
+	// Visibility applies to organization variables only
+	Visibility            string  `json:"visibility,omitempty"`
+	SelectedRepositoryIDs []int64 `json:"selected_repository_ids,omitempty"`
+}
+
+// GetSecretsPublicKey gets the public key used to encrypt secrets in a scope
+func (c *Client) GetSecretsPublicKey(ctx context.Context, scope ActionsScope) (*SecretsPublicKey, error) {
+	url := scope.path() + "/secrets/public-key"
+
+	req, err := c.newRequest(ctx, "GET", url, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := c.do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	var key SecretsPublicKey
+	if err := json.NewDecoder(resp.Body).Decode(&key); err != nil {
+		return nil, fmt.Errorf("failed to decode response: %w", err)
+	}
+
+	return &key, nil
+}
+
+// ListSecrets lists the secrets of a scope
+func (c *Client) ListSecrets(ctx context.Context, scope ActionsScope, opts *ListOptions) ([]*Secret, int, error) {
+	url := addListOptions(scope.path()+"/secrets", opts)
+
+	req, err := c.newRequest(ctx, "GET", url, nil)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	resp, err := c.do(req)
+	if err != nil {
+		return nil, 0, err
+	}
+	defer resp.Body.Close()
+
+	var response struct {
+		TotalCount int       `json:"total_count"`
+		Secrets    []*Secret `json:"secrets"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&response); err != nil {
+		return nil, 0, fmt.Errorf("failed to decode response: %w", err)
+	}
+
+	return response.Secrets, response.TotalCount, nil
+}
+
+// CreateOrUpdateSecret creates or updates a secret with a value encrypted
+// by EncryptSecret
+func (c *Client) CreateOrUpdateSecret(ctx context.Context, scope ActionsScope, name string, secret *EncryptedSecret) error {
+	url := fmt.Sprintf("%s/secrets/%s", scope.path(), name)
+
+	req, err := c.newRequest(ctx, "PUT", url, secret)
+	if err != nil {
+		return err
+	}
+
+	resp, err := c.do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	return nil
+}
+
+// DeleteSecret deletes a secret
+func (c *Client) DeleteSecret(ctx context.Context, scope ActionsScope, name string) error {
+	url := fmt.Sprintf("%s/secrets/%s", scope.path(), name)
+
+	req, err := c.newRequest(ctx, "DELETE", url, nil)
+	if err != nil {
+		return err
+	}
+
+	resp, err := c.do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	return nil
+}
+
+// ListVariables lists the variables of a scope
+func (c *Client) ListVariables(ctx context.Context, scope ActionsScope, opts *ListOptions) ([]*Variable, int, error) {
+	url := addListOptions(scope.path()+"/variables", opts)
+
+	req, err := c.newRequest(ctx, "GET", url, nil)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	resp, err := c.do(req)
+	if err != nil {
+		return nil, 0, err
+	}
+	defer resp.Body.Close()
+
+	var response struct {
+		TotalCount int         `json:"total_count"`
+		Variables  []*Variable `json:"variables"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&response); err != nil {
+		return nil, 0, fmt.Errorf("failed to decode response: %w", err)
+	}
+
+	return response.Variables, response.TotalCount, nil
+}
+
+// CreateVariable creates a variable
+func (c *Client) CreateVariable(ctx context.Context, scope ActionsScope, variable *VariableRequest) error {
+	url := scope.path() + "/variables"
+
+	req, err := c.newRequest(ctx, "POST", url, variable)
+	if err != nil {
+		return err
+	}
+
+	resp, err := c.do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	return nil
+}
+
+// UpdateVariable updates an existing variable
+func (c *Client) UpdateVariable(ctx context.Context, scope ActionsScope, variable *VariableRequest) error {
+	url := fmt.Sprintf("%s/variables/%s", scope.path(), variable.Name)
+
+	req, err := c.newRequest(ctx, "PATCH", url, variable)
+	if err != nil {
+		return err
+	}
+
+	resp, err := c.do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	return nil
+}
+
+// DeleteVariable deletes a variable
+func (c *Client) DeleteVariable(ctx context.Context, scope ActionsScope, name string) error {
+	url := fmt.Sprintf("%s/variables/%s", scope.path(), name)
+
+	req, err := c.newRequest(ctx, "DELETE", url, nil)
+	if err != nil {
+		return err
+	}
+
+	resp, err := c.do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	return nil
+}
+
+// EncryptSecret seals a secret value for GitHub with the scope's public key.
+// The result is a libsodium crypto_box_seal box, base64 encoded, that only
+// the holder of the private key can open.
+func EncryptSecret(key *SecretsPublicKey, value string) (string, error) {
+	decoded, err := base64.StdEncoding.DecodeString(key.Key)
+	if err != nil {
+		return "", fmt.Errorf("failed to decode public key: %w", err)
+	}
+	if len(decoded) != 32 {
+		return "", fmt.Errorf("invalid public key length %d", len(decoded))
+	}
+
+	var recipient [32]byte
+	copy(recipient[:], decoded)
+
+	sealed, err := box.SealAnonymous(nil, []byte(value), &recipient, rand.Reader)
+	if err != nil {
+		return "", fmt.Errorf("failed to encrypt secret: %w", err)
+	}
+
+	return base64.StdEncoding.EncodeToString(sealed), nil
+}
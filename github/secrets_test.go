@@ -0,0 +1,97 @@
+package github
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"golang.org/x/crypto/nacl/box"
+)
+
+func TestEncryptSecret(t *testing.T) {
+	publicKey, privateKey, err := box.GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatalf("GenerateKey failed: %v", err)
+	}
+	key := &SecretsPublicKey{KeyID: "1", Key: base64.StdEncoding.EncodeToString(publicKey[:])}
+
+	encrypted, err := EncryptSecret(key, "hunter2")
+	if err != nil {
+		t.Fatalf("EncryptSecret failed: %v", err)
+	}
+
+	sealed, err := base64.StdEncoding.DecodeString(encrypted)
+	if err != nil {
+		t.Fatalf("Encrypted value is not base64: %v", err)
+	}
+	opened, ok := box.OpenAnonymous(nil, sealed, publicKey, privateKey)
+	if !ok {
+		t.Fatal("Failed to open sealed box")
+	}
+	if string(opened) != "hunter2" {
+		t.Errorf("Expected hunter2, got %q", opened)
+	}
+
+	if _, err := EncryptSecret(&SecretsPublicKey{Key: "c2hvcnQ="}, "x"); err == nil {
+		t.Error("Expected an error for a short public key")
+	}
+}
+
+func TestActionsScopePaths(t *testing.T) {
+	tests := []struct {
+		scope ActionsScope
+		path  string
+	}{
+		{ActionsScope{Owner: "octo", Repo: "hello"}, "repos/octo/hello/actions"},
+		{ActionsScope{Owner: "octo", Repo: "hello", Environment: "prod eu"}, "repos/octo/hello/environments/prod%20eu"},
+		{ActionsScope{Org: "octo-org"}, "orgs/octo-org/actions"},
+	}
+
+	for _, tt := range tests {
+		if got := tt.scope.path(); got != tt.path {
+			t.Errorf("Expected %s, got %s", tt.path, got)
+		}
+	}
+}
+
+func TestCreateOrUpdateSecret(t *testing.T) {
+	client := setupTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != "PUT" || r.URL.Path != "/orgs/octo-org/actions/secrets/TOKEN" {
+			t.Errorf("Unexpected request %s %s", r.Method, r.URL.Path)
+		}
+		var body EncryptedSecret
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			t.Fatalf("Failed to decode body: %v", err)
+		}
+		if body.KeyID != "568" || body.EncryptedValue != "c2VhbGVk" || body.Visibility != "private" {
+			t.Errorf("Unexpected body: %+v", body)
+		}
+		w.WriteHeader(http.StatusCreated)
+	})
+
+	err := client.CreateOrUpdateSecret(context.Background(), ActionsScope{Org: "octo-org"}, "TOKEN", &EncryptedSecret{
+		EncryptedValue: "c2VhbGVk",
+		KeyID:          "568",
+		Visibility:     "private",
+	})
+	if err != nil {
+		t.Errorf("CreateOrUpdateSecret failed: %v", err)
+	}
+}
+
+func TestUpdateVariable(t *testing.T) {
+	client := setupTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != "PATCH" || r.URL.Path != "/repos/octo/hello/environments/prod/variables/REGION" {
+			t.Errorf("Unexpected request %s %s", r.Method, r.URL.Path)
+		}
+		w.WriteHeader(http.StatusNoContent)
+	})
+
+	scope := ActionsScope{Owner: "octo", Repo: "hello", Environment: "prod"}
+	if err := client.UpdateVariable(context.Background(), scope, &VariableRequest{Name: "REGION", Value: "eu"}); err != nil {
+		t.Errorf("UpdateVariable failed: %v", err)
+	}
+}
@@ -0,0 +1,110 @@
+package github
+
+import (
+	"context"
+	"fmt"
+	"path"
+	"strings"
+)
+
+// maxTreeRequests bounds the requests GetFullTree makes when a recursive
+// listing is truncated
+const maxTreeRequests = 100
+
+// GetFullTree lists every entry below a tree, given by SHA or by a branch,
+// tag or commit. GitHub truncates large recursive listings; the subtrees
+// are then listed one by one. The result is only truncated if that takes
+// more than maxTreeRequests requests.
+func (c *Client) GetFullTree(ctx context.Context, owner, repo, sha string) (*Tree, error) {
+	tree, err := c.GetTree(ctx, owner, repo, sha, true)
+	if err != nil {
+		return nil, err
+	}
+	if !tree.Truncated {
+		return tree, nil
+	}
+
+	root, err := c.GetTree(ctx, owner, repo, sha, false)
+	if err != nil {
+		return nil, err
+	}
+	full := &Tree{SHA: root.SHA}
+	requests := 2
+	if err := c.walkTree(ctx, owner, repo, "", root, full, &requests); err != nil {
+		return nil, err
+	}
+	return full, nil
+}
+
+// walkTree adds the entries of tree, whose path is prefix, to full and
+// lists its subtrees
+func (c *Client) walkTree(ctx context.Context, owner, repo, prefix string, tree *Tree, full *Tree, requests *int) error {
+	for _, entry := range tree.Entries {
+		entry.Path = path.Join(prefix, entry.Path)
+		full.Entries = append(full.Entries, entry)
+		if entry.Type != "tree" || entry.SHA == nil {
+			continue
+		}
+
+		if *requests >= maxTreeRequests {
+			full.Truncated = true
+			continue
+		}
+		*requests++
+		subtree, err := c.GetTree(ctx, owner, repo, *entry.SHA, true)
+		if err != nil {
+			return fmt.Errorf("failed to list %s: %w", entry.Path, err)
+		}
+		if subtree.Truncated {
+			// Too large as well: list this level only and descend
+			*requests++
+			subtree, err = c.GetTree(ctx, owner, repo, *entry.SHA, false)
+			if err != nil {
+				return fmt.Errorf("failed to list %s: %w", entry.Path, err)
+			}
+			if err := c.walkTree(ctx, owner, repo, entry.Path, subtree, full, requests); err != nil {
+				return err
+			}
+			continue
+		}
+		for _, child := range subtree.Entries {
+			child.Path = path.Join(entry.Path, child.Path)
+			full.Entries = append(full.Entries, child)
+		}
+	}
+	return nil
+}
+
+// MatchPath reports whether a slash-separated path matches a glob pattern.
+// Patterns use path.Match syntax per segment, and ** matches any number of
+// segments. A pattern without a slash matches the base name at any depth,
+// so "*.go" matches "cmd/main.go".
+func MatchPath(pattern, name string) bool {
+	if !strings.Contains(pattern, "/") {
+		matched, _ := path.Match(pattern, path.Base(name))
+		return matched
+	}
+	return matchSegments(strings.Split(pattern, "/"), strings.Split(name, "/"))
+}
+
+// matchSegments matches path segments against pattern segments
+func matchSegments(pattern, name []string) bool {
+	for len(pattern) > 0 {
+		if pattern[0] == "**" {
+			for i := 0; i <= len(name); i++ {
+				if matchSegments(pattern[1:], name[i:]) {
+					return true
+				}
+			}
+			return false
+		}
+		if len(name) == 0 {
+			return false
+		}
+		if matched, _ := path.Match(pattern[0], name[0]); !matched {
+			return false
+		}
+		pattern, name = pattern[1:], name[1:]
+	}
+	return len(name) == 0
+}
@@ -0,0 +1,94 @@
+package github
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+func TestGetContents_Directory(t *testing.T) {
+	client := setupTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `[
+			{"name": "main.go", "path": "cmd/main.go", "sha": "a1", "size": 120, "type": "file"},
+			{"name": "internal", "path": "cmd/internal", "sha": "t1", "size": 0, "type": "dir"}
+		]`)
+	})
+
+	file, entries, err := client.GetContents(context.Background(), "octo", "hello", "cmd", "")
+	if err != nil {
+		t.Fatalf("GetContents failed: %v", err)
+	}
+	if file != nil || len(entries) != 2 || entries[1].Type != "dir" || entries[0].Size != 120 {
+		t.Errorf("Unexpected directory listing: %+v %+v", file, entries)
+	}
+
+	if _, err := client.GetContent(context.Background(), "octo", "hello", "cmd", ""); err == nil {
+		t.Error("Expected GetContent to fail for a directory")
+	}
+}
+
+func TestGetFullTree_Truncated(t *testing.T) {
+	client := setupTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		recursive := r.URL.Query().Get("recursive") != ""
+		switch strings.TrimPrefix(r.URL.Path, "/repos/octo/hello/git/trees/") {
+		case "main":
+			if recursive {
+				fmt.Fprint(w, `{"sha": "root", "tree": [], "truncated": true}`)
+				return
+			}
+			fmt.Fprint(w, `{"sha": "root", "tree": [
+				{"path": "README.md", "type": "blob", "sha": "b1"},
+				{"path": "src", "type": "tree", "sha": "src"}
+			]}`)
+		case "src":
+			if recursive {
+				fmt.Fprint(w, `{"sha": "src", "tree": [], "truncated": true}`)
+				return
+			}
+			fmt.Fprint(w, `{"sha": "src", "tree": [{"path": "lib", "type": "tree", "sha": "lib"}]}`)
+		case "lib":
+			fmt.Fprint(w, `{"sha": "lib", "tree": [{"path": "util.go", "type": "blob", "sha": "b2"}]}`)
+		default:
+			t.Errorf("Unexpected request %s", r.URL.Path)
+		}
+	})
+
+	tree, err := client.GetFullTree(context.Background(), "octo", "hello", "main")
+	if err != nil {
+		t.Fatalf("GetFullTree failed: %v", err)
+	}
+	var paths []string
+	for _, entry := range tree.Entries {
+		paths = append(paths, entry.Path)
+	}
+	if got := strings.Join(paths, ","); got != "README.md,src,src/lib,src/lib/util.go" || tree.Truncated {
+		t.Errorf("Unexpected tree: %s (truncated %v)", got, tree.Truncated)
+	}
+}
+
+func TestMatchPath(t *testing.T) {
+	tests := []struct {
+		pattern string
+		name    string
+		want    bool
+	}{
+		{"*.go", "main.go", true},
+		{"*.go", "cmd/server/main.go", true},
+		{"*.go", "main.go.orig", false},
+		{"cmd/*.go", "cmd/main.go", true},
+		{"cmd/*.go", "cmd/server/main.go", false},
+		{"cmd/**/*.go", "cmd/main.go", true},
+		{"cmd/**/*.go", "cmd/server/main.go", true},
+		{"**/testdata/**", "pkg/a/testdata/x/y.json", true},
+		{".github/workflows/*.y*ml", ".github/workflows/ci.yml", true},
+		{"docs/**", "README.md", false},
+	}
+
+	for _, tt := range tests {
+		if got := MatchPath(tt.pattern, tt.name); got != tt.want {
+			t.Errorf("MatchPath(%q, %q) = %v, want %v", tt.pattern, tt.name, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,103 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// tokenExpirationLayouts are the formats used by the
+// github-authentication-token-expiration header
+var tokenExpirationLayouts = []string{
+	"2006-01-02 15:04:05 MST",
+	"2006-01-02 15:04:05 -0700",
+	time.RFC3339,
+}
+
+// TokenInfo describes the token used for a request, as reported by the
+// API response headers
+type TokenInfo struct {
+	// Scopes are the classic OAuth scopes granted to the token (X-OAuth-Scopes)
+	Scopes []string
+
+	// ScopesReported is false when the response had no X-OAuth-Scopes header,
+	// e.g. for fine-grained personal access tokens and app tokens
+	ScopesReported bool
+
+	// AcceptedScopes are the scopes the endpoint accepts (X-Accepted-OAuth-Scopes)
+	AcceptedScopes []string
+
+	// ExpiresAt is the token expiry, or zero when the token does not expire
+	ExpiresAt time.Time
+}
+
+// GetAuthenticatedUser gets the authenticated user, along with what the
+// response headers reveal about the token
+func (c *Client) GetAuthenticatedUser(ctx context.Context) (*User, *TokenInfo, error) {
+	req, err := c.newRequest(ctx, "GET", "user", nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	resp, err := c.do(req)
+	if err != nil {
+		return nil, nil, err
+	}
+	defer resp.Body.Close()
+
+	var user User
+	if err := json.NewDecoder(resp.Body).Decode(&user); err != nil {
+		return nil, nil, fmt.Errorf("failed to decode response: %w", err)
+	}
+
+	info, err := parseTokenInfo(resp.Header)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return &user, info, nil
+}
+
+// parseTokenInfo reads token metadata from API response headers
+func parseTokenInfo(header http.Header) (*TokenInfo, error) {
+	info := &TokenInfo{
+		AcceptedScopes: splitScopes(header.Get("X-Accepted-OAuth-Scopes")),
+	}
+
+	if values, ok := header["X-Oauth-Scopes"]; ok {
+		info.ScopesReported = true
+		if len(values) > 0 {
+			info.Scopes = splitScopes(values[0])
+		}
+	}
+
+	if expiration := header.Get("Github-Authentication-Token-Expiration"); expiration != "" {
+		var parsed bool
+		for _, layout := range tokenExpirationLayouts {
+			if t, err := time.Parse(layout, expiration); err == nil {
+				info.ExpiresAt = t.UTC()
+				parsed = true
+				break
+			}
+		}
+		if !parsed {
+			return nil, fmt.Errorf("failed to parse token expiration %q", expiration)
+		}
+	}
+
+	return info, nil
+}
+
+// splitScopes splits a comma separated scope header
+func splitScopes(value string) []string {
+	var scopes []string
+	for _, scope := range strings.Split(value, ",") {
+		if scope = strings.TrimSpace(scope); scope != "" {
+			scopes = append(scopes, scope)
+		}
+	}
+	return scopes
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	neturl "net/url"
 	"strconv"
 )
 
@@ -145,3 +146,146 @@ func (c *Client) RerunWorkflow(ctx context.Context, owner, repo string, runID in
 
 	return nil
 }
+
+// RerunFailedJobs reruns the failed jobs of a workflow run and the jobs
+// that depend on them
+func (c *Client) RerunFailedJobs(ctx context.Context, owner, repo string, runID int64) error {
+	url := fmt.Sprintf("repos/%s/%s/actions/runs/%d/rerun-failed-jobs", owner, repo, runID)
+
+	req, err := c.newRequest(ctx, "POST", url, nil)
+	if err != nil {
+		return err
+	}
+
+	resp, err := c.do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	return nil
+}
+
+// ListWorkflowRunArtifacts lists the artifacts of a workflow run
+func (c *Client) ListWorkflowRunArtifacts(ctx context.Context, owner, repo string, runID int64, opts *ListOptions) ([]*Artifact, int, error) {
+	url := addListOptions(fmt.Sprintf("repos/%s/%s/actions/runs/%d/artifacts", owner, repo, runID), opts)
+
+	req, err := c.newRequest(ctx, "GET", url, nil)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	resp, err := c.do(req)
+	if err != nil {
+		return nil, 0, err
+	}
+	defer resp.Body.Close()
+
+	var response struct {
+		TotalCount int         `json:"total_count"`
+		Artifacts  []*Artifact `json:"artifacts"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&response); err != nil {
+		return nil, 0, fmt.Errorf("failed to decode response: %w", err)
+	}
+
+	return response.Artifacts, response.TotalCount, nil
+}
+
+// GetArtifact gets a workflow artifact
+func (c *Client) GetArtifact(ctx context.Context, owner, repo string, artifactID int64) (*Artifact, error) {
+	url := fmt.Sprintf("repos/%s/%s/actions/artifacts/%d", owner, repo, artifactID)
+
+	req, err := c.newRequest(ctx, "GET", url, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := c.do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	var artifact Artifact
+	if err := json.NewDecoder(resp.Body).Decode(&artifact); err != nil {
+		return nil, fmt.Errorf("failed to decode response: %w", err)
+	}
+
+	return &artifact, nil
+}
+
+// DownloadArtifact downloads the zip archive of a workflow artifact,
+// failing if it is larger than maxSize bytes
+func (c *Client) DownloadArtifact(ctx context.Context, owner, repo string, artifactID, maxSize int64) ([]byte, error) {
+	return c.download(ctx, fmt.Sprintf("repos/%s/%s/actions/artifacts/%d/zip", owner, repo, artifactID), maxSize)
+}
+
+// ListWorkflowRunsWithOptions lists the runs of a workflow matching the
+// given filters
+func (c *Client) ListWorkflowRunsWithOptions(ctx context.Context, owner, repo string, workflowID int64, opts *ListWorkflowRunsOptions) ([]*WorkflowRun, error) {
+	url := fmt.Sprintf("repos/%s/%s/actions/workflows/%d/runs", owner, repo, workflowID)
+
+	if opts != nil {
+		params := neturl.Values{}
+		for name, value := range map[string]string{
+			"actor":   opts.Actor,
+			"branch":  opts.Branch,
+			"event":   opts.Event,
+			"status":  opts.Status,
+			"created": opts.Created,
+		} {
+			if value != "" {
+				params.Set(name, value)
+			}
+		}
+		if len(params) > 0 {
+			url += "?" + params.Encode()
+		}
+		url = addListOptions(url, &opts.ListOptions)
+	}
+
+	req, err := c.newRequest(ctx, "GET", url, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := c.do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	var response struct {
+		TotalCount   int            `json:"total_count"`
+		WorkflowRuns []*WorkflowRun `json:"workflow_runs"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&response); err != nil {
+		return nil, fmt.Errorf("failed to decode response: %w", err)
+	}
+
+	return response.WorkflowRuns, nil
+}
+
+// GetWorkflow gets a workflow by its ID or file name
+func (c *Client) GetWorkflow(ctx context.Context, owner, repo, workflowID string) (*Workflow, error) {
+	url := fmt.Sprintf("repos/%s/%s/actions/workflows/%s", owner, repo, neturl.PathEscape(workflowID))
+
+	req, err := c.newRequest(ctx, "GET", url, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := c.do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	var workflow Workflow
+	if err := json.NewDecoder(resp.Body).Decode(&workflow); err != nil {
+		return nil, fmt.Errorf("failed to decode response: %w", err)
+	}
+
+	return &workflow, nil
+}
@@ -0,0 +1,79 @@
+package github
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+func TestListWorkflowRunArtifacts(t *testing.T) {
+	client := setupTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/repos/octo/hello/actions/runs/42/artifacts" {
+			t.Errorf("Unexpected request path %s", r.URL.Path)
+		}
+		fmt.Fprint(w, `{"total_count": 1, "artifacts": [{"id": 7, "name": "coverage", "size_in_bytes": 2048, "expired": false}]}`)
+	})
+
+	artifacts, total, err := client.ListWorkflowRunArtifacts(context.Background(), "octo", "hello", 42, nil)
+	if err != nil {
+		t.Fatalf("ListWorkflowRunArtifacts failed: %v", err)
+	}
+	if total != 1 || artifacts[0].Name != "coverage" || artifacts[0].SizeInBytes != 2048 {
+		t.Errorf("Unexpected artifacts: %+v", artifacts)
+	}
+}
+
+func TestDownloadArtifact_SizeLimit(t *testing.T) {
+	client := setupTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, strings.Repeat("x", 100))
+	})
+
+	if _, err := client.DownloadArtifact(context.Background(), "octo", "hello", 7, 50); err == nil {
+		t.Error("Expected an error for an artifact over the size limit")
+	}
+	if data, err := client.DownloadArtifact(context.Background(), "octo", "hello", 7, 100); err != nil || len(data) != 100 {
+		t.Errorf("Expected 100 bytes, got %d: %v", len(data), err)
+	}
+}
+
+func TestRerunFailedJobs(t *testing.T) {
+	client := setupTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != "POST" || r.URL.Path != "/repos/octo/hello/actions/runs/42/rerun-failed-jobs" {
+			t.Errorf("Unexpected request %s %s", r.Method, r.URL.Path)
+		}
+		w.WriteHeader(http.StatusCreated)
+	})
+
+	if err := client.RerunFailedJobs(context.Background(), "octo", "hello", 42); err != nil {
+		t.Errorf("RerunFailedJobs failed: %v", err)
+	}
+}
+
+func TestListWorkflowRunsWithOptions(t *testing.T) {
+	client := setupTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/repos/octo/hello/actions/workflows/9/runs" {
+			t.Errorf("Unexpected request path %s", r.URL.Path)
+		}
+		query := r.URL.Query()
+		if query.Get("event") != "workflow_dispatch" || query.Get("actor") != "mona" ||
+			query.Get("created") != ">=2024-05-01T10:00:00Z" || query.Get("per_page") != "10" {
+			t.Errorf("Unexpected query: %s", r.URL.RawQuery)
+		}
+		fmt.Fprint(w, `{"total_count": 1, "workflow_runs": [{"id": 42, "status": "queued"}]}`)
+	})
+
+	runs, err := client.ListWorkflowRunsWithOptions(context.Background(), "octo", "hello", 9, &ListWorkflowRunsOptions{
+		Event:       "workflow_dispatch",
+		Actor:       "mona",
+		Created:     ">=2024-05-01T10:00:00Z",
+		ListOptions: ListOptions{PerPage: 10},
+	})
+	if err != nil {
+		t.Fatalf("ListWorkflowRunsWithOptions failed: %v", err)
+	}
+	if len(runs) != 1 || runs[0].ID != 42 {
+		t.Errorf("Unexpected runs: %+v", runs)
+	}
+}
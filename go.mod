@@ -2,6 +2,9 @@ module github-mcp-server-go
 
 go 1.21
 
-require golang.org/x/crypto v0.18.0
+require (
+	golang.org/x/crypto v0.18.0
+	gopkg.in/yaml.v3 v3.0.1
+)
 
 require golang.org/x/sys v0.16.0 // indirect
@@ -9,23 +9,54 @@ import (
 	"os/signal"
 	"syscall"
 
+	"github-mcp-server-go/github"
 	"github-mcp-server-go/server"
+	"github-mcp-server-go/storage"
 	"github-mcp-server-go/transport"
 )
 
 func main() {
+	// Run as a git credential helper when invoked as `credential <operation>`
+	if len(os.Args) > 1 && os.Args[1] == "credential" {
+		os.Exit(runCredentialHelper(os.Args[2:]))
+	}
+
 	// Parse command line flags
 	tokenFlag := flag.String("token", "", "GitHub Personal Access Token")
 	debugFlag := flag.Bool("debug", false, "Enable debug logging")
+	configDirFlag := flag.String("config-dir", "data", "Directory for stored data such as login tokens and configuration")
+	appIDFlag := flag.Int64("app-id", 0, "GitHub App ID (authenticate as a GitHub App instead of with a token)")
+	appKeyFlag := flag.String("app-key", "", "Path to the GitHub App private key (PEM)")
+	installationIDFlag := flag.Int64("installation-id", 0, "GitHub App installation ID (resolved per repository if omitted)")
+	oauthClientIDFlag := flag.String("oauth-client-id", os.Getenv("GITHUB_OAUTH_CLIENT_ID"), "OAuth app client ID for device flow login")
+	tokenStoreFlag := flag.String("token-store", storage.BackendFile, "Backend for stored login tokens: file or encrypted")
+	tokenKeyFileFlag := flag.String("token-key-file", "", "Key file for the encrypted token store (created if missing); otherwise GITHUB_MCP_TOKEN_PASSPHRASE is used")
+	lintWorkflowsFlag := flag.Bool("lint-workflows", false, "Lint .github/workflows files written by create_file, update_file, commit_changes and edit_file and refuse files with errors")
 	flag.Parse()
 
+	// Configure GitHub App authentication if requested
+	var appAuth *github.AppAuth
+	if *appIDFlag != 0 || *appKeyFlag != "" {
+		if *appIDFlag == 0 || *appKeyFlag == "" {
+			log.Fatal("Both -app-id and -app-key are required for GitHub App authentication")
+		}
+		var err error
+		appAuth, err = github.NewAppAuthFromFile(*appIDFlag, *appKeyFlag, *installationIDFlag)
+		if err != nil {
+			log.Fatalf("Failed to configure GitHub App authentication: %v", err)
+		}
+	}
+
+	// The encrypted token store needs a key source
+	tokenPassphrase := os.Getenv("GITHUB_MCP_TOKEN_PASSPHRASE")
+	if *tokenStoreFlag == storage.BackendEncrypted && *tokenKeyFileFlag == "" && tokenPassphrase == "" {
+		log.Fatal("The encrypted token store requires -token-key-file or GITHUB_MCP_TOKEN_PASSPHRASE")
+	}
+
 	// Check for token in environment variable if not provided via flag
 	token := *tokenFlag
 	if token == "" {
 		token = os.Getenv("GITHUB_PERSONAL_ACCESS_TOKEN")
-		if token == "" {
-			log.Fatal("GitHub token must be provided either via -token flag or GITHUB_PERSONAL_ACCESS_TOKEN environment variable")
-		}
 	}
 
 	// Setup logger
@@ -33,14 +64,30 @@ func main() {
 	if *debugFlag {
 		logger.Println("Debug logging enabled")
 	}
+	if token == "" && appAuth == nil {
+		logger.Println("No GitHub token configured; use the auth_login_token or auth_login_device tools to sign in")
+	}
 
 	// Create server
 	logger.Println("Initializing GitHub MCP server")
-	srv := server.New(server.Config{
-		Token:  token,
-		Logger: logger,
-		Debug:  *debugFlag,
-	})
+	cfg := server.Config{
+		Token:         token,
+		Logger:        logger,
+		Debug:         *debugFlag,
+		ConfigDir:     *configDirFlag,
+		OAuthClientID: *oauthClientIDFlag,
+		LintWorkflows: *lintWorkflowsFlag,
+		TokenStore: storage.StoreConfig{
+			Backend:    *tokenStoreFlag,
+			Passphrase: tokenPassphrase,
+			KeyFile:    *tokenKeyFileFlag,
+		},
+	}
+	if appAuth != nil {
+		logger.Printf("Authenticating as GitHub App %d", appAuth.AppID)
+		cfg.Auth = appAuth
+	}
+	srv := server.New(cfg)
 
 	// Create transport
 	stdioTransport := transport.NewStdioTransport()
@@ -1,6 +1,8 @@
 // github-mcp-server-go/protocol/protocol.go
 package protocol
 
+import "encoding/base64"
+
 // Protocol version constants
 const (
 	LatestProtocolVersion = "1.0"
@@ -61,6 +63,16 @@ func NewResponse(id interface{}, result interface{}) *Message {
 	}
 }
 
+// NewNotification creates a new JSON-RPC notification, which has no ID and
+// expects no response
+func NewNotification(method string, params interface{}) *Message {
+	return &Message{
+		JSONRPC: JSONRPCVersion,
+		Method:  method,
+		Params:  params,
+	}
+}
+
 // NewErrorResponse creates a new JSON-RPC error response
 func NewErrorResponse(id interface{}, code int, message string, data interface{}) *Message {
 	return &Message{
@@ -166,6 +178,22 @@ type Property struct {
 type CallToolParams struct {
 	Name      string                 `json:"name"`
 	Arguments map[string]interface{} `json:"arguments"`
+	Meta      *RequestMeta           `json:"_meta,omitempty"`
+}
+
+// RequestMeta represents the _meta field of a request
+type RequestMeta struct {
+	// ProgressToken asks for notifications/progress messages carrying this token
+	ProgressToken interface{} `json:"progressToken,omitempty"`
+}
+
+// ProgressNotificationParams represents the parameters of a
+// notifications/progress message
+type ProgressNotificationParams struct {
+	ProgressToken interface{} `json:"progressToken"`
+	Progress      float64     `json:"progress"`
+	Total         float64     `json:"total,omitempty"`
+	Message       string      `json:"message,omitempty"`
 }
 
 // CallToolResult represents the result of the tools/call method
@@ -175,9 +203,19 @@ type CallToolResult struct {
 
 // Content represents content in a tool result
 type Content struct {
-	Type  string `json:"type"`
-	Text  string `json:"text,omitempty"`
-	Error bool   `json:"isError,omitempty"`
+	Type     string            `json:"type"`
+	Text     string            `json:"text,omitempty"`
+	Resource *ResourceContents `json:"resource,omitempty"`
+	Error    bool              `json:"isError,omitempty"`
+}
+
+// ResourceContents is the content of an embedded resource. Binary content
+// is base64 encoded in Blob.
+type ResourceContents struct {
+	URI      string `json:"uri"`
+	MimeType string `json:"mimeType,omitempty"`
+	Text     string `json:"text,omitempty"`
+	Blob     string `json:"blob,omitempty"`
 }
 
 // TextContent creates a new text content
@@ -196,3 +234,15 @@ func ErrorContent(text string) Content {
 		Error: true,
 	}
 }
+
+// BlobResourceContent creates embedded resource content holding binary data
+func BlobResourceContent(uri, mimeType string, data []byte) Content {
+	return Content{
+		Type: "resource",
+		Resource: &ResourceContents{
+			URI:      uri,
+			MimeType: mimeType,
+			Blob:     base64.StdEncoding.EncodeToString(data),
+		},
+	}
+}
@@ -0,0 +1,298 @@
+package server
+
+import (
+	"time"
+
+	"github-mcp-server-go/protocol"
+)
+
+// repoProperties returns the schema properties identifying a repository
+func repoProperties() map[string]protocol.Property {
+	return map[string]protocol.Property{
+		"owner": {
+			Type:        "string",
+			Description: "Repository owner (username or organization)",
+		},
+		"repo": {
+			Type:        "string",
+			Description: "Repository name",
+		},
+	}
+}
+
+// getWorkflowRunFailureToolDef returns the definition for the get_workflow_run_failure tool
+func getWorkflowRunFailureToolDef() *protocol.Tool {
+	properties := repoProperties()
+	properties["run_id"] = protocol.Property{
+		Type:        "number",
+		Description: "Workflow run ID",
+	}
+	properties["tail_lines"] = protocol.Property{
+		Type:        "number",
+		Description: "Number of log lines to show from the end of each failed step",
+		Default:     defaultFailureTailLines,
+	}
+	properties["max_bytes"] = protocol.Property{
+		Type:        "number",
+		Description: "Size budget for all log excerpts, shared between the failed steps",
+		Default:     defaultFailureMaxBytes,
+	}
+
+	return &protocol.Tool{
+		Name: "get_workflow_run_failure",
+		Description: "Explain why a workflow run failed: the ##[error] annotations and last log lines " +
+			"of each failed step, with links to the failed jobs",
+		Schema: protocol.ToolSchema{
+			Type:       "object",
+			Properties: properties,
+			Required:   []string{"owner", "repo", "run_id"},
+		},
+	}
+}
+
+// runProperties returns the schema properties identifying a workflow run
+func runProperties() map[string]protocol.Property {
+	properties := repoProperties()
+	properties["run_id"] = protocol.Property{
+		Type:        "number",
+		Description: "Workflow run ID",
+	}
+	return properties
+}
+
+// getWorkflowRunToolDef returns the definition for the get_workflow_run tool
+func getWorkflowRunToolDef() *protocol.Tool {
+	return &protocol.Tool{
+		Name:        "get_workflow_run",
+		Description: "Get the status, conclusion and details of a workflow run",
+		Schema: protocol.ToolSchema{
+			Type:       "object",
+			Properties: runProperties(),
+			Required:   []string{"owner", "repo", "run_id"},
+		},
+	}
+}
+
+// listWorkflowJobsToolDef returns the definition for the list_workflow_jobs tool
+func listWorkflowJobsToolDef() *protocol.Tool {
+	return &protocol.Tool{
+		Name:        "list_workflow_jobs",
+		Description: "List the jobs of the latest attempt of a workflow run with the status and conclusion of each step",
+		Schema: protocol.ToolSchema{
+			Type:       "object",
+			Properties: runProperties(),
+			Required:   []string{"owner", "repo", "run_id"},
+		},
+	}
+}
+
+// cancelWorkflowRunToolDef returns the definition for the cancel_workflow_run tool
+func cancelWorkflowRunToolDef() *protocol.Tool {
+	return &protocol.Tool{
+		Name:        "cancel_workflow_run",
+		Description: "Cancel a queued or in-progress workflow run",
+		Schema: protocol.ToolSchema{
+			Type:       "object",
+			Properties: runProperties(),
+			Required:   []string{"owner", "repo", "run_id"},
+		},
+	}
+}
+
+// rerunWorkflowRunToolDef returns the definition for the rerun_workflow_run tool
+func rerunWorkflowRunToolDef() *protocol.Tool {
+	properties := runProperties()
+	properties["failed_only"] = protocol.Property{
+		Type:        "boolean",
+		Description: "Only rerun the failed jobs and the jobs that depend on them",
+		Default:     false,
+	}
+
+	return &protocol.Tool{
+		Name:        "rerun_workflow_run",
+		Description: "Rerun a completed workflow run, or only its failed jobs",
+		Schema: protocol.ToolSchema{
+			Type:       "object",
+			Properties: properties,
+			Required:   []string{"owner", "repo", "run_id"},
+		},
+	}
+}
+
+// listWorkflowRunArtifactsToolDef returns the definition for the list_workflow_run_artifacts tool
+func listWorkflowRunArtifactsToolDef() *protocol.Tool {
+	return &protocol.Tool{
+		Name:        "list_workflow_run_artifacts",
+		Description: "List the artifacts uploaded by a workflow run with their sizes and expiry",
+		Schema: protocol.ToolSchema{
+			Type:       "object",
+			Properties: paginate(runProperties()),
+			Required:   []string{"owner", "repo", "run_id"},
+		},
+	}
+}
+
+// downloadArtifactToolDef returns the definition for the download_artifact tool
+func downloadArtifactToolDef() *protocol.Tool {
+	properties := repoProperties()
+	properties["artifact_id"] = protocol.Property{
+		Type:        "number",
+		Description: "Artifact ID",
+	}
+	properties["directory"] = protocol.Property{
+		Type:        "string",
+		Description: "Local directory to extract the artifact into; created if missing",
+	}
+	properties["max_bytes"] = protocol.Property{
+		Type:        "number",
+		Description: "Maximum size of the artifact and of the extracted files",
+		Default:     defaultArtifactMaxBytes,
+	}
+	properties["max_files"] = protocol.Property{
+		Type:        "number",
+		Description: "Maximum number of files to extract",
+		Default:     defaultArtifactMaxFiles,
+	}
+
+	return &protocol.Tool{
+		Name:        "download_artifact",
+		Description: "Download a workflow artifact and extract it to a local directory",
+		Schema: protocol.ToolSchema{
+			Type:       "object",
+			Properties: properties,
+			Required:   []string{"owner", "repo", "artifact_id", "directory"},
+		},
+	}
+}
+
+// watchWorkflowRunToolDef returns the definition for the watch_workflow_run tool
+func watchWorkflowRunToolDef() *protocol.Tool {
+	properties := repoProperties()
+	properties["run_id"] = protocol.Property{
+		Type:        "number",
+		Description: "Workflow run to watch; omit to find the run started by a dispatch of workflow_id",
+	}
+	properties["workflow_id"] = protocol.Property{
+		Type:        "string",
+		Description: "Dispatched workflow whose run should be found: ID, file name (e.g. deploy.yml) or name",
+	}
+	properties["ref"] = protocol.Property{
+		Type:        "string",
+		Description: "Branch the workflow was dispatched on",
+	}
+	properties["actor"] = protocol.Property{
+		Type:        "string",
+		Description: "User who dispatched the workflow (defaults to the authenticated user)",
+	}
+	properties["since"] = protocol.Property{
+		Type:        "string",
+		Description: "Only consider runs created at or after this RFC 3339 time (defaults to two minutes ago)",
+		Format:      "date-time",
+	}
+	properties["timeout_seconds"] = protocol.Property{
+		Type:        "number",
+		Description: "How long to wait for the run to complete",
+		Default:     int(defaultWatchTimeout / time.Second),
+	}
+
+	return &protocol.Tool{
+		Name: "watch_workflow_run",
+		Description: "Wait for a workflow run to complete, reporting status changes as progress notifications, " +
+			"and return its conclusion with the failed jobs and steps. Use after trigger_workflow to follow the dispatched run.",
+		Schema: protocol.ToolSchema{
+			Type:       "object",
+			Properties: properties,
+			Required:   []string{"owner", "repo"},
+		},
+	}
+}
+
+// getWorkflowInputsToolDef returns the definition for the get_workflow_inputs tool
+func getWorkflowInputsToolDef() *protocol.Tool {
+	properties := repoProperties()
+	properties["workflow_id"] = protocol.Property{
+		Type:        "string",
+		Description: "Workflow ID, file name (e.g. deploy.yml) or name",
+	}
+	properties["ref"] = protocol.Property{
+		Type:        "string",
+		Description: "Branch, tag or SHA to read the workflow file from (defaults to the default branch)",
+	}
+
+	return &protocol.Tool{
+		Name: "get_workflow_inputs",
+		Description: "Get the workflow_dispatch inputs of a workflow with their types, defaults, options and " +
+			"whether they are required, to build the inputs for trigger_workflow",
+		Schema: protocol.ToolSchema{
+			Type:       "object",
+			Properties: properties,
+			Required:   []string{"owner", "repo", "workflow_id"},
+		},
+	}
+}
+
+// lintWorkflowToolDef returns the definition for the lint_workflow tool
+func lintWorkflowToolDef() *protocol.Tool {
+	return &protocol.Tool{
+		Name: "lint_workflow",
+		Description: "Check a GitHub Actions workflow file offline before committing it: triggers, job and step keys, " +
+			"needs references and cycles, ${{ }} expression syntax and contexts, and uses references",
+		Schema: protocol.ToolSchema{
+			Type: "object",
+			Properties: map[string]protocol.Property{
+				"content": {
+					Type:        "string",
+					Description: "Workflow YAML",
+				},
+				"path": {
+					Type:        "string",
+					Description: "File path used in the reported issues",
+				},
+			},
+			Required: []string{"content"},
+		},
+	}
+}
+
+// analyzeWorkflowRunsToolDef returns the definition for the analyze_workflow_runs tool
+func analyzeWorkflowRunsToolDef() *protocol.Tool {
+	properties := repoProperties()
+	properties["workflow_id"] = protocol.Property{
+		Type:        "string",
+		Description: "Workflow ID, file name (e.g. ci.yml) or name",
+	}
+	properties["branch"] = protocol.Property{
+		Type:        "string",
+		Description: "Only analyze runs on this branch",
+	}
+	properties["event"] = protocol.Property{
+		Type:        "string",
+		Description: "Only analyze runs triggered by this event, e.g. push or pull_request",
+	}
+	properties["days"] = protocol.Property{
+		Type:        "number",
+		Description: "Size of the time window in days",
+		Default:     defaultAnalyticsDays,
+	}
+	properties["max_runs"] = protocol.Property{
+		Type:        "number",
+		Description: "Maximum number of recent runs to analyze; each run costs one API request for its jobs",
+		Default:     defaultAnalyticsMaxRuns,
+	}
+	properties["max_steps"] = protocol.Property{
+		Type:        "number",
+		Description: "Number of slowest steps to list",
+		Default:     defaultAnalyticsMaxSteps,
+	}
+
+	return &protocol.Tool{
+		Name: "analyze_workflow_runs",
+		Description: "Analyze the recent completed runs of a workflow: failure rate, flaky (failed then passed on rerun) " +
+			"count, p50/p95 duration and duration trend per job, and the slowest steps, as compact tables",
+		Schema: protocol.ToolSchema{
+			Type:       "object",
+			Properties: properties,
+			Required:   []string{"owner", "repo", "workflow_id"},
+		},
+	}
+}
@@ -0,0 +1,751 @@
+package server
+
+import (
+	"context"
+	"fmt"
+	"path"
+	"strconv"
+	"strings"
+	"time"
+
+	"github-mcp-server-go/archive"
+	"github-mcp-server-go/github"
+	"github-mcp-server-go/protocol"
+	"github-mcp-server-go/workflow"
+)
+
+// Defaults for get_workflow_run_failure
+const (
+	defaultFailureTailLines = 50
+	defaultFailureMaxBytes  = 16000
+)
+
+// failedConclusion reports whether a job or step conclusion is a failure
+func failedConclusion(conclusion string) bool {
+	return conclusion == "failure" || conclusion == "timed_out"
+}
+
+// stepFailure is the log excerpt of a failed step, or of a whole job when
+// its steps could not be told apart
+type stepFailure struct {
+	job     *github.WorkflowJob
+	step    *github.WorkflowStep
+	content string
+}
+
+// handleGetWorkflowRunFailure handles the get_workflow_run_failure tool
+func (s *Server) handleGetWorkflowRunFailure(ctx context.Context, args map[string]interface{}) (*protocol.CallToolResult, error) {
+	owner, repo, err := requireRepo(args)
+	if err != nil {
+		return nil, err
+	}
+	runID, err := requireInt(args, "run_id")
+	if err != nil {
+		return nil, err
+	}
+	tailLines, err := optionalInt(args, "tail_lines", defaultFailureTailLines)
+	if err != nil {
+		return nil, err
+	}
+	maxBytes, err := optionalInt(args, "max_bytes", defaultFailureMaxBytes)
+	if err != nil {
+		return nil, err
+	}
+	if tailLines < 0 || maxBytes < 1 {
+		return nil, fmt.Errorf("tail_lines must not be negative and max_bytes must be positive")
+	}
+
+	run, err := s.client.GetWorkflowRun(ctx, owner, repo, runID)
+	if err != nil {
+		return errorResult("Failed to get workflow run: %v", err), nil
+	}
+
+	jobs, err := s.client.ListAllWorkflowJobs(ctx, owner, repo, runID)
+	if err != nil {
+		return errorResult("Failed to list workflow jobs: %v", err), nil
+	}
+
+	var failedJobs []*github.WorkflowJob
+	for _, job := range jobs {
+		if failedConclusion(job.Conclusion) {
+			failedJobs = append(failedJobs, job)
+		}
+	}
+	if len(failedJobs) == 0 {
+		return &protocol.CallToolResult{
+			Content: []protocol.Content{
+				protocol.TextContent(fmt.Sprintf("Workflow run %d (%s) has no failed jobs: status %s, conclusion %s",
+					run.ID, run.Name, run.Status, run.Conclusion)),
+			},
+		}, nil
+	}
+
+	// The run archive splits logs per step; jobs missing from it fall back
+	// to their own log
+	var runLogs *github.RunLogs
+	if data, err := s.client.DownloadWorkflowRunLogs(ctx, owner, repo, runID); err == nil {
+		runLogs, _ = github.ParseRunLogs(data)
+	}
+
+	var failures []stepFailure
+	for _, job := range failedJobs {
+		var jobLog *github.JobLog
+		if runLogs != nil {
+			jobLog = runLogs.Job(job.Name)
+		}
+
+		found := false
+		for _, step := range job.Steps {
+			if !failedConclusion(step.Conclusion) || jobLog == nil {
+				continue
+			}
+			if stepLog := jobLog.Step(step.Number); stepLog != nil {
+				failures = append(failures, stepFailure{job: job, step: step, content: stepLog.Content})
+				found = true
+			}
+		}
+		if found {
+			continue
+		}
+
+		var content string
+		if jobLog != nil && jobLog.Content != "" {
+			content = jobLog.Content
+		} else if content, err = s.client.DownloadJobLogs(ctx, owner, repo, job.ID); err != nil {
+			return errorResult("Failed to download logs of job %s: %v", job.Name, err), nil
+		}
+		failures = append(failures, stepFailure{job: job, step: failedStep(job), content: content})
+	}
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "Workflow run %d (%s) %s: %s\n", run.ID, run.Name, run.Conclusion, run.HTMLURL)
+
+	budget := int(maxBytes) / len(failures)
+	for _, failure := range failures {
+		fmt.Fprintf(&b, "\nJob %s (%s): %s\n", failure.job.Name, failure.job.Conclusion, failure.job.HTMLURL)
+		if failure.step != nil {
+			fmt.Fprintf(&b, "Step %d %q (%s)\n", failure.step.Number, failure.step.Name, failure.step.Conclusion)
+		}
+
+		excerpt := github.ExcerptLog(failure.content, int(tailLines), budget)
+		if len(excerpt.Errors) > 0 {
+			b.WriteString("Errors:\n")
+			for _, line := range excerpt.Errors {
+				fmt.Fprintf(&b, "  %s\n", line)
+			}
+		}
+		fmt.Fprintf(&b, "Last %d lines", len(excerpt.Tail))
+		if excerpt.OmittedLines > 0 {
+			fmt.Fprintf(&b, " (%d earlier lines omitted)", excerpt.OmittedLines)
+		}
+		b.WriteString(":\n")
+		for _, line := range excerpt.Tail {
+			fmt.Fprintf(&b, "  %s\n", line)
+		}
+	}
+
+	return &protocol.CallToolResult{
+		Content: []protocol.Content{
+			protocol.TextContent(b.String()),
+		},
+	}, nil
+}
+
+// failedStep returns the first failed step of a job, or nil
+func failedStep(job *github.WorkflowJob) *github.WorkflowStep {
+	for _, step := range job.Steps {
+		if failedConclusion(step.Conclusion) {
+			return step
+		}
+	}
+	return nil
+}
+
+// Defaults for download_artifact
+const (
+	defaultArtifactMaxBytes = 100 << 20
+	defaultArtifactMaxFiles = 1000
+)
+
+// runArgs returns the owner, repo and run_id arguments
+func runArgs(args map[string]interface{}) (string, string, int64, error) {
+	owner, repo, err := requireRepo(args)
+	if err != nil {
+		return "", "", 0, err
+	}
+	runID, err := requireInt(args, "run_id")
+	if err != nil {
+		return "", "", 0, err
+	}
+	return owner, repo, runID, nil
+}
+
+// handleGetWorkflowRun handles the get_workflow_run tool
+func (s *Server) handleGetWorkflowRun(ctx context.Context, args map[string]interface{}) (*protocol.CallToolResult, error) {
+	owner, repo, runID, err := runArgs(args)
+	if err != nil {
+		return nil, err
+	}
+
+	run, err := s.client.GetWorkflowRun(ctx, owner, repo, runID)
+	if err != nil {
+		return errorResult("Failed to get workflow run: %v", err), nil
+	}
+
+	return jsonResult(run)
+}
+
+// handleListWorkflowJobs handles the list_workflow_jobs tool
+func (s *Server) handleListWorkflowJobs(ctx context.Context, args map[string]interface{}) (*protocol.CallToolResult, error) {
+	owner, repo, runID, err := runArgs(args)
+	if err != nil {
+		return nil, err
+	}
+
+	jobs, err := s.client.ListAllWorkflowJobs(ctx, owner, repo, runID)
+	if err != nil {
+		return errorResult("Failed to list workflow jobs: %v", err), nil
+	}
+
+	return jsonResult(jobs)
+}
+
+// handleCancelWorkflowRun handles the cancel_workflow_run tool
+func (s *Server) handleCancelWorkflowRun(ctx context.Context, args map[string]interface{}) (*protocol.CallToolResult, error) {
+	owner, repo, runID, err := runArgs(args)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.client.CancelWorkflowRun(ctx, owner, repo, runID); err != nil {
+		return errorResult("Failed to cancel workflow run: %v", err), nil
+	}
+
+	return &protocol.CallToolResult{
+		Content: []protocol.Content{
+			protocol.TextContent(fmt.Sprintf("Cancellation of workflow run %d requested", runID)),
+		},
+	}, nil
+}
+
+// handleRerunWorkflowRun handles the rerun_workflow_run tool
+func (s *Server) handleRerunWorkflowRun(ctx context.Context, args map[string]interface{}) (*protocol.CallToolResult, error) {
+	owner, repo, runID, err := runArgs(args)
+	if err != nil {
+		return nil, err
+	}
+
+	message := fmt.Sprintf("Rerun of workflow run %d requested", runID)
+	if optionalBool(args, "failed_only") {
+		err = s.client.RerunFailedJobs(ctx, owner, repo, runID)
+		message = fmt.Sprintf("Rerun of the failed jobs of workflow run %d requested", runID)
+	} else {
+		err = s.client.RerunWorkflow(ctx, owner, repo, runID)
+	}
+	if err != nil {
+		return errorResult("Failed to rerun workflow run: %v", err), nil
+	}
+
+	return &protocol.CallToolResult{
+		Content: []protocol.Content{
+			protocol.TextContent(message),
+		},
+	}, nil
+}
+
+// handleListWorkflowRunArtifacts handles the list_workflow_run_artifacts tool
+func (s *Server) handleListWorkflowRunArtifacts(ctx context.Context, args map[string]interface{}) (*protocol.CallToolResult, error) {
+	owner, repo, runID, err := runArgs(args)
+	if err != nil {
+		return nil, err
+	}
+	opts, err := listOptionsArgs(args)
+	if err != nil {
+		return nil, err
+	}
+
+	artifacts, _, err := s.client.ListWorkflowRunArtifacts(ctx, owner, repo, runID, opts)
+	if err != nil {
+		return errorResult("Failed to list artifacts: %v", err), nil
+	}
+
+	return jsonResult(artifacts)
+}
+
+// handleDownloadArtifact handles the download_artifact tool
+func (s *Server) handleDownloadArtifact(ctx context.Context, args map[string]interface{}) (*protocol.CallToolResult, error) {
+	owner, repo, err := requireRepo(args)
+	if err != nil {
+		return nil, err
+	}
+	artifactID, err := requireInt(args, "artifact_id")
+	if err != nil {
+		return nil, err
+	}
+	directory, err := requireString(args, "directory")
+	if err != nil {
+		return nil, err
+	}
+	maxBytes, err := optionalInt(args, "max_bytes", defaultArtifactMaxBytes)
+	if err != nil {
+		return nil, err
+	}
+	maxFiles, err := optionalInt(args, "max_files", defaultArtifactMaxFiles)
+	if err != nil {
+		return nil, err
+	}
+
+	artifact, err := s.client.GetArtifact(ctx, owner, repo, artifactID)
+	if err != nil {
+		return errorResult("Failed to get artifact: %v", err), nil
+	}
+	if artifact.Expired {
+		return errorResult("Artifact %s has expired", artifact.Name), nil
+	}
+	if artifact.SizeInBytes > maxBytes {
+		return errorResult("Artifact %s is %d bytes, more than max_bytes (%d)", artifact.Name, artifact.SizeInBytes, maxBytes), nil
+	}
+
+	data, err := s.client.DownloadArtifact(ctx, owner, repo, artifactID, maxBytes)
+	if err != nil {
+		return errorResult("Failed to download artifact: %v", err), nil
+	}
+
+	files, err := archive.Extract(data, directory, archive.Limits{MaxFiles: int(maxFiles), MaxBytes: maxBytes})
+	if err != nil {
+		return errorResult("Failed to extract artifact %s: %v", artifact.Name, err), nil
+	}
+
+	return jsonResult(map[string]interface{}{
+		"artifact": artifact.Name,
+		"files":    files,
+	})
+}
+
+// Polling parameters for watch_workflow_run
+const (
+	defaultWatchTimeout     = 30 * time.Minute
+	watchPollInterval       = 5 * time.Second
+	watchMaxPollInterval    = 60 * time.Second
+	defaultDispatchLookback = 2 * time.Minute
+)
+
+// watchResult is the outcome of watch_workflow_run
+type watchResult struct {
+	RunID      int64        `json:"run_id"`
+	Name       string       `json:"name"`
+	HTMLURL    string       `json:"html_url"`
+	Status     string       `json:"status"`
+	Conclusion string       `json:"conclusion,omitempty"`
+	TimedOut   bool         `json:"timed_out,omitempty"`
+	FailedJobs []*failedJob `json:"failed_jobs,omitempty"`
+}
+
+// failedJob summarizes a failed job of a watched run
+type failedJob struct {
+	Name        string   `json:"name"`
+	Conclusion  string   `json:"conclusion"`
+	HTMLURL     string   `json:"html_url"`
+	FailedSteps []string `json:"failed_steps,omitempty"`
+}
+
+// handleWatchWorkflowRun handles the watch_workflow_run tool
+func (s *Server) handleWatchWorkflowRun(ctx context.Context, args map[string]interface{}) (*protocol.CallToolResult, error) {
+	owner, repo, err := requireRepo(args)
+	if err != nil {
+		return nil, err
+	}
+	runID, err := optionalInt(args, "run_id", 0)
+	if err != nil {
+		return nil, err
+	}
+	if _, ok := args["workflow_id"]; runID == 0 && !ok {
+		return nil, fmt.Errorf("run_id or workflow_id is required")
+	}
+	timeoutSeconds, err := optionalInt(args, "timeout_seconds", int64(defaultWatchTimeout/time.Second))
+	if err != nil {
+		return nil, err
+	}
+
+	since := time.Now().Add(-defaultDispatchLookback)
+	if value := optionalString(args, "since", ""); value != "" {
+		if since, err = time.Parse(time.RFC3339, value); err != nil {
+			return nil, fmt.Errorf("since must be an RFC 3339 time: %v", err)
+		}
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, time.Duration(timeoutSeconds)*time.Second)
+	defer cancel()
+
+	var run *github.WorkflowRun
+	if runID != 0 {
+		run, err = s.client.GetWorkflowRun(ctx, owner, repo, runID)
+		if err != nil {
+			return errorResult("Failed to get workflow run: %v", err), nil
+		}
+	} else {
+		wf, err := s.workflowArg(ctx, owner, repo, args)
+		if err != nil {
+			return errorResult("Failed to find workflow: %v", err), nil
+		}
+
+		opts := &github.ListWorkflowRunsOptions{
+			Event:   "workflow_dispatch",
+			Branch:  optionalString(args, "ref", ""),
+			Actor:   optionalString(args, "actor", ""),
+			Created: ">=" + since.UTC().Format(time.RFC3339),
+		}
+		if opts.Actor == "" {
+			if user, _, err := s.client.GetAuthenticatedUser(ctx); err == nil {
+				opts.Actor = user.Login
+			}
+		}
+
+		reportProgress(ctx, 0, fmt.Sprintf("Waiting for a run of %s", wf.Name))
+		run, err = s.findDispatchedRun(ctx, owner, repo, wf.ID, opts)
+		if err != nil {
+			return errorResult("Failed to find the dispatched run: %v", err), nil
+		}
+	}
+
+	// Poll until the run completes, backing off while nothing changes
+	step := 1.0
+	interval := watchPollInterval
+	reportProgress(ctx, step, fmt.Sprintf("Run %d (%s) is %s: %s", run.ID, run.Name, run.Status, run.HTMLURL))
+	timedOut := func() (*protocol.CallToolResult, error) {
+		return jsonResult(&watchResult{
+			RunID:    run.ID,
+			Name:     run.Name,
+			HTMLURL:  run.HTMLURL,
+			Status:   run.Status,
+			TimedOut: true,
+		})
+	}
+	for run.Status != "completed" {
+		if err := sleepContext(ctx, interval); err != nil {
+			return timedOut()
+		}
+
+		current, err := s.client.GetWorkflowRun(ctx, owner, repo, run.ID)
+		if err != nil {
+			// The timeout may expire during the request as well
+			if ctx.Err() != nil {
+				return timedOut()
+			}
+			return errorResult("Failed to get workflow run: %v", err), nil
+		}
+
+		if current.Status != run.Status {
+			step++
+			reportProgress(ctx, step, fmt.Sprintf("Run %d is %s", current.ID, current.Status))
+			interval = watchPollInterval
+		} else if interval = interval * 3 / 2; interval > watchMaxPollInterval {
+			interval = watchMaxPollInterval
+		}
+		run = current
+	}
+
+	result := &watchResult{
+		RunID:      run.ID,
+		Name:       run.Name,
+		HTMLURL:    run.HTMLURL,
+		Status:     run.Status,
+		Conclusion: run.Conclusion,
+	}
+	reportProgress(ctx, step+1, fmt.Sprintf("Run %d completed: %s", run.ID, run.Conclusion))
+
+	if run.Conclusion != "success" {
+		jobs, err := s.client.ListAllWorkflowJobs(ctx, owner, repo, run.ID)
+		if err != nil {
+			return errorResult("Failed to list workflow jobs: %v", err), nil
+		}
+		for _, job := range jobs {
+			if !failedConclusion(job.Conclusion) {
+				continue
+			}
+			failed := &failedJob{Name: job.Name, Conclusion: job.Conclusion, HTMLURL: job.HTMLURL}
+			for _, step := range job.Steps {
+				if failedConclusion(step.Conclusion) {
+					failed.FailedSteps = append(failed.FailedSteps, step.Name)
+				}
+			}
+			result.FailedJobs = append(result.FailedJobs, failed)
+		}
+	}
+
+	return jsonResult(result)
+}
+
+// findDispatchedRun polls for the first run matching opts. The dispatch
+// API returns no run ID and the run appears a few seconds after the call.
+func (s *Server) findDispatchedRun(ctx context.Context, owner, repo string, workflowID int64, opts *github.ListWorkflowRunsOptions) (*github.WorkflowRun, error) {
+	interval := watchPollInterval
+	for {
+		runs, err := s.client.ListWorkflowRunsWithOptions(ctx, owner, repo, workflowID, opts)
+		if err != nil {
+			return nil, err
+		}
+
+		// Runs are listed newest first; the oldest match is the one
+		// started by the dispatch right after the since time
+		if len(runs) > 0 {
+			return runs[len(runs)-1], nil
+		}
+
+		if err := sleepContext(ctx, interval); err != nil {
+			return nil, fmt.Errorf("no run of workflow %d created since %s", workflowID, strings.TrimPrefix(opts.Created, ">="))
+		}
+		if interval *= 2; interval > watchMaxPollInterval {
+			interval = watchMaxPollInterval
+		}
+	}
+}
+
+// sleepContext waits for d or until ctx is done
+func sleepContext(ctx context.Context, d time.Duration) error {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
+
+// workflowArg resolves the workflow_id argument, which may be a numeric ID,
+// a file name such as deploy.yml, a path under .github/workflows or the
+// workflow's name
+func (s *Server) workflowArg(ctx context.Context, owner, repo string, args map[string]interface{}) (*github.Workflow, error) {
+	var ref string
+	switch value := args["workflow_id"].(type) {
+	case float64:
+		ref = strconv.FormatInt(int64(value), 10)
+	case int:
+		ref = strconv.Itoa(value)
+	case int64:
+		ref = strconv.FormatInt(value, 10)
+	case string:
+		ref = strings.TrimSpace(value)
+	}
+	if ref == "" {
+		return nil, fmt.Errorf("workflow_id is required and must be a workflow ID, file name or name")
+	}
+
+	// IDs and file names are resolved by GitHub directly
+	if _, err := strconv.ParseInt(ref, 10, 64); err == nil {
+		return s.client.GetWorkflow(ctx, owner, repo, ref)
+	}
+	if strings.HasSuffix(ref, ".yml") || strings.HasSuffix(ref, ".yaml") {
+		return s.client.GetWorkflow(ctx, owner, repo, path.Base(ref))
+	}
+
+	var workflows []*github.Workflow
+	for page := 1; ; page++ {
+		pageWorkflows, err := s.client.ListWorkflows(ctx, owner, repo, page, 100)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list workflows: %w", err)
+		}
+		workflows = append(workflows, pageWorkflows...)
+		if len(pageWorkflows) < 100 {
+			break
+		}
+	}
+
+	var matches []*github.Workflow
+	for _, workflow := range workflows {
+		if workflow.Path == ref || path.Base(workflow.Path) == ref {
+			return workflow, nil
+		}
+		if strings.EqualFold(workflow.Name, ref) {
+			matches = append(matches, workflow)
+		}
+	}
+
+	switch len(matches) {
+	case 0:
+		return nil, fmt.Errorf("no workflow named %q in %s/%s", ref, owner, repo)
+	case 1:
+		return matches[0], nil
+	default:
+		var paths []string
+		for _, workflow := range matches {
+			paths = append(paths, path.Base(workflow.Path))
+		}
+		return nil, fmt.Errorf("several workflows are named %q; use a file name: %s", ref, strings.Join(paths, ", "))
+	}
+}
+
+// workflowInputs fetches a workflow file at ref and parses its dispatch
+// inputs
+func (s *Server) workflowInputs(ctx context.Context, owner, repo string, wf *github.Workflow, ref string) ([]*workflow.Input, bool, error) {
+	content, err := s.client.GetContent(ctx, owner, repo, wf.Path, ref)
+	if err != nil {
+		return nil, false, fmt.Errorf("failed to get %s: %w", wf.Path, err)
+	}
+	return workflow.DispatchInputs([]byte(content.Content))
+}
+
+// handleGetWorkflowInputs handles the get_workflow_inputs tool
+func (s *Server) handleGetWorkflowInputs(ctx context.Context, args map[string]interface{}) (*protocol.CallToolResult, error) {
+	owner, repo, err := requireRepo(args)
+	if err != nil {
+		return nil, err
+	}
+
+	wf, err := s.workflowArg(ctx, owner, repo, args)
+	if err != nil {
+		return errorResult("Failed to find workflow: %v", err), nil
+	}
+
+	inputs, dispatchable, err := s.workflowInputs(ctx, owner, repo, wf, optionalString(args, "ref", ""))
+	if err != nil {
+		return errorResult("Failed to read workflow inputs: %v", err), nil
+	}
+	if inputs == nil {
+		inputs = []*workflow.Input{}
+	}
+
+	return jsonResult(map[string]interface{}{
+		"workflow_id":  wf.ID,
+		"name":         wf.Name,
+		"path":         wf.Path,
+		"dispatchable": dispatchable,
+		"inputs":       inputs,
+	})
+}
+
+// formatLintIssues renders lint issues one per line, prefixed with the path
+func formatLintIssues(name string, issues []workflow.Issue) string {
+	var b strings.Builder
+	for _, issue := range issues {
+		fmt.Fprintf(&b, "%s:%s\n", name, issue)
+	}
+	return b.String()
+}
+
+// handleLintWorkflow handles the lint_workflow tool
+func (s *Server) handleLintWorkflow(ctx context.Context, args map[string]interface{}) (*protocol.CallToolResult, error) {
+	content, err := requireString(args, "content")
+	if err != nil {
+		return nil, err
+	}
+	name := optionalString(args, "path", "workflow.yml")
+
+	issues := workflow.Lint([]byte(content))
+	if len(issues) == 0 {
+		return &protocol.CallToolResult{
+			Content: []protocol.Content{
+				protocol.TextContent(fmt.Sprintf("%s: no issues found", name)),
+			},
+		}, nil
+	}
+
+	return &protocol.CallToolResult{
+		Content: []protocol.Content{
+			protocol.TextContent(formatLintIssues(name, issues)),
+		},
+	}, nil
+}
+
+// checkWorkflowFile lints a workflow file about to be committed when
+// workflow linting is enabled. It returns an error result if the file has
+// errors, or nil if it may be committed.
+func (s *Server) checkWorkflowFile(args map[string]interface{}, filePath, content string) *protocol.CallToolResult {
+	if !s.config.LintWorkflows || !workflow.IsWorkflowPath(filePath) || optionalBool(args, "skip_lint") {
+		return nil
+	}
+
+	issues := workflow.Lint([]byte(content))
+	if !workflow.HasErrors(issues) {
+		return nil
+	}
+	return errorResult("Workflow %s was not committed because it has errors (pass skip_lint to commit anyway):\n%s",
+		filePath, formatLintIssues(filePath, issues))
+}
+
+// Defaults for analyze_workflow_runs
+const (
+	defaultAnalyticsDays     = 14
+	defaultAnalyticsMaxRuns  = 100
+	defaultAnalyticsMaxSteps = 20
+)
+
+// handleAnalyzeWorkflowRuns handles the analyze_workflow_runs tool
+func (s *Server) handleAnalyzeWorkflowRuns(ctx context.Context, args map[string]interface{}) (*protocol.CallToolResult, error) {
+	owner, repo, err := requireRepo(args)
+	if err != nil {
+		return nil, err
+	}
+	days, err := optionalInt(args, "days", defaultAnalyticsDays)
+	if err != nil {
+		return nil, err
+	}
+	maxRuns, err := optionalInt(args, "max_runs", defaultAnalyticsMaxRuns)
+	if err != nil {
+		return nil, err
+	}
+	maxSteps, err := optionalInt(args, "max_steps", defaultAnalyticsMaxSteps)
+	if err != nil {
+		return nil, err
+	}
+	if days < 1 || maxRuns < 1 {
+		return nil, fmt.Errorf("days and max_runs must be positive")
+	}
+	if maxSteps < 0 {
+		return nil, fmt.Errorf("max_steps must not be negative")
+	}
+
+	wf, err := s.workflowArg(ctx, owner, repo, args)
+	if err != nil {
+		return errorResult("Failed to find workflow: %v", err), nil
+	}
+
+	since := time.Now().AddDate(0, 0, -int(days))
+	opts := &github.ListWorkflowRunsOptions{
+		Branch:  optionalString(args, "branch", ""),
+		Event:   optionalString(args, "event", ""),
+		Status:  "completed",
+		Created: ">=" + since.UTC().Format(time.RFC3339),
+	}
+
+	// Runs are listed newest first, so the cap keeps the most recent ones
+	var runs []*github.WorkflowRun
+	for page := 1; int64(len(runs)) < maxRuns; page++ {
+		opts.ListOptions = github.ListOptions{Page: page, PerPage: 100}
+		pageRuns, err := s.client.ListWorkflowRunsWithOptions(ctx, owner, repo, wf.ID, opts)
+		if err != nil {
+			return errorResult("Failed to list workflow runs: %v", err), nil
+		}
+		runs = append(runs, pageRuns...)
+		if len(pageRuns) < opts.PerPage {
+			break
+		}
+	}
+	if int64(len(runs)) > maxRuns {
+		runs = runs[:maxRuns]
+	}
+
+	jobs := make(map[int64][]*github.WorkflowJob, len(runs))
+	for i, run := range runs {
+		reportProgress(ctx, float64(i), fmt.Sprintf("Listing jobs of run %d (%d of %d)", run.ID, i+1, len(runs)))
+		runJobs, err := s.client.ListAllWorkflowJobAttempts(ctx, owner, repo, run.ID)
+		if err != nil {
+			return errorResult("Failed to list jobs of run %d: %v", run.ID, err), nil
+		}
+		jobs[run.ID] = runJobs
+	}
+
+	stats := github.AnalyzeWorkflowRuns(runs, jobs)
+	header := fmt.Sprintf("Workflow %s (%s), last %d days", wf.Name, wf.Path, days)
+	if opts.Branch != "" {
+		header += ", branch " + opts.Branch
+	}
+
+	return &protocol.CallToolResult{
+		Content: []protocol.Content{
+			protocol.TextContent(header + "\n" + stats.Table(int(maxSteps))),
+		},
+	}, nil
+}
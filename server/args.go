@@ -0,0 +1,131 @@
+package server
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+
+	"github-mcp-server-go/protocol"
+)
+
+// requireString returns a required string argument
+func requireString(args map[string]interface{}, name string) (string, error) {
+	value, ok := args[name].(string)
+	if !ok || value == "" {
+		return "", fmt.Errorf("%s is required and must be a string", name)
+	}
+	return value, nil
+}
+
+// optionalString returns a string argument, or def if it is not set
+func optionalString(args map[string]interface{}, name, def string) string {
+	if value, ok := args[name].(string); ok && value != "" {
+		return value
+	}
+	return def
+}
+
+// requireInt returns a required integer argument, given as a number or a
+// numeric string
+func requireInt(args map[string]interface{}, name string) (int64, error) {
+	if _, ok := args[name]; !ok {
+		return 0, fmt.Errorf("%s is required and must be an integer", name)
+	}
+	return parseInt(args, name)
+}
+
+// optionalInt returns an integer argument, or def if it is not set
+func optionalInt(args map[string]interface{}, name string, def int64) (int64, error) {
+	if _, ok := args[name]; !ok {
+		return def, nil
+	}
+	return parseInt(args, name)
+}
+
+// parseInt converts an integer argument
+func parseInt(args map[string]interface{}, name string) (int64, error) {
+	switch n := args[name].(type) {
+	case float64:
+		return int64(n), nil
+	case int:
+		return int64(n), nil
+	case int64:
+		return n, nil
+	case string:
+		value, err := strconv.ParseInt(n, 10, 64)
+		if err != nil {
+			return 0, fmt.Errorf("%s must be an integer: %v", name, err)
+		}
+		return value, nil
+	default:
+		return 0, fmt.Errorf("%s is required and must be an integer", name)
+	}
+}
+
+// optionalBool returns a boolean argument, or false if it is not set
+func optionalBool(args map[string]interface{}, name string) bool {
+	value, _ := args[name].(bool)
+	return value
+}
+
+// requireRepo returns the required owner and repo arguments
+func requireRepo(args map[string]interface{}) (string, string, error) {
+	owner, err := requireString(args, "owner")
+	if err != nil {
+		return "", "", err
+	}
+	repo, err := requireString(args, "repo")
+	if err != nil {
+		return "", "", err
+	}
+	return owner, repo, nil
+}
+
+// jsonResult formats a value as an indented JSON tool result
+func jsonResult(v interface{}) (*protocol.CallToolResult, error) {
+	data, err := json.MarshalIndent(v, "", "\t")
+	if err != nil {
+		return nil, fmt.Errorf("failed to format result: %w", err)
+	}
+
+	return &protocol.CallToolResult{
+		Content: []protocol.Content{
+			protocol.TextContent(string(data)),
+		},
+	}, nil
+}
+
+// errorResult formats a failed GitHub call as an error tool result
+func errorResult(format string, args ...interface{}) *protocol.CallToolResult {
+	return &protocol.CallToolResult{
+		Content: []protocol.Content{
+			protocol.ErrorContent(fmt.Sprintf(format, args...)),
+		},
+	}
+}
+
+// optionalStrings returns a list of strings given as an array or a single
+// string, or nil if the argument is not set
+func optionalStrings(args map[string]interface{}, name string) ([]string, error) {
+	switch value := args[name].(type) {
+	case nil:
+		return nil, nil
+	case string:
+		if value == "" {
+			return nil, nil
+		}
+		return []string{value}, nil
+	case []interface{}:
+		values := make([]string, 0, len(value))
+		for _, item := range value {
+			str, ok := item.(string)
+			if !ok {
+				return nil, fmt.Errorf("%s must be an array of strings", name)
+			}
+			values = append(values, str)
+		}
+		return values, nil
+	default:
+		return nil, fmt.Errorf("%s must be an array of strings", name)
+	}
+}
@@ -12,7 +12,10 @@ import (
 // registerAuthTools registers authentication-related tools
 func (s *Server) registerAuthTools() {
 	// Initialize auth tool
-	authTool, err := auth.NewTool(filepath.Join(s.config.ConfigDir, "auth"))
+	authTool, err := auth.NewToolWithOptions(filepath.Join(s.config.ConfigDir, "auth"), auth.ToolOptions{
+		OAuthClientID: s.config.OAuthClientID,
+		Store:         s.config.TokenStore,
+	})
 	if err != nil {
 		s.config.Logger.Printf("Failed to initialize auth tool: %v", err)
 		return
@@ -21,8 +24,11 @@ func (s *Server) registerAuthTools() {
 
 	// Register auth tools
 	s.tools["auth_login_token"] = s.handleLoginWithToken
+	s.tools["auth_login_device"] = s.handleLoginWithDevice
 	s.tools["auth_logout"] = s.handleLogout
 	s.tools["auth_status"] = s.handleAuthStatus
+	s.tools["auth_list_accounts"] = s.handleListAccounts
+	s.tools["auth_switch"] = s.handleSwitchAccount
 }
 
 // handleLoginWithToken handles the auth_login_token tool
@@ -35,6 +41,16 @@ func (s *Server) handleLoginWithToken(ctx context.Context, args map[string]inter
 	return s.authTool.LoginWithToken(ctx, args)
 }
 
+// handleLoginWithDevice handles the auth_login_device tool
+func (s *Server) handleLoginWithDevice(ctx context.Context, args map[string]interface{}) (*protocol.CallToolResult, error) {
+	if s.authTool == nil {
+		return nil, fmt.Errorf("auth tool not initialized")
+	}
+
+	// Call auth tool
+	return s.authTool.LoginWithDevice(ctx, args)
+}
+
 // handleLogout handles the auth_logout tool
 func (s *Server) handleLogout(ctx context.Context, args map[string]interface{}) (*protocol.CallToolResult, error) {
 	if s.authTool == nil {
@@ -54,3 +70,23 @@ func (s *Server) handleAuthStatus(ctx context.Context, args map[string]interface
 	// Call auth tool
 	return s.authTool.GetAuthStatus(ctx, args)
 }
+
+// handleListAccounts handles the auth_list_accounts tool
+func (s *Server) handleListAccounts(ctx context.Context, args map[string]interface{}) (*protocol.CallToolResult, error) {
+	if s.authTool == nil {
+		return nil, fmt.Errorf("auth tool not initialized")
+	}
+
+	// Call auth tool
+	return s.authTool.ListAccounts(ctx, args)
+}
+
+// handleSwitchAccount handles the auth_switch tool
+func (s *Server) handleSwitchAccount(ctx context.Context, args map[string]interface{}) (*protocol.CallToolResult, error) {
+	if s.authTool == nil {
+		return nil, fmt.Errorf("auth tool not initialized")
+	}
+
+	// Call auth tool
+	return s.authTool.SwitchAccount(ctx, args)
+}
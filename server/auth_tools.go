@@ -1,13 +1,15 @@
 package server
 
 import (
+	"strings"
+
 	"github-mcp-server-go/protocol"
 )
 
 func loginWithTokenToolDef() *protocol.Tool {
 	return &protocol.Tool{
 		Name:        "auth_login_token",
-		Description: "Login with a GitHub Personal Access Token",
+		Description: "Login with a GitHub Personal Access Token. The token is validated and its login, scopes and expiry are recorded.",
 		Schema: protocol.ToolSchema{
 			Type: "object",
 			Properties: map[string]protocol.Property{
@@ -15,12 +17,49 @@ func loginWithTokenToolDef() *protocol.Tool {
 					Type:        "string",
 					Description: "GitHub Personal Access Token",
 				},
+				"account": {
+					Type:        "string",
+					Description: "Account name to store the token under (optional, defaults to the active account)",
+				},
+				"host": {
+					Type:        "string",
+					Description: "GitHub Enterprise Server hostname (optional, defaults to github.com)",
+				},
+			},
+			Required: []string{"token"},
+		},
+	}
+}
+
+func loginWithDeviceToolDef() *protocol.Tool {
+	return &protocol.Tool{
+		Name:        "auth_login_device",
+		Description: "Login through GitHub's OAuth device flow. Returns a verification URL and user code to enter in a browser; the token is stored once the user authorizes.",
+		Schema: protocol.ToolSchema{
+			Type: "object",
+			Properties: map[string]protocol.Property{
+				"client_id": {
+					Type:        "string",
+					Description: "OAuth app client ID (optional, defaults to the server's configured client ID)",
+				},
 				"scopes": {
 					Type:        "array",
-					Description: "Token scopes (optional, defaults to ['repo', 'user'])",
+					Description: "OAuth scopes to request (optional, defaults to ['repo', 'read:org'])",
+				},
+				"wait": {
+					Type:        "boolean",
+					Description: "Wait for the user to complete authorization before returning",
+					Default:     false,
+				},
+				"account": {
+					Type:        "string",
+					Description: "Account name to store the token under (optional, defaults to the active account)",
+				},
+				"host": {
+					Type:        "string",
+					Description: "GitHub Enterprise Server hostname (optional, defaults to github.com)",
 				},
 			},
-			Required: []string{"token"},
 		},
 	}
 }
@@ -28,10 +67,15 @@ func loginWithTokenToolDef() *protocol.Tool {
 func logoutToolDef() *protocol.Tool {
 	return &protocol.Tool{
 		Name:        "auth_logout",
-		Description: "Logout and remove stored authentication credentials",
+		Description: "Logout and remove stored authentication credentials of an account",
 		Schema: protocol.ToolSchema{
-			Type:       "object",
-			Properties: map[string]protocol.Property{},
+			Type: "object",
+			Properties: map[string]protocol.Property{
+				"account": {
+					Type:        "string",
+					Description: "Account to log out of (optional, defaults to the active account)",
+				},
+			},
 		},
 	}
 }
@@ -39,10 +83,68 @@ func logoutToolDef() *protocol.Tool {
 func getAuthStatusToolDef() *protocol.Tool {
 	return &protocol.Tool{
 		Name:        "auth_status",
-		Description: "Get current authentication status",
+		Description: "Get current authentication status, including the token's login, scopes and expiry, with warnings for tokens close to expiring or missing scopes the tools need",
+		Schema: protocol.ToolSchema{
+			Type: "object",
+			Properties: map[string]protocol.Property{
+				"account": {
+					Type:        "string",
+					Description: "Account to check (optional, defaults to the active account)",
+				},
+			},
+		},
+	}
+}
+
+func listAccountsToolDef() *protocol.Tool {
+	return &protocol.Tool{
+		Name:        "auth_list_accounts",
+		Description: "List stored accounts with their login, host, scopes and expiry; the active account is marked with *",
 		Schema: protocol.ToolSchema{
 			Type:       "object",
 			Properties: map[string]protocol.Property{},
 		},
 	}
 }
+
+func switchAccountToolDef() *protocol.Tool {
+	return &protocol.Tool{
+		Name:        "auth_switch",
+		Description: "Switch the active account used for GitHub requests",
+		Schema: protocol.ToolSchema{
+			Type: "object",
+			Properties: map[string]protocol.Property{
+				"account": {
+					Type:        "string",
+					Description: "Name of a stored account",
+				},
+			},
+			Required: []string{"account"},
+		},
+	}
+}
+
+// usesGitHubAccount reports whether a tool calls the GitHub API and so
+// accepts a per-call account override
+func usesGitHubAccount(name string) bool {
+	if name == "lint_workflow" {
+		return false
+	}
+	for _, prefix := range []string{"auth_", "config_", "alias_"} {
+		if strings.HasPrefix(name, prefix) {
+			return false
+		}
+	}
+	return true
+}
+
+// addAccountProperty adds the optional account override to a tool schema
+func addAccountProperty(tool *protocol.Tool) {
+	if tool.Schema.Properties == nil {
+		tool.Schema.Properties = map[string]protocol.Property{}
+	}
+	tool.Schema.Properties["account"] = protocol.Property{
+		Type:        "string",
+		Description: "Stored account to run this call as (optional, defaults to the active account)",
+	}
+}
@@ -0,0 +1,347 @@
+package server
+
+import "github-mcp-server-go/protocol"
+
+// branchProperties returns the schema properties identifying a branch
+func branchProperties() map[string]protocol.Property {
+	properties := repoProperties()
+	properties["branch"] = protocol.Property{
+		Type:        "string",
+		Description: "Branch name",
+	}
+	return properties
+}
+
+// protectionProperties returns the schema properties of the branch
+// protection settings. Settings that are not given keep their current value.
+func protectionProperties() map[string]protocol.Property {
+	properties := branchProperties()
+	properties["required_reviews"] = protocol.Property{
+		Type:        "boolean",
+		Description: "Require pull request reviews before merging; false removes the review requirement",
+	}
+	properties["required_approving_review_count"] = protocol.Property{
+		Type:        "number",
+		Description: "Number of approvals required (0-6)",
+	}
+	properties["dismiss_stale_reviews"] = protocol.Property{
+		Type:        "boolean",
+		Description: "Dismiss approvals when new commits are pushed",
+	}
+	properties["require_code_owner_reviews"] = protocol.Property{
+		Type:        "boolean",
+		Description: "Require an approval from a code owner",
+	}
+	properties["require_last_push_approval"] = protocol.Property{
+		Type:        "boolean",
+		Description: "Require the most recent push to be approved by someone other than its author",
+	}
+	properties["required_status_checks"] = protocol.Property{
+		Type:        "array",
+		Description: "Status check contexts that must pass before merging; an empty list removes the requirement",
+	}
+	properties["strict"] = protocol.Property{
+		Type:        "boolean",
+		Description: "Require branches to be up to date before merging",
+	}
+	properties["restrict_pushes"] = protocol.Property{
+		Type:        "boolean",
+		Description: "Restrict who can push to the branch; false removes the restriction",
+	}
+	properties["push_users"] = protocol.Property{
+		Type:        "array",
+		Description: "Users allowed to push when pushes are restricted",
+	}
+	properties["push_teams"] = protocol.Property{
+		Type:        "array",
+		Description: "Team slugs allowed to push when pushes are restricted",
+	}
+	properties["push_apps"] = protocol.Property{
+		Type:        "array",
+		Description: "App slugs allowed to push when pushes are restricted",
+	}
+	properties["enforce_admins"] = protocol.Property{
+		Type:        "boolean",
+		Description: "Apply the protection to administrators too",
+	}
+	properties["required_linear_history"] = protocol.Property{
+		Type:        "boolean",
+		Description: "Prevent merge commits from being pushed",
+	}
+	properties["allow_force_pushes"] = protocol.Property{
+		Type:        "boolean",
+		Description: "Allow force pushes",
+	}
+	properties["allow_deletions"] = protocol.Property{
+		Type:        "boolean",
+		Description: "Allow the branch to be deleted",
+	}
+	properties["required_conversation_resolution"] = protocol.Property{
+		Type:        "boolean",
+		Description: "Require review conversations to be resolved before merging",
+	}
+	return properties
+}
+
+// listBranchesToolDef returns the definition for the list_branches tool
+func listBranchesToolDef() *protocol.Tool {
+	properties := paginate(repoProperties())
+	properties["protected"] = protocol.Property{
+		Type:        "boolean",
+		Description: "Only list protected branches",
+		Default:     false,
+	}
+
+	return &protocol.Tool{
+		Name:        "list_branches",
+		Description: "List the branches of a repository",
+		Schema: protocol.ToolSchema{
+			Type:       "object",
+			Properties: properties,
+			Required:   []string{"owner", "repo"},
+		},
+	}
+}
+
+// getBranchToolDef returns the definition for the get_branch tool
+func getBranchToolDef() *protocol.Tool {
+	return &protocol.Tool{
+		Name:        "get_branch",
+		Description: "Get a branch with its head commit and protection status",
+		Schema: protocol.ToolSchema{
+			Type:       "object",
+			Properties: branchProperties(),
+			Required:   []string{"owner", "repo", "branch"},
+		},
+	}
+}
+
+// createBranchToolDef returns the definition for the create_branch tool
+func createBranchToolDef() *protocol.Tool {
+	properties := branchProperties()
+	properties["from"] = protocol.Property{
+		Type:        "string",
+		Description: "Branch, tag or commit SHA to create the branch from (default: the default branch)",
+	}
+
+	return &protocol.Tool{
+		Name:        "create_branch",
+		Description: "Create a branch from another branch, a tag or a commit",
+		Schema: protocol.ToolSchema{
+			Type:       "object",
+			Properties: properties,
+			Required:   []string{"owner", "repo", "branch"},
+		},
+	}
+}
+
+// deleteBranchToolDef returns the definition for the delete_branch tool
+func deleteBranchToolDef() *protocol.Tool {
+	return &protocol.Tool{
+		Name:        "delete_branch",
+		Description: "Delete a branch. The default branch of the repository cannot be deleted.",
+		Schema: protocol.ToolSchema{
+			Type:       "object",
+			Properties: branchProperties(),
+			Required:   []string{"owner", "repo", "branch"},
+		},
+	}
+}
+
+// renameBranchToolDef returns the definition for the rename_branch tool
+func renameBranchToolDef() *protocol.Tool {
+	properties := branchProperties()
+	properties["new_name"] = protocol.Property{
+		Type:        "string",
+		Description: "New branch name",
+	}
+
+	return &protocol.Tool{
+		Name: "rename_branch",
+		Description: "Rename a branch. GitHub retargets open pull requests and moves branch protection " +
+			"to the new name.",
+		Schema: protocol.ToolSchema{
+			Type:       "object",
+			Properties: properties,
+			Required:   []string{"owner", "repo", "branch", "new_name"},
+		},
+	}
+}
+
+// getBranchProtectionToolDef returns the definition for the get_branch_protection tool
+func getBranchProtectionToolDef() *protocol.Tool {
+	return &protocol.Tool{
+		Name: "get_branch_protection",
+		Description: "Get the classic protection settings of a branch (null if unprotected) and the " +
+			"ruleset rules in force on it",
+		Schema: protocol.ToolSchema{
+			Type:       "object",
+			Properties: branchProperties(),
+			Required:   []string{"owner", "repo", "branch"},
+		},
+	}
+}
+
+// applyBranchProtectionToolDef returns the definition for the apply_branch_protection tool
+func applyBranchProtectionToolDef() *protocol.Tool {
+	properties := protectionProperties()
+	properties["dry_run"] = protocol.Property{
+		Type:        "boolean",
+		Description: "Only report the changes that would be made",
+		Default:     false,
+	}
+
+	return &protocol.Tool{
+		Name: "apply_branch_protection",
+		Description: "Protect a branch or change its protection. Settings that are not given keep their " +
+			"current value; the changes made are reported setting by setting.",
+		Schema: protocol.ToolSchema{
+			Type:       "object",
+			Properties: properties,
+			Required:   []string{"owner", "repo", "branch"},
+		},
+	}
+}
+
+// diffBranchProtectionToolDef returns the definition for the diff_branch_protection tool
+func diffBranchProtectionToolDef() *protocol.Tool {
+	properties := protectionProperties()
+	properties["template_branch"] = protocol.Property{
+		Type:        "string",
+		Description: "Compare with the protection of this branch instead of the settings given",
+	}
+	properties["template_repo"] = protocol.Property{
+		Type:        "string",
+		Description: "Repository of the template branch as owner/repo (default: the same repository)",
+	}
+
+	return &protocol.Tool{
+		Name: "diff_branch_protection",
+		Description: "Compare the protection of a branch with a template branch or with the settings " +
+			"given, without changing anything",
+		Schema: protocol.ToolSchema{
+			Type:       "object",
+			Properties: properties,
+			Required:   []string{"owner", "repo", "branch"},
+		},
+	}
+}
+
+// auditBranchProtectionToolDef returns the definition for the audit_branch_protection tool
+func auditBranchProtectionToolDef() *protocol.Tool {
+	return &protocol.Tool{
+		Name: "audit_branch_protection",
+		Description: "Audit the effective protection (classic protection and rulesets) of a branch in " +
+			"every repository of an organization against a policy",
+		Schema: protocol.ToolSchema{
+			Type: "object",
+			Properties: map[string]protocol.Property{
+				"org": {
+					Type:        "string",
+					Description: "Organization",
+				},
+				"branch": {
+					Type:        "string",
+					Description: "Branch to audit (default: the default branch of each repository)",
+				},
+				"include_archived": {
+					Type:        "boolean",
+					Description: "Audit archived repositories too",
+					Default:     false,
+				},
+				"only_issues": {
+					Type:        "boolean",
+					Description: "Only report branches that fall short of the policy",
+					Default:     false,
+				},
+				"min_approvals": {
+					Type:        "number",
+					Description: "Minimum number of required approvals",
+					Default:     1,
+				},
+				"require_status_checks": {
+					Type:        "boolean",
+					Description: "Require at least one required status check",
+					Default:     true,
+				},
+				"require_code_owner_reviews": {
+					Type:        "boolean",
+					Description: "Require code owner reviews",
+					Default:     false,
+				},
+				"require_enforce_admins": {
+					Type:        "boolean",
+					Description: "Require the protection to apply to administrators",
+					Default:     false,
+				},
+			},
+			Required: []string{"org"},
+		},
+	}
+}
+
+// listRulesetsToolDef returns the definition for the list_rulesets tool
+func listRulesetsToolDef() *protocol.Tool {
+	properties := paginate(repoProperties())
+	properties["branch"] = protocol.Property{
+		Type:        "string",
+		Description: "List the rules in force on this branch instead of the rulesets",
+	}
+
+	return &protocol.Tool{
+		Name:        "list_rulesets",
+		Description: "List the rulesets of a repository, including those inherited from its organization",
+		Schema: protocol.ToolSchema{
+			Type:       "object",
+			Properties: properties,
+			Required:   []string{"owner", "repo"},
+		},
+	}
+}
+
+// getRulesetToolDef returns the definition for the get_ruleset tool
+func getRulesetToolDef() *protocol.Tool {
+	properties := repoProperties()
+	properties["ruleset_id"] = protocol.Property{
+		Type:        "number",
+		Description: "Ruleset ID",
+	}
+
+	return &protocol.Tool{
+		Name:        "get_ruleset",
+		Description: "Get a ruleset with its conditions, bypass actors and rules",
+		Schema: protocol.ToolSchema{
+			Type:       "object",
+			Properties: properties,
+			Required:   []string{"owner", "repo", "ruleset_id"},
+		},
+	}
+}
+
+// applyRulesetToolDef returns the definition for the apply_ruleset tool
+func applyRulesetToolDef() *protocol.Tool {
+	properties := repoProperties()
+	properties["ruleset"] = protocol.Property{
+		Type: "object",
+		Description: "Ruleset as accepted by the GitHub API, e.g. " +
+			`{"name": "protect-main", "enforcement": "active", ` +
+			`"conditions": {"ref_name": {"include": ["~DEFAULT_BRANCH"], "exclude": []}}, ` +
+			`"rules": [{"type": "deletion"}, {"type": "non_fast_forward"}]}`,
+	}
+	properties["dry_run"] = protocol.Property{
+		Type:        "boolean",
+		Description: "Only report the changes that would be made",
+		Default:     false,
+	}
+
+	return &protocol.Tool{
+		Name: "apply_ruleset",
+		Description: "Create a repository ruleset, or replace the repository ruleset with the same name, " +
+			"reporting the settings and rules that change",
+		Schema: protocol.ToolSchema{
+			Type:       "object",
+			Properties: properties,
+			Required:   []string{"owner", "repo", "ruleset"},
+		},
+	}
+}
@@ -0,0 +1,160 @@
+package server
+
+import (
+	"context"
+	"fmt"
+
+	"github-mcp-server-go/github"
+	"github-mcp-server-go/protocol"
+)
+
+// registerBranchTools registers branch, branch protection and ruleset tools
+func (s *Server) registerBranchTools() {
+	s.tools["list_branches"] = s.handleListBranches
+	s.tools["get_branch"] = s.handleGetBranch
+	s.tools["create_branch"] = s.handleCreateBranch
+	s.tools["delete_branch"] = s.handleDeleteBranch
+	s.tools["rename_branch"] = s.handleRenameBranch
+
+	// Branch protection
+	s.tools["get_branch_protection"] = s.handleGetBranchProtection
+	s.tools["apply_branch_protection"] = s.handleApplyBranchProtection
+	s.tools["diff_branch_protection"] = s.handleDiffBranchProtection
+	s.tools["audit_branch_protection"] = s.handleAuditBranchProtection
+
+	// Rulesets
+	s.tools["list_rulesets"] = s.handleListRulesets
+	s.tools["get_ruleset"] = s.handleGetRuleset
+	s.tools["apply_ruleset"] = s.handleApplyRuleset
+}
+
+// handleListBranches handles the list_branches tool
+func (s *Server) handleListBranches(ctx context.Context, args map[string]interface{}) (*protocol.CallToolResult, error) {
+	owner, repo, err := requireRepo(args)
+	if err != nil {
+		return nil, err
+	}
+	listOpts, err := listOptionsArgs(args)
+	if err != nil {
+		return nil, err
+	}
+
+	opts := &github.ListBranchesOptions{Protected: optionalBool(args, "protected"), ListOptions: *listOpts}
+	branches, err := s.client.ListBranches(ctx, owner, repo, opts)
+	if err != nil {
+		return errorResult("Failed to list branches: %v", err), nil
+	}
+
+	return jsonResult(branches)
+}
+
+// handleGetBranch handles the get_branch tool
+func (s *Server) handleGetBranch(ctx context.Context, args map[string]interface{}) (*protocol.CallToolResult, error) {
+	owner, repo, err := requireRepo(args)
+	if err != nil {
+		return nil, err
+	}
+	branch, err := requireString(args, "branch")
+	if err != nil {
+		return nil, err
+	}
+
+	result, err := s.client.GetBranch(ctx, owner, repo, branch)
+	if err != nil {
+		return errorResult("Failed to get branch: %v", err), nil
+	}
+
+	return jsonResult(result)
+}
+
+// handleCreateBranch handles the create_branch tool
+func (s *Server) handleCreateBranch(ctx context.Context, args map[string]interface{}) (*protocol.CallToolResult, error) {
+	owner, repo, err := requireRepo(args)
+	if err != nil {
+		return nil, err
+	}
+	branch, err := requireString(args, "branch")
+	if err != nil {
+		return nil, err
+	}
+
+	from := optionalString(args, "from", "")
+	if from == "" {
+		repository, err := s.client.GetRepository(ctx, owner, repo)
+		if err != nil {
+			return errorResult("Failed to get repository: %v", err), nil
+		}
+		from = repository.DefaultBranch
+	}
+
+	sha, err := s.client.ResolveCommitSHA(ctx, owner, repo, from)
+	if err != nil {
+		return errorResult("Failed to resolve %s: %v", from, err), nil
+	}
+
+	if _, err := s.client.CreateBranch(ctx, owner, repo, branch, sha); err != nil {
+		return errorResult("Failed to create branch: %v", err), nil
+	}
+
+	return &protocol.CallToolResult{
+		Content: []protocol.Content{
+			protocol.TextContent(fmt.Sprintf("Branch %s created from %s at %s", branch, from, sha)),
+		},
+	}, nil
+}
+
+// handleDeleteBranch handles the delete_branch tool
+func (s *Server) handleDeleteBranch(ctx context.Context, args map[string]interface{}) (*protocol.CallToolResult, error) {
+	owner, repo, err := requireRepo(args)
+	if err != nil {
+		return nil, err
+	}
+	branch, err := requireString(args, "branch")
+	if err != nil {
+		return nil, err
+	}
+
+	repository, err := s.client.GetRepository(ctx, owner, repo)
+	if err != nil {
+		return errorResult("Failed to get repository: %v", err), nil
+	}
+	if branch == repository.DefaultBranch {
+		return errorResult("Refusing to delete %s, the default branch of %s/%s", branch, owner, repo), nil
+	}
+
+	if err := s.client.DeleteBranch(ctx, owner, repo, branch); err != nil {
+		return errorResult("Failed to delete branch: %v", err), nil
+	}
+
+	return &protocol.CallToolResult{
+		Content: []protocol.Content{
+			protocol.TextContent(fmt.Sprintf("Branch %s deleted", branch)),
+		},
+	}, nil
+}
+
+// handleRenameBranch handles the rename_branch tool
+func (s *Server) handleRenameBranch(ctx context.Context, args map[string]interface{}) (*protocol.CallToolResult, error) {
+	owner, repo, err := requireRepo(args)
+	if err != nil {
+		return nil, err
+	}
+	branch, err := requireString(args, "branch")
+	if err != nil {
+		return nil, err
+	}
+	newName, err := requireString(args, "new_name")
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err := s.client.RenameBranch(ctx, owner, repo, branch, newName); err != nil {
+		return errorResult("Failed to rename branch: %v", err), nil
+	}
+
+	return &protocol.CallToolResult{
+		Content: []protocol.Content{
+			protocol.TextContent(fmt.Sprintf("Branch %s renamed to %s", branch, newName)),
+		},
+	}, nil
+}
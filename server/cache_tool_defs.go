@@ -0,0 +1,76 @@
+package server
+
+import "github-mcp-server-go/protocol"
+
+// cacheFilterProperties adds the key prefix and ref filters for caches
+func cacheFilterProperties(properties map[string]protocol.Property) map[string]protocol.Property {
+	properties["key"] = protocol.Property{
+		Type:        "string",
+		Description: "Only match caches whose key starts with this prefix",
+	}
+	properties["ref"] = protocol.Property{
+		Type:        "string",
+		Description: "Only match caches of this Git reference, e.g. refs/heads/main or refs/pull/42/merge",
+	}
+	return properties
+}
+
+// listCachesToolDef returns the definition for the list_caches tool
+func listCachesToolDef() *protocol.Tool {
+	properties := cacheFilterProperties(paginate(repoProperties()))
+	properties["sort"] = protocol.Property{
+		Type:        "string",
+		Description: "Property to sort by",
+		Enum:        []string{"created_at", "last_accessed_at", "size_in_bytes"},
+		Default:     "last_accessed_at",
+	}
+	properties["direction"] = protocol.Property{
+		Type:        "string",
+		Description: "Sort direction",
+		Enum:        []string{"asc", "desc"},
+		Default:     "desc",
+	}
+
+	return &protocol.Tool{
+		Name:        "list_caches",
+		Description: "List the Actions caches of a repository with their keys, refs, sizes and last access",
+		Schema: protocol.ToolSchema{
+			Type:       "object",
+			Properties: properties,
+			Required:   []string{"owner", "repo"},
+		},
+	}
+}
+
+// getCacheUsageToolDef returns the definition for the get_cache_usage tool
+func getCacheUsageToolDef() *protocol.Tool {
+	return &protocol.Tool{
+		Name: "get_cache_usage",
+		Description: "Sum the count and size of the Actions caches of a repository or of each repository of an " +
+			"organization, optionally only those matching a key prefix or ref",
+		Schema: protocol.ToolSchema{
+			Type:       "object",
+			Properties: cacheFilterProperties(repoOrOrgProperties()),
+		},
+	}
+}
+
+// deleteCachesToolDef returns the definition for the delete_caches tool
+func deleteCachesToolDef() *protocol.Tool {
+	properties := cacheFilterProperties(repoOrOrgProperties())
+	properties["dry_run"] = protocol.Property{
+		Type:        "boolean",
+		Description: "List the caches that would be deleted without deleting them",
+		Default:     false,
+	}
+
+	return &protocol.Tool{
+		Name: "delete_caches",
+		Description: "Delete the Actions caches matching a key prefix and/or ref in a repository or in every " +
+			"repository of an organization, reporting the space freed",
+		Schema: protocol.ToolSchema{
+			Type:       "object",
+			Properties: properties,
+		},
+	}
+}
@@ -0,0 +1,211 @@
+package server
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github-mcp-server-go/github"
+	"github-mcp-server-go/protocol"
+)
+
+// registerCacheTools registers Actions cache tools
+func (s *Server) registerCacheTools() {
+	s.tools["list_caches"] = s.handleListCaches
+	s.tools["get_cache_usage"] = s.handleGetCacheUsage
+	s.tools["delete_caches"] = s.handleDeleteCaches
+}
+
+// repositoryCaches holds the caches of one repository matching a filter
+type repositoryCaches struct {
+	owner  string
+	repo   string
+	caches []*github.ActionsCache
+}
+
+// cacheUsageResult sums the cache usage of one or more repositories
+type cacheUsageResult struct {
+	TotalCount       int                  `json:"total_count"`
+	TotalSizeInBytes int64                `json:"total_size_in_bytes"`
+	Repositories     []*github.CacheUsage `json:"repositories"`
+}
+
+// add adds the usage of a repository to the totals
+func (r *cacheUsageResult) add(usage *github.CacheUsage) {
+	r.TotalCount += usage.ActiveCachesCount
+	r.TotalSizeInBytes += usage.ActiveCachesSizeInBytes
+	r.Repositories = append(r.Repositories, usage)
+}
+
+// deletedCache describes a cache removed by delete_caches
+type deletedCache struct {
+	Repository  string `json:"repository"`
+	ID          int64  `json:"id"`
+	Key         string `json:"key"`
+	Ref         string `json:"ref"`
+	SizeInBytes int64  `json:"size_in_bytes"`
+}
+
+// deleteCachesResult reports the outcome of delete_caches
+type deleteCachesResult struct {
+	DryRun     bool            `json:"dry_run"`
+	Deleted    int             `json:"deleted"`
+	FreedBytes int64           `json:"freed_bytes"`
+	Caches     []*deletedCache `json:"caches"`
+	Errors     []string        `json:"errors,omitempty"`
+}
+
+// matchingCaches lists the caches matching a key prefix and ref in a
+// repository or, for an organization, in each repository with active caches
+func (s *Server) matchingCaches(ctx context.Context, scope github.ActionsScope, key, ref string) ([]*repositoryCaches, error) {
+	if scope.Org == "" {
+		caches, err := s.client.ListAllCaches(ctx, scope.Owner, scope.Repo, key, ref)
+		if err != nil {
+			return nil, err
+		}
+		return []*repositoryCaches{{owner: scope.Owner, repo: scope.Repo, caches: caches}}, nil
+	}
+
+	usages, err := s.client.ListAllCacheUsageByRepository(ctx, scope.Org)
+	if err != nil {
+		return nil, err
+	}
+
+	var matches []*repositoryCaches
+	for i, usage := range usages {
+		owner, repo, ok := strings.Cut(usage.FullName, "/")
+		if !ok {
+			continue
+		}
+		reportProgress(ctx, float64(i), fmt.Sprintf("Listing caches of %s", usage.FullName))
+		caches, err := s.client.ListAllCaches(ctx, owner, repo, key, ref)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list caches of %s: %w", usage.FullName, err)
+		}
+		if len(caches) > 0 {
+			matches = append(matches, &repositoryCaches{owner: owner, repo: repo, caches: caches})
+		}
+	}
+
+	return matches, nil
+}
+
+// handleListCaches handles the list_caches tool
+func (s *Server) handleListCaches(ctx context.Context, args map[string]interface{}) (*protocol.CallToolResult, error) {
+	owner, repo, err := requireRepo(args)
+	if err != nil {
+		return nil, err
+	}
+	listOpts, err := listOptionsArgs(args)
+	if err != nil {
+		return nil, err
+	}
+
+	opts := &github.ListCachesOptions{
+		Key:         optionalString(args, "key", ""),
+		Ref:         optionalString(args, "ref", ""),
+		Sort:        optionalString(args, "sort", ""),
+		Direction:   optionalString(args, "direction", ""),
+		ListOptions: *listOpts,
+	}
+
+	caches, total, err := s.client.ListCaches(ctx, owner, repo, opts)
+	if err != nil {
+		return errorResult("Failed to list caches: %v", err), nil
+	}
+
+	return jsonResult(map[string]interface{}{
+		"total_count": total,
+		"caches":      caches,
+	})
+}
+
+// handleGetCacheUsage handles the get_cache_usage tool
+func (s *Server) handleGetCacheUsage(ctx context.Context, args map[string]interface{}) (*protocol.CallToolResult, error) {
+	scope, err := repoOrOrgArgs(args)
+	if err != nil {
+		return nil, err
+	}
+	key := optionalString(args, "key", "")
+	ref := optionalString(args, "ref", "")
+
+	result := &cacheUsageResult{Repositories: []*github.CacheUsage{}}
+
+	// Without filters GitHub has the totals already
+	if key == "" && ref == "" {
+		if scope.Org == "" {
+			usage, err := s.client.GetCacheUsage(ctx, scope.Owner, scope.Repo)
+			if err != nil {
+				return errorResult("Failed to get cache usage: %v", err), nil
+			}
+			result.add(usage)
+			return jsonResult(result)
+		}
+
+		usages, err := s.client.ListAllCacheUsageByRepository(ctx, scope.Org)
+		if err != nil {
+			return errorResult("Failed to get cache usage: %v", err), nil
+		}
+		for _, usage := range usages {
+			result.add(usage)
+		}
+		return jsonResult(result)
+	}
+
+	matches, err := s.matchingCaches(ctx, scope, key, ref)
+	if err != nil {
+		return errorResult("Failed to list caches: %v", err), nil
+	}
+	for _, match := range matches {
+		usage := &github.CacheUsage{FullName: match.owner + "/" + match.repo}
+		for _, cache := range match.caches {
+			usage.ActiveCachesCount++
+			usage.ActiveCachesSizeInBytes += cache.SizeInBytes
+		}
+		result.add(usage)
+	}
+
+	return jsonResult(result)
+}
+
+// handleDeleteCaches handles the delete_caches tool
+func (s *Server) handleDeleteCaches(ctx context.Context, args map[string]interface{}) (*protocol.CallToolResult, error) {
+	scope, err := repoOrOrgArgs(args)
+	if err != nil {
+		return nil, err
+	}
+	key := optionalString(args, "key", "")
+	ref := optionalString(args, "ref", "")
+	if key == "" && ref == "" {
+		return nil, fmt.Errorf("key or ref is required")
+	}
+
+	matches, err := s.matchingCaches(ctx, scope, key, ref)
+	if err != nil {
+		return errorResult("Failed to list caches: %v", err), nil
+	}
+
+	result := &deleteCachesResult{DryRun: optionalBool(args, "dry_run"), Caches: []*deletedCache{}}
+	for _, match := range matches {
+		fullName := match.owner + "/" + match.repo
+		for _, cache := range match.caches {
+			if !result.DryRun {
+				if err := s.client.DeleteCache(ctx, match.owner, match.repo, cache.ID); err != nil {
+					result.Errors = append(result.Errors, fmt.Sprintf("%s cache %d: %v", fullName, cache.ID, err))
+					continue
+				}
+			}
+			result.Deleted++
+			result.FreedBytes += cache.SizeInBytes
+			result.Caches = append(result.Caches, &deletedCache{
+				Repository:  fullName,
+				ID:          cache.ID,
+				Key:         cache.Key,
+				Ref:         cache.Ref,
+				SizeInBytes: cache.SizeInBytes,
+			})
+		}
+	}
+
+	return jsonResult(result)
+}
@@ -0,0 +1,107 @@
+package server
+
+import (
+	"github-mcp-server-go/diff"
+	"github-mcp-server-go/protocol"
+)
+
+// commitChangesToolDef returns the definition for the commit_changes tool
+func commitChangesToolDef() *protocol.Tool {
+	properties := repoProperties()
+	properties["branch"] = protocol.Property{
+		Type:        "string",
+		Description: "Branch to commit to",
+	}
+	properties["message"] = protocol.Property{
+		Type:        "string",
+		Description: "Commit message",
+	}
+	properties["files"] = protocol.Property{
+		Type: "array",
+		Description: "Files to change, each an object with path and either content (text), content_base64 " +
+			"(binary), delete: true, or previous_path to rename a file (content optional). mode can be " +
+			"file, executable or symlink and defaults to the existing file's mode.",
+	}
+	properties["expected_head_sha"] = protocol.Property{
+		Type:        "string",
+		Description: "Commit the branch must still point at; nothing is committed if it moved",
+	}
+	properties["from"] = protocol.Property{
+		Type:        "string",
+		Description: "Create the branch from this branch, tag or commit if it does not exist",
+	}
+	properties["author_name"] = protocol.Property{
+		Type:        "string",
+		Description: "Commit author name (default: the authenticated user)",
+	}
+	properties["author_email"] = protocol.Property{
+		Type:        "string",
+		Description: "Commit author email, required with author_name",
+	}
+	properties["skip_lint"] = protocol.Property{
+		Type:        "boolean",
+		Description: "Commit workflow files even if lint_workflow reports errors (when workflow linting is enabled)",
+	}
+
+	return &protocol.Tool{
+		Name: "commit_changes",
+		Description: "Add, modify, delete and rename many files in a single commit on a branch. The commit " +
+			"only fast-forwards the branch, so it fails instead of overwriting concurrent pushes.",
+		Schema: protocol.ToolSchema{
+			Type:       "object",
+			Properties: properties,
+			Required:   []string{"owner", "repo", "branch", "message", "files"},
+		},
+	}
+}
+
+// editFileToolDef returns the definition for the edit_file tool
+func editFileToolDef() *protocol.Tool {
+	properties := repoProperties()
+	properties["path"] = protocol.Property{
+		Type:        "string",
+		Description: "File path in the repository",
+	}
+	properties["message"] = protocol.Property{
+		Type:        "string",
+		Description: "Commit message",
+	}
+	properties["branch"] = protocol.Property{
+		Type:        "string",
+		Description: "Branch to edit the file on (default: the default branch)",
+	}
+	properties["patch"] = protocol.Property{
+		Type:        "string",
+		Description: "Unified diff of the file; bare @@ hunks without file headers are accepted",
+	}
+	properties["edits"] = protocol.Property{
+		Type: "array",
+		Description: "Search and replace edits applied in order, each an object with old_text, new_text " +
+			"and optionally replace_all. old_text must occur exactly once unless replace_all is set.",
+	}
+	properties["fuzz"] = protocol.Property{
+		Type:        "number",
+		Description: "Number of context lines a hunk may ignore at each end when it does not match exactly",
+		Default:     diff.DefaultFuzz,
+	}
+	properties["expected_head_sha"] = protocol.Property{
+		Type:        "string",
+		Description: "Commit the branch must still point at; nothing is committed if it moved",
+	}
+	properties["skip_lint"] = protocol.Property{
+		Type:        "boolean",
+		Description: "Commit a workflow file even if lint_workflow reports errors (when workflow linting is enabled)",
+	}
+
+	return &protocol.Tool{
+		Name: "edit_file",
+		Description: "Change part of a file with a unified diff or search and replace edits, applied to the " +
+			"current content of the branch and committed. Hunks that do not apply are reported as conflicts " +
+			"and nothing is committed.",
+		Schema: protocol.ToolSchema{
+			Type:       "object",
+			Properties: properties,
+			Required:   []string{"owner", "repo", "path", "message"},
+		},
+	}
+}
@@ -0,0 +1,294 @@
+package server
+
+import (
+	"context"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github-mcp-server-go/diff"
+	"github-mcp-server-go/github"
+	"github-mcp-server-go/protocol"
+)
+
+// fileChangeArg is one entry of the files argument of commit_changes
+type fileChangeArg struct {
+	Path          string  `json:"path"`
+	Content       *string `json:"content"`
+	ContentBase64 *string `json:"content_base64"`
+	Delete        bool    `json:"delete"`
+	PreviousPath  string  `json:"previous_path"`
+	Mode          string  `json:"mode"`
+}
+
+// fileModes maps the mode argument of commit_changes to git file modes
+var fileModes = map[string]string{
+	"file":       github.ModeFile,
+	"executable": github.ModeExecutable,
+	"symlink":    github.ModeSymlink,
+}
+
+// fileChangesArg parses the files argument of commit_changes
+func fileChangesArg(args map[string]interface{}) ([]*github.FileChange, error) {
+	if _, ok := args["files"].([]interface{}); !ok {
+		return nil, fmt.Errorf("files is required and must be an array")
+	}
+	var files []*fileChangeArg
+	if err := parseParams(args["files"], &files); err != nil {
+		return nil, fmt.Errorf("invalid files: %w", err)
+	}
+	if len(files) == 0 {
+		return nil, fmt.Errorf("files must not be empty")
+	}
+
+	changes := make([]*github.FileChange, 0, len(files))
+	for _, file := range files {
+		if file.Path == "" {
+			return nil, fmt.Errorf("every file needs a path")
+		}
+		change := &github.FileChange{Path: file.Path, Delete: file.Delete, PreviousPath: file.PreviousPath}
+
+		if file.Mode != "" {
+			mode, ok := fileModes[file.Mode]
+			if !ok {
+				return nil, fmt.Errorf("%s: mode must be file, executable or symlink", file.Path)
+			}
+			change.Mode = mode
+		}
+
+		switch {
+		case file.Content != nil && file.ContentBase64 != nil:
+			return nil, fmt.Errorf("%s: give content or content_base64, not both", file.Path)
+		case file.Content != nil:
+			change.Content = []byte(*file.Content)
+		case file.ContentBase64 != nil:
+			content, err := base64.StdEncoding.DecodeString(*file.ContentBase64)
+			if err != nil {
+				return nil, fmt.Errorf("%s: invalid content_base64: %w", file.Path, err)
+			}
+			change.Content = content
+		}
+
+		if change.Delete && (change.Content != nil || change.PreviousPath != "") {
+			return nil, fmt.Errorf("%s: a deleted file takes no content or previous_path", file.Path)
+		}
+		if !change.Delete && change.Content == nil && change.PreviousPath == "" {
+			return nil, fmt.Errorf("%s: content or content_base64 is required", file.Path)
+		}
+		changes = append(changes, change)
+	}
+
+	return changes, nil
+}
+
+// handleCommitChanges handles the commit_changes tool
+func (s *Server) handleCommitChanges(ctx context.Context, args map[string]interface{}) (*protocol.CallToolResult, error) {
+	owner, repo, err := requireRepo(args)
+	if err != nil {
+		return nil, err
+	}
+	branch, err := requireString(args, "branch")
+	if err != nil {
+		return nil, err
+	}
+	message, err := requireString(args, "message")
+	if err != nil {
+		return nil, err
+	}
+	changes, err := fileChangesArg(args)
+	if err != nil {
+		return nil, err
+	}
+
+	// Refuse broken workflow files
+	for _, change := range changes {
+		if change.Content == nil {
+			continue
+		}
+		if result := s.checkWorkflowFile(args, change.Path, string(change.Content)); result != nil {
+			return result, nil
+		}
+	}
+
+	opts := &github.CommitChangesOptions{
+		Branch:          branch,
+		Message:         message,
+		Changes:         changes,
+		ExpectedHeadSHA: optionalString(args, "expected_head_sha", ""),
+		From:            optionalString(args, "from", ""),
+	}
+	if name := optionalString(args, "author_name", ""); name != "" {
+		email, err := requireString(args, "author_email")
+		if err != nil {
+			return nil, err
+		}
+		opts.Author = &github.CommitAuthor{Name: name, Email: email}
+	}
+
+	commit, err := s.client.CommitChanges(ctx, owner, repo, opts)
+	if err != nil {
+		var stale *github.StaleHeadError
+		if errors.As(err, &stale) {
+			return errorResult("Nothing was committed: %v. Check the latest changes on %s and retry.", err, branch), nil
+		}
+		return errorResult("Failed to commit changes: %v", err), nil
+	}
+
+	return jsonResult(map[string]interface{}{
+		"sha":      commit.SHA,
+		"html_url": commit.HTMLURL,
+		"branch":   branch,
+		"parent":   parentSHA(commit),
+		"files":    len(changes),
+	})
+}
+
+// parentSHA returns the first parent of a commit
+func parentSHA(commit *github.GitCommit) string {
+	if len(commit.Parents) == 0 {
+		return ""
+	}
+	return commit.Parents[0].SHA
+}
+
+// patchFile parses a unified diff of one file. A diff of bare hunks is
+// taken to change path.
+func patchFile(patch, path string) (*diff.File, error) {
+	if strings.HasPrefix(strings.TrimLeft(patch, "\n"), "@@ ") {
+		patch = fmt.Sprintf("--- a/%s\n+++ b/%s\n%s", path, path, patch)
+	}
+	files, err := diff.Parse(patch)
+	if err != nil {
+		return nil, fmt.Errorf("invalid patch: %w", err)
+	}
+
+	for _, file := range files {
+		if file.Path() != path && file.OldPath != path {
+			continue
+		}
+		if file.Status != diff.StatusModified || file.Binary {
+			return nil, fmt.Errorf("the patch %s %s; edit_file only changes the text of existing files", file.Status, path)
+		}
+		return file, nil
+	}
+	return nil, fmt.Errorf("the patch does not change %s", path)
+}
+
+// handleEditFile handles the edit_file tool
+func (s *Server) handleEditFile(ctx context.Context, args map[string]interface{}) (*protocol.CallToolResult, error) {
+	owner, repo, err := requireRepo(args)
+	if err != nil {
+		return nil, err
+	}
+	path, err := requireString(args, "path")
+	if err != nil {
+		return nil, err
+	}
+	message, err := requireString(args, "message")
+	if err != nil {
+		return nil, err
+	}
+	fuzz, err := optionalInt(args, "fuzz", diff.DefaultFuzz)
+	if err != nil {
+		return nil, err
+	}
+	patch := optionalString(args, "patch", "")
+	var edits []*diff.Edit
+	if raw, ok := args["edits"]; ok {
+		if err := parseParams(raw, &edits); err != nil {
+			return nil, fmt.Errorf("invalid edits: %w", err)
+		}
+	}
+	if (patch == "") == (len(edits) == 0) {
+		return nil, fmt.Errorf("give either patch or edits")
+	}
+
+	var file *diff.File
+	if patch != "" {
+		if file, err = patchFile(patch, path); err != nil {
+			return nil, err
+		}
+	}
+
+	branch := optionalString(args, "branch", "")
+	if branch == "" {
+		repository, err := s.client.GetRepository(ctx, owner, repo)
+		if err != nil {
+			return errorResult("Failed to get repository: %v", err), nil
+		}
+		branch = repository.DefaultBranch
+	}
+
+	// Read the file at the current head of the branch
+	ref, err := s.client.GetRef(ctx, owner, repo, "heads/"+branch)
+	if err != nil {
+		return errorResult("Failed to get branch %s: %v", branch, err), nil
+	}
+	head := ref.Object.SHA
+	if expected := optionalString(args, "expected_head_sha", ""); expected != "" && expected != head {
+		return errorResult("Nothing was committed: %v", &github.StaleHeadError{Branch: branch, Expected: expected, Actual: head}), nil
+	}
+
+	current, err := s.client.GetContent(ctx, owner, repo, path, head)
+	if err != nil {
+		return errorResult("Failed to get %s: %v", path, err), nil
+	}
+	data := []byte(current.Content)
+	if current.Encoding != "base64" {
+		if data, err = s.client.GetRawContent(ctx, owner, repo, path, head, 0, 0); err != nil {
+			return errorResult("Failed to get %s: %v", path, err), nil
+		}
+	}
+	if _, binary := github.DetectContentType(path, data); binary {
+		return errorResult("%s is a binary file; use commit_changes to replace it", path), nil
+	}
+
+	// Apply the changes
+	var content string
+	var applied []*diff.AppliedHunk
+	if file != nil {
+		content, applied, err = file.Apply(string(data), int(fuzz))
+	} else {
+		content, err = diff.ApplyEdits(string(data), edits)
+	}
+	if err != nil {
+		return errorResult("Nothing was committed: %v", err), nil
+	}
+	if content == string(data) {
+		return errorResult("The changes leave %s unchanged", path), nil
+	}
+
+	// Refuse broken workflow files
+	if result := s.checkWorkflowFile(args, path, content); result != nil {
+		return result, nil
+	}
+
+	commit, err := s.client.CommitChanges(ctx, owner, repo, &github.CommitChangesOptions{
+		Branch:          branch,
+		Message:         message,
+		Changes:         []*github.FileChange{{Path: path, Content: []byte(content)}},
+		ExpectedHeadSHA: head,
+	})
+	if err != nil {
+		var stale *github.StaleHeadError
+		if errors.As(err, &stale) {
+			return errorResult("Nothing was committed: %v. Retry to apply the changes to the latest %s.", err, path), nil
+		}
+		return errorResult("Failed to commit changes: %v", err), nil
+	}
+
+	result := map[string]interface{}{
+		"sha":      commit.SHA,
+		"html_url": commit.HTMLURL,
+		"branch":   branch,
+		"parent":   head,
+		"path":     path,
+	}
+	if file != nil {
+		result["hunks"] = applied
+	} else {
+		result["edits"] = len(edits)
+	}
+	return jsonResult(result)
+}
@@ -0,0 +1,163 @@
+package server
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github-mcp-server-go/github"
+	"github-mcp-server-go/protocol"
+)
+
+// defaultMaxFileBytes bounds the bytes get_file_content returns when no
+// byte range is given
+const defaultMaxFileBytes = 1 << 20
+
+// fileContentResult describes the file, or part of a file, returned by
+// get_file_content
+type fileContentResult struct {
+	Name     string `json:"name"`
+	Path     string `json:"path"`
+	SHA      string `json:"sha"`
+	Size     int    `json:"size"`
+	Type     string `json:"type"`
+	MimeType string `json:"mime_type"`
+	Binary   bool   `json:"binary,omitempty"`
+
+	// Offset and Length give the bytes returned when they are not the
+	// whole file
+	Offset    int64 `json:"offset,omitempty"`
+	Length    int64 `json:"length,omitempty"`
+	Truncated bool  `json:"truncated,omitempty"`
+
+	StartLine  int `json:"start_line,omitempty"`
+	EndLine    int `json:"end_line,omitempty"`
+	TotalLines int `json:"total_lines,omitempty"`
+
+	Content *string `json:"content,omitempty"`
+}
+
+// fileContentResult reads the byte or line range of a file asked for in
+// args. Binary files are returned as an embedded resource.
+func (s *Server) fileContentResult(ctx context.Context, owner, repo, ref string, file *github.FileContent, args map[string]interface{}) (*protocol.CallToolResult, error) {
+	offset, err := optionalInt(args, "offset", 0)
+	if err != nil {
+		return nil, err
+	}
+	length, err := optionalInt(args, "length", 0)
+	if err != nil {
+		return nil, err
+	}
+	startLine, err := optionalInt(args, "start_line", 0)
+	if err != nil {
+		return nil, err
+	}
+	endLine, err := optionalInt(args, "end_line", 0)
+	if err != nil {
+		return nil, err
+	}
+	maxBytes, err := optionalInt(args, "max_bytes", defaultMaxFileBytes)
+	if err != nil {
+		return nil, err
+	}
+	if offset < 0 || length < 0 || startLine < 0 || endLine < 0 || maxBytes < 1 {
+		return nil, fmt.Errorf("offset, length, start_line, end_line and max_bytes must not be negative")
+	}
+	lines := startLine > 0 || endLine > 0
+	if lines && (offset > 0 || length > 0) {
+		return nil, fmt.Errorf("give a byte range (offset, length) or a line range (start_line, end_line), not both")
+	}
+
+	// Line ranges need the whole file; byte ranges are capped at max_bytes
+	if !lines && (length == 0 || length > maxBytes) {
+		length = maxBytes
+	}
+	inline := file.Encoding == "base64"
+	var data []byte
+	switch {
+	case inline:
+		data = []byte(file.Content)
+		if !lines {
+			data = byteRange(data, offset, length)
+		}
+	case lines:
+		data, err = s.client.GetRawContent(ctx, owner, repo, file.Path, ref, 0, 0)
+	default:
+		data, err = s.client.GetRawContent(ctx, owner, repo, file.Path, ref, offset, length)
+	}
+	if err != nil {
+		return errorResult("Failed to get file content: %v", err), nil
+	}
+
+	result := &fileContentResult{
+		Name: file.Name,
+		Path: file.Path,
+		SHA:  file.SHA,
+		Size: file.Size,
+		Type: file.Type,
+	}
+	result.MimeType, result.Binary = github.DetectContentType(file.Name, data)
+	if !lines && (offset > 0 || offset+int64(len(data)) < int64(file.Size)) {
+		result.Offset = offset
+		result.Length = int64(len(data))
+		result.Truncated = offset+int64(len(data)) < int64(file.Size)
+	}
+
+	if result.Binary {
+		if lines {
+			return errorResult("%s is a binary file; use offset and length instead of a line range", file.Path), nil
+		}
+		uri := file.DownloadURL
+		if uri == "" {
+			uri = file.HTMLURL
+		}
+		meta, err := jsonResult(result)
+		if err != nil {
+			return nil, err
+		}
+		meta.Content = append(meta.Content, protocol.BlobResourceContent(uri, result.MimeType, data))
+		return meta, nil
+	}
+
+	text := string(data)
+	if lines {
+		text, result.StartLine, result.EndLine, result.TotalLines = lineRange(text, int(startLine), int(endLine))
+	}
+	result.Content = &text
+
+	return jsonResult(result)
+}
+
+// byteRange returns length bytes of data starting at offset
+func byteRange(data []byte, offset, length int64) []byte {
+	if offset >= int64(len(data)) {
+		return nil
+	}
+	data = data[offset:]
+	if length < int64(len(data)) {
+		data = data[:length]
+	}
+	return data
+}
+
+// lineRange returns lines start to end (1-based, inclusive; 0 for the
+// first or last line) of text, with the range actually returned and the
+// number of lines
+func lineRange(text string, start, end int) (string, int, int, int) {
+	lines := strings.SplitAfter(text, "\n")
+	if lines[len(lines)-1] == "" {
+		lines = lines[:len(lines)-1]
+	}
+	total := len(lines)
+
+	if start < 1 {
+		start = 1
+	}
+	if end < 1 || end > total {
+		end = total
+	}
+	if start > end {
+		return "", start, end, total
+	}
+	return strings.Join(lines[start-1:end], ""), start, end, total
+}
@@ -0,0 +1,40 @@
+package server
+
+import (
+	"context"
+
+	"github-mcp-server-go/protocol"
+)
+
+// progressFunc reports the progress of a long-running tool call
+type progressFunc func(progress float64, message string)
+
+// progressKey is the context key of the call's progressFunc
+type progressKey struct{}
+
+// withProgress returns a context whose tool call reports progress to fn
+func withProgress(ctx context.Context, fn progressFunc) context.Context {
+	return context.WithValue(ctx, progressKey{}, fn)
+}
+
+// reportProgress reports progress if the client asked for it
+func reportProgress(ctx context.Context, progress float64, message string) {
+	if fn, ok := ctx.Value(progressKey{}).(progressFunc); ok {
+		fn(progress, message)
+	}
+}
+
+// progressNotifier sends notifications/progress messages with the token
+// the client gave in the request's _meta
+func (s *Server) progressNotifier(ctx context.Context, token interface{}) progressFunc {
+	return func(progress float64, message string) {
+		notification := protocol.NewNotification("notifications/progress", protocol.ProgressNotificationParams{
+			ProgressToken: token,
+			Progress:      progress,
+			Message:       message,
+		})
+		if err := s.sendResponse(ctx, s.transport, nil, notification); err != nil && s.config.Logger != nil {
+			s.config.Logger.Printf("Error sending progress notification: %v", err)
+		}
+	}
+}
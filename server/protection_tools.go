@@ -0,0 +1,424 @@
+package server
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github-mcp-server-go/github"
+	"github-mcp-server-go/protocol"
+)
+
+// protectionResult reports the outcome of apply_branch_protection and
+// apply_ruleset
+type protectionResult struct {
+	Applied bool                       `json:"applied"`
+	Changes []*github.ProtectionChange `json:"changes"`
+	Result  interface{}                `json:"result,omitempty"`
+}
+
+// branchProtection gets the protection of a branch, or nil if the branch
+// is not protected
+func (s *Server) branchProtection(ctx context.Context, owner, repo, branch string) (*github.BranchProtection, error) {
+	protection, err := s.client.GetBranchProtection(ctx, owner, repo, branch)
+	if github.IsStatus(err, http.StatusNotFound) {
+		return nil, nil
+	}
+	return protection, err
+}
+
+// setBool sets target to a boolean argument if it is given
+func setBool(args map[string]interface{}, name string, target *bool) bool {
+	value, ok := args[name].(bool)
+	if ok {
+		*target = value
+	}
+	return ok
+}
+
+// protectionArgs applies the protection settings given as arguments to a
+// copy of base, which may be nil for an unprotected branch
+func protectionArgs(args map[string]interface{}, base *github.BranchProtection) (*github.BranchProtection, error) {
+	protection := &github.BranchProtection{}
+	if base != nil {
+		if err := parseParams(base, protection); err != nil {
+			return nil, err
+		}
+	}
+
+	// Required reviews: any review setting enables them, false removes them
+	reviews := protection.RequiredReviews
+	if reviews == nil {
+		reviews = &github.RequiredReviews{RequiredApprovingReviewCount: 1}
+	}
+	changed := false
+	if _, ok := args["required_approving_review_count"]; ok {
+		count, err := parseInt(args, "required_approving_review_count")
+		if err != nil {
+			return nil, err
+		}
+		reviews.RequiredApprovingReviewCount = int(count)
+		changed = true
+	}
+	changed = setBool(args, "dismiss_stale_reviews", &reviews.DismissStaleReviews) || changed
+	changed = setBool(args, "require_code_owner_reviews", &reviews.RequireCodeOwnerReviews) || changed
+	changed = setBool(args, "require_last_push_approval", &reviews.RequireLastPushApproval) || changed
+	if changed {
+		protection.RequiredReviews = reviews
+	}
+	if enabled, ok := args["required_reviews"].(bool); ok {
+		if !enabled {
+			protection.RequiredReviews = nil
+		} else if protection.RequiredReviews == nil {
+			protection.RequiredReviews = reviews
+		}
+	}
+
+	// Status checks: an empty list removes them
+	if _, ok := args["required_status_checks"]; ok {
+		contexts, err := optionalStrings(args, "required_status_checks")
+		if err != nil {
+			return nil, err
+		}
+		if len(contexts) == 0 {
+			protection.RequiredStatusChecks = nil
+		} else {
+			checks := &github.RequiredStatusChecks{Contexts: contexts}
+			if current := protection.RequiredStatusChecks; current != nil {
+				// Checks that are kept stay pinned to their app
+				checks.Strict = current.Strict
+				for _, check := range current.Checks {
+					for _, context := range contexts {
+						if check.Context == context {
+							checks.Checks = append(checks.Checks, check)
+						}
+					}
+				}
+			}
+			protection.RequiredStatusChecks = checks
+		}
+	}
+	if protection.RequiredStatusChecks != nil {
+		setBool(args, "strict", &protection.RequiredStatusChecks.Strict)
+	}
+
+	// Push restrictions: any list enables them, false removes them
+	restrictions := protection.Restrictions
+	if restrictions == nil {
+		restrictions = &github.PushRestrictions{Users: []string{}, Teams: []string{}, Apps: []string{}}
+	}
+	changed = false
+	for name, target := range map[string]*[]string{
+		"push_users": &restrictions.Users,
+		"push_teams": &restrictions.Teams,
+		"push_apps":  &restrictions.Apps,
+	} {
+		if _, ok := args[name]; !ok {
+			continue
+		}
+		values, err := optionalStrings(args, name)
+		if err != nil {
+			return nil, err
+		}
+		if values == nil {
+			values = []string{}
+		}
+		*target = values
+		changed = true
+	}
+	if changed {
+		protection.Restrictions = restrictions
+	}
+	if enabled, ok := args["restrict_pushes"].(bool); ok {
+		if !enabled {
+			protection.Restrictions = nil
+		} else if protection.Restrictions == nil {
+			protection.Restrictions = restrictions
+		}
+	}
+
+	setBool(args, "enforce_admins", &protection.EnforceAdmins)
+	setBool(args, "required_linear_history", &protection.RequireLinearHistory)
+	setBool(args, "allow_force_pushes", &protection.AllowForcePushes)
+	setBool(args, "allow_deletions", &protection.AllowDeletions)
+	setBool(args, "required_conversation_resolution", &protection.RequireConversationResolution)
+
+	return protection, nil
+}
+
+// handleGetBranchProtection handles the get_branch_protection tool
+func (s *Server) handleGetBranchProtection(ctx context.Context, args map[string]interface{}) (*protocol.CallToolResult, error) {
+	owner, repo, err := requireRepo(args)
+	if err != nil {
+		return nil, err
+	}
+	branch, err := requireString(args, "branch")
+	if err != nil {
+		return nil, err
+	}
+
+	protection, err := s.branchProtection(ctx, owner, repo, branch)
+	if err != nil {
+		return errorResult("Failed to get branch protection: %v", err), nil
+	}
+
+	rules, err := s.client.GetBranchRules(ctx, owner, repo, branch)
+	if err != nil {
+		return errorResult("Failed to get ruleset rules: %v", err), nil
+	}
+
+	return jsonResult(map[string]interface{}{
+		"protection":    protection,
+		"ruleset_rules": rules,
+	})
+}
+
+// handleApplyBranchProtection handles the apply_branch_protection tool.
+// Settings that are not given keep their current values.
+func (s *Server) handleApplyBranchProtection(ctx context.Context, args map[string]interface{}) (*protocol.CallToolResult, error) {
+	owner, repo, err := requireRepo(args)
+	if err != nil {
+		return nil, err
+	}
+	branch, err := requireString(args, "branch")
+	if err != nil {
+		return nil, err
+	}
+
+	current, err := s.branchProtection(ctx, owner, repo, branch)
+	if err != nil {
+		return errorResult("Failed to get branch protection: %v", err), nil
+	}
+	desired, err := protectionArgs(args, current)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &protectionResult{Changes: github.DiffBranchProtection(current, desired)}
+	if optionalBool(args, "dry_run") || len(result.Changes) == 0 {
+		return jsonResult(result)
+	}
+
+	updated, err := s.client.UpdateBranchProtection(ctx, owner, repo, branch, desired)
+	if err != nil {
+		return errorResult("Failed to update branch protection: %v", err), nil
+	}
+	result.Applied = true
+	result.Result = updated
+
+	return jsonResult(result)
+}
+
+// handleDiffBranchProtection handles the diff_branch_protection tool. The
+// branch is compared with a template branch, or with its own protection
+// changed by the settings given.
+func (s *Server) handleDiffBranchProtection(ctx context.Context, args map[string]interface{}) (*protocol.CallToolResult, error) {
+	owner, repo, err := requireRepo(args)
+	if err != nil {
+		return nil, err
+	}
+	branch, err := requireString(args, "branch")
+	if err != nil {
+		return nil, err
+	}
+
+	current, err := s.branchProtection(ctx, owner, repo, branch)
+	if err != nil {
+		return errorResult("Failed to get branch protection: %v", err), nil
+	}
+
+	var desired *github.BranchProtection
+	if templateBranch := optionalString(args, "template_branch", ""); templateBranch != "" {
+		templateOwner, templateRepo := owner, repo
+		if template := optionalString(args, "template_repo", ""); template != "" {
+			var ok bool
+			templateOwner, templateRepo, ok = strings.Cut(template, "/")
+			if !ok {
+				return nil, fmt.Errorf("template_repo must be owner/repo")
+			}
+		}
+		desired, err = s.branchProtection(ctx, templateOwner, templateRepo, templateBranch)
+		if err != nil {
+			return errorResult("Failed to get template branch protection: %v", err), nil
+		}
+	} else {
+		desired, err = protectionArgs(args, current)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return jsonResult(github.DiffBranchProtection(current, desired))
+}
+
+// branchAuditResult summarizes audit_branch_protection
+type branchAuditResult struct {
+	Org        string                `json:"org"`
+	Audited    int                   `json:"audited"`
+	WithIssues int                   `json:"with_issues"`
+	Branches   []*github.BranchAudit `json:"branches"`
+}
+
+// handleAuditBranchProtection handles the audit_branch_protection tool
+func (s *Server) handleAuditBranchProtection(ctx context.Context, args map[string]interface{}) (*protocol.CallToolResult, error) {
+	org, err := requireString(args, "org")
+	if err != nil {
+		return nil, err
+	}
+	minApprovals, err := optionalInt(args, "min_approvals", 1)
+	if err != nil {
+		return nil, err
+	}
+	policy := &github.ProtectionPolicy{
+		MinApprovals:            int(minApprovals),
+		RequireStatusChecks:     true,
+		RequireCodeOwnerReviews: optionalBool(args, "require_code_owner_reviews"),
+		RequireEnforceAdmins:    optionalBool(args, "require_enforce_admins"),
+	}
+	setBool(args, "require_status_checks", &policy.RequireStatusChecks)
+
+	repos, err := s.client.ListAllOrganizationRepositories(ctx, org)
+	if err != nil {
+		return errorResult("Failed to list repositories: %v", err), nil
+	}
+
+	result := &branchAuditResult{Org: org, Branches: []*github.BranchAudit{}}
+	for i, repository := range repos {
+		if repository.Archived && !optionalBool(args, "include_archived") {
+			continue
+		}
+		branch := optionalString(args, "branch", repository.DefaultBranch)
+		owner, repo, _ := strings.Cut(repository.FullName, "/")
+		reportProgress(ctx, float64(i), fmt.Sprintf("Auditing %s (%d of %d)", repository.FullName, i+1, len(repos)))
+
+		audit := s.auditBranch(ctx, owner, repo, branch, policy)
+		result.Audited++
+		if len(audit.Issues) > 0 || audit.Error != "" {
+			result.WithIssues++
+		} else if optionalBool(args, "only_issues") {
+			continue
+		}
+		result.Branches = append(result.Branches, audit)
+	}
+
+	return jsonResult(result)
+}
+
+// auditBranch audits the protection of one branch, recording errors in the
+// audit rather than failing the whole run
+func (s *Server) auditBranch(ctx context.Context, owner, repo, branch string, policy *github.ProtectionPolicy) *github.BranchAudit {
+	fullName := owner + "/" + repo
+	protection, err := s.branchProtection(ctx, owner, repo, branch)
+	if err != nil {
+		return &github.BranchAudit{Repository: fullName, Branch: branch, Issues: []string{}, Error: err.Error()}
+	}
+	rules, err := s.client.GetBranchRules(ctx, owner, repo, branch)
+	if err != nil {
+		return &github.BranchAudit{Repository: fullName, Branch: branch, Issues: []string{}, Error: err.Error()}
+	}
+	return github.AuditBranchProtection(fullName, branch, protection, rules, policy)
+}
+
+// handleListRulesets handles the list_rulesets tool
+func (s *Server) handleListRulesets(ctx context.Context, args map[string]interface{}) (*protocol.CallToolResult, error) {
+	owner, repo, err := requireRepo(args)
+	if err != nil {
+		return nil, err
+	}
+
+	if branch := optionalString(args, "branch", ""); branch != "" {
+		rules, err := s.client.GetBranchRules(ctx, owner, repo, branch)
+		if err != nil {
+			return errorResult("Failed to get ruleset rules: %v", err), nil
+		}
+		return jsonResult(rules)
+	}
+
+	opts, err := listOptionsArgs(args)
+	if err != nil {
+		return nil, err
+	}
+	rulesets, err := s.client.ListRulesets(ctx, owner, repo, opts)
+	if err != nil {
+		return errorResult("Failed to list rulesets: %v", err), nil
+	}
+
+	return jsonResult(rulesets)
+}
+
+// handleGetRuleset handles the get_ruleset tool
+func (s *Server) handleGetRuleset(ctx context.Context, args map[string]interface{}) (*protocol.CallToolResult, error) {
+	owner, repo, err := requireRepo(args)
+	if err != nil {
+		return nil, err
+	}
+	rulesetID, err := requireInt(args, "ruleset_id")
+	if err != nil {
+		return nil, err
+	}
+
+	ruleset, err := s.client.GetRuleset(ctx, owner, repo, rulesetID)
+	if err != nil {
+		return errorResult("Failed to get ruleset: %v", err), nil
+	}
+
+	return jsonResult(ruleset)
+}
+
+// handleApplyRuleset handles the apply_ruleset tool, creating the ruleset
+// or replacing the repository ruleset with the same name
+func (s *Server) handleApplyRuleset(ctx context.Context, args map[string]interface{}) (*protocol.CallToolResult, error) {
+	owner, repo, err := requireRepo(args)
+	if err != nil {
+		return nil, err
+	}
+	if _, ok := args["ruleset"].(map[string]interface{}); !ok {
+		return nil, fmt.Errorf("ruleset is required and must be an object")
+	}
+	var desired github.Ruleset
+	if err := parseParams(args["ruleset"], &desired); err != nil {
+		return nil, fmt.Errorf("invalid ruleset: %w", err)
+	}
+	if desired.Name == "" {
+		return nil, fmt.Errorf("ruleset name is required")
+	}
+	if desired.Enforcement == "" {
+		desired.Enforcement = "active"
+	}
+	desired.ID, desired.SourceType, desired.Source = 0, "", ""
+
+	rulesets, err := s.client.ListRulesets(ctx, owner, repo, &github.ListOptions{PerPage: 100})
+	if err != nil {
+		return errorResult("Failed to list rulesets: %v", err), nil
+	}
+	var current *github.Ruleset
+	for _, ruleset := range rulesets {
+		if ruleset.Name == desired.Name && ruleset.SourceType == "Repository" {
+			current, err = s.client.GetRuleset(ctx, owner, repo, ruleset.ID)
+			if err != nil {
+				return errorResult("Failed to get ruleset: %v", err), nil
+			}
+			break
+		}
+	}
+
+	result := &protectionResult{Changes: github.DiffRulesets(current, &desired)}
+	if optionalBool(args, "dry_run") || len(result.Changes) == 0 {
+		return jsonResult(result)
+	}
+
+	var applied *github.Ruleset
+	if current != nil {
+		applied, err = s.client.UpdateRuleset(ctx, owner, repo, current.ID, &desired)
+	} else {
+		applied, err = s.client.CreateRuleset(ctx, owner, repo, &desired)
+	}
+	if err != nil {
+		return errorResult("Failed to apply ruleset: %v", err), nil
+	}
+	result.Applied = true
+	result.Result = applied
+
+	return jsonResult(result)
+}
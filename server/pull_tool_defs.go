@@ -0,0 +1,227 @@
+package server
+
+import "github-mcp-server-go/protocol"
+
+// getPullRequestDiffToolDef returns the definition for the get_pull_request_diff tool
+func getPullRequestDiffToolDef() *protocol.Tool {
+	properties := pullRequestProperties()
+	properties["format"] = protocol.Property{
+		Type: "string",
+		Description: "Output format: diff (unified diff), patch (one patch per commit, unparsed) or " +
+			"annotated (each line prefixed with its old and new line numbers, for choosing review comment lines)",
+		Enum:    []string{"diff", "patch", "annotated"},
+		Default: "diff",
+	}
+	properties["path"] = protocol.Property{
+		Type:        "string",
+		Description: "Only include this file or the files under this directory",
+	}
+	properties["max_lines_per_file"] = protocol.Property{
+		Type:        "number",
+		Description: "Maximum diff lines per file; larger files are truncated with a note",
+		Default:     defaultMaxDiffLinesPerFile,
+	}
+
+	return &protocol.Tool{
+		Name:        "get_pull_request_diff",
+		Description: "Get the full diff of a pull request",
+		Schema: protocol.ToolSchema{
+			Type:       "object",
+			Properties: properties,
+			Required:   []string{"owner", "repo", "number"},
+		},
+	}
+}
+
+// getPullRequestChecksToolDef returns the definition for the get_pull_request_checks tool
+func getPullRequestChecksToolDef() *protocol.Tool {
+	properties := pullRequestProperties()
+	properties["include_annotations"] = protocol.Property{
+		Type:        "boolean",
+		Description: "Include the annotations (file, line and message) of failing check runs",
+		Default:     true,
+	}
+
+	return &protocol.Tool{
+		Name: "get_pull_request_checks",
+		Description: "Summarize the CI state of a pull request's head commit as success, failure or pending, " +
+			"combining commit statuses and check runs, with the failing and missing required checks and links to their logs",
+		Schema: protocol.ToolSchema{
+			Type:       "object",
+			Properties: properties,
+			Required:   []string{"owner", "repo", "number"},
+		},
+	}
+}
+
+// updatePullRequestToolDef returns the definition for the update_pull_request tool
+func updatePullRequestToolDef() *protocol.Tool {
+	properties := pullRequestProperties()
+	properties["title"] = protocol.Property{
+		Type:        "string",
+		Description: "New title",
+	}
+	properties["body"] = protocol.Property{
+		Type:        "string",
+		Description: "New description",
+	}
+	properties["base"] = protocol.Property{
+		Type:        "string",
+		Description: "New base branch",
+	}
+	properties["maintainer_can_modify"] = protocol.Property{
+		Type:        "boolean",
+		Description: "Whether maintainers can push to the head branch",
+	}
+
+	return &protocol.Tool{
+		Name:        "update_pull_request",
+		Description: "Update the title, description or base branch of a pull request",
+		Schema: protocol.ToolSchema{
+			Type:       "object",
+			Properties: properties,
+			Required:   []string{"owner", "repo", "number"},
+		},
+	}
+}
+
+// requestReviewersToolDef returns the definition for the request_reviewers tool
+func requestReviewersToolDef() *protocol.Tool {
+	properties := pullRequestProperties()
+	properties["reviewers"] = protocol.Property{
+		Type:        "array",
+		Description: "Logins of the users to request reviews from",
+	}
+	properties["team_reviewers"] = protocol.Property{
+		Type:        "array",
+		Description: "Slugs of the organization teams to request reviews from",
+	}
+
+	return &protocol.Tool{
+		Name:        "request_reviewers",
+		Description: "Request reviews on a pull request from users and teams",
+		Schema: protocol.ToolSchema{
+			Type:       "object",
+			Properties: properties,
+			Required:   []string{"owner", "repo", "number"},
+		},
+	}
+}
+
+// convertToDraftToolDef returns the definition for the convert_pull_request_to_draft tool
+func convertToDraftToolDef() *protocol.Tool {
+	return &protocol.Tool{
+		Name:        "convert_pull_request_to_draft",
+		Description: "Convert an open pull request to a draft",
+		Schema: protocol.ToolSchema{
+			Type:       "object",
+			Properties: pullRequestProperties(),
+			Required:   []string{"owner", "repo", "number"},
+		},
+	}
+}
+
+// markReadyForReviewToolDef returns the definition for the mark_pull_request_ready_for_review tool
+func markReadyForReviewToolDef() *protocol.Tool {
+	return &protocol.Tool{
+		Name:        "mark_pull_request_ready_for_review",
+		Description: "Mark a draft pull request as ready for review",
+		Schema: protocol.ToolSchema{
+			Type:       "object",
+			Properties: pullRequestProperties(),
+			Required:   []string{"owner", "repo", "number"},
+		},
+	}
+}
+
+// updatePullRequestBranchToolDef returns the definition for the update_pull_request_branch tool
+func updatePullRequestBranchToolDef() *protocol.Tool {
+	properties := pullRequestProperties()
+	properties["expected_head_sha"] = protocol.Property{
+		Type:        "string",
+		Description: "Only update if the head branch is still at this commit",
+	}
+
+	return &protocol.Tool{
+		Name:        "update_pull_request_branch",
+		Description: "Merge the latest changes from the base branch into the pull request branch",
+		Schema: protocol.ToolSchema{
+			Type:       "object",
+			Properties: properties,
+			Required:   []string{"owner", "repo", "number"},
+		},
+	}
+}
+
+// closePullRequestToolDef returns the definition for the close_pull_request tool
+func closePullRequestToolDef() *protocol.Tool {
+	return &protocol.Tool{
+		Name:        "close_pull_request",
+		Description: "Close a pull request without merging it",
+		Schema: protocol.ToolSchema{
+			Type:       "object",
+			Properties: pullRequestProperties(),
+			Required:   []string{"owner", "repo", "number"},
+		},
+	}
+}
+
+// reopenPullRequestToolDef returns the definition for the reopen_pull_request tool
+func reopenPullRequestToolDef() *protocol.Tool {
+	return &protocol.Tool{
+		Name:        "reopen_pull_request",
+		Description: "Reopen a closed pull request",
+		Schema: protocol.ToolSchema{
+			Type:       "object",
+			Properties: pullRequestProperties(),
+			Required:   []string{"owner", "repo", "number"},
+		},
+	}
+}
+
+// enableAutoMergeToolDef returns the definition for the enable_auto_merge tool
+func enableAutoMergeToolDef() *protocol.Tool {
+	properties := pullRequestProperties()
+	properties["merge_method"] = protocol.Property{
+		Type:        "string",
+		Description: "Merge method to use once the pull request can be merged",
+		Enum:        []string{"merge", "squash", "rebase"},
+		Default:     "merge",
+	}
+	properties["commit_title"] = protocol.Property{
+		Type:        "string",
+		Description: "Title for the merge commit",
+	}
+	properties["commit_message"] = protocol.Property{
+		Type:        "string",
+		Description: "Extra detail for the merge commit",
+	}
+	properties["sha"] = protocol.Property{
+		Type:        "string",
+		Description: "Only enable auto-merge if the head is still at this commit",
+	}
+
+	return &protocol.Tool{
+		Name: "enable_auto_merge",
+		Description: "Queue a pull request to merge automatically once required checks and reviews pass; " +
+			"auto-merge must be allowed in the repository settings",
+		Schema: protocol.ToolSchema{
+			Type:       "object",
+			Properties: properties,
+			Required:   []string{"owner", "repo", "number"},
+		},
+	}
+}
+
+// disableAutoMergeToolDef returns the definition for the disable_auto_merge tool
+func disableAutoMergeToolDef() *protocol.Tool {
+	return &protocol.Tool{
+		Name:        "disable_auto_merge",
+		Description: "Cancel auto-merge for a pull request",
+		Schema: protocol.ToolSchema{
+			Type:       "object",
+			Properties: pullRequestProperties(),
+			Required:   []string{"owner", "repo", "number"},
+		},
+	}
+}
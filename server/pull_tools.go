@@ -0,0 +1,360 @@
+package server
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github-mcp-server-go/diff"
+	"github-mcp-server-go/github"
+	"github-mcp-server-go/protocol"
+)
+
+// defaultMaxDiffLinesPerFile limits the diff lines returned for each file
+const defaultMaxDiffLinesPerFile = 400
+
+// handleGetPullRequestDiff handles the get_pull_request_diff tool
+func (s *Server) handleGetPullRequestDiff(ctx context.Context, args map[string]interface{}) (*protocol.CallToolResult, error) {
+	owner, repo, number, err := pullRequestArgs(args)
+	if err != nil {
+		return nil, err
+	}
+
+	format := optionalString(args, "format", "diff")
+	if format != "diff" && format != "patch" && format != "annotated" {
+		return nil, fmt.Errorf("format must be one of diff, patch or annotated")
+	}
+
+	maxLines, err := optionalInt(args, "max_lines_per_file", defaultMaxDiffLinesPerFile)
+	if err != nil {
+		return nil, err
+	}
+	path := optionalString(args, "path", "")
+
+	// Patches include commit messages, so they are returned as-is
+	if format == "patch" {
+		patch, err := s.client.GetPullRequestDiff(ctx, owner, repo, number, github.MediaTypePatch)
+		if err != nil {
+			return errorResult("Failed to get pull request patch: %v", err), nil
+		}
+		return &protocol.CallToolResult{
+			Content: []protocol.Content{
+				protocol.TextContent(patch),
+			},
+		}, nil
+	}
+
+	text, err := s.client.GetPullRequestDiff(ctx, owner, repo, number, github.MediaTypeDiff)
+	if err != nil {
+		return errorResult("Failed to get pull request diff: %v", err), nil
+	}
+
+	files, err := diff.Parse(text)
+	if err != nil {
+		return errorResult("Failed to parse pull request diff: %v", err), nil
+	}
+
+	// Keep only files at or under the requested path
+	if path != "" {
+		path = strings.TrimSuffix(path, "/")
+		filtered := files[:0]
+		for _, file := range files {
+			if file.Path() == path || strings.HasPrefix(file.Path(), path+"/") || file.OldPath == path {
+				filtered = append(filtered, file)
+			}
+		}
+		files = filtered
+		if len(files) == 0 {
+			return errorResult("No changes to %s in pull request #%d", path, number), nil
+		}
+	}
+
+	diff.Truncate(files, int(maxLines))
+
+	result := diff.Format(files)
+	if format == "annotated" {
+		result = diff.FormatAnnotated(files)
+	}
+
+	return &protocol.CallToolResult{
+		Content: []protocol.Content{
+			protocol.TextContent(result),
+		},
+	}, nil
+}
+
+// maxFailureAnnotations limits the annotations fetched for each failing check run
+const maxFailureAnnotations = 10
+
+// handleGetPullRequestChecks handles the get_pull_request_checks tool
+func (s *Server) handleGetPullRequestChecks(ctx context.Context, args map[string]interface{}) (*protocol.CallToolResult, error) {
+	owner, repo, number, err := pullRequestArgs(args)
+	if err != nil {
+		return nil, err
+	}
+	includeAnnotations := true
+	if value, ok := args["include_annotations"].(bool); ok {
+		includeAnnotations = value
+	}
+
+	pr, err := s.client.GetPullRequest(ctx, owner, repo, number)
+	if err != nil {
+		return errorResult("Failed to get pull request: %v", err), nil
+	}
+	sha := pr.Head.SHA
+
+	status, err := s.client.GetCombinedStatus(ctx, owner, repo, sha)
+	if err != nil {
+		return errorResult("Failed to get commit status: %v", err), nil
+	}
+
+	runs, err := s.client.ListAllCheckRuns(ctx, owner, repo, sha)
+	if err != nil {
+		return errorResult("Failed to list check runs: %v", err), nil
+	}
+
+	// Required checks come from the base branch protection, which may be
+	// missing or hidden from the token
+	required, err := s.client.GetRequiredStatusChecks(ctx, owner, repo, pr.Base.Ref)
+	if err != nil && !github.IsStatus(err, http.StatusNotFound) && !github.IsStatus(err, http.StatusForbidden) {
+		return errorResult("Failed to get required status checks: %v", err), nil
+	}
+
+	summary := github.SummarizeChecks(sha, status, runs, required)
+
+	if includeAnnotations {
+		for _, check := range summary.Checks {
+			if check.Kind != "check_run" || check.State != github.CheckStateFailure {
+				continue
+			}
+			annotations, err := s.client.ListCheckRunAnnotations(ctx, owner, repo, check.CheckRunID, &github.ListOptions{PerPage: maxFailureAnnotations})
+			if err != nil {
+				continue
+			}
+			check.Annotations = annotations
+		}
+	}
+
+	return jsonResult(summary)
+}
+
+// pullRequestState is the part of a pull request reported by lifecycle tools
+type pullRequestState struct {
+	Number             int               `json:"number"`
+	Title              string            `json:"title"`
+	State              string            `json:"state"`
+	Draft              bool              `json:"draft"`
+	Base               string            `json:"base"`
+	HTMLURL            string            `json:"html_url"`
+	AutoMerge          *github.AutoMerge `json:"auto_merge,omitempty"`
+	RequestedReviewers []string          `json:"requested_reviewers,omitempty"`
+	RequestedTeams     []string          `json:"requested_teams,omitempty"`
+	Message            string            `json:"message,omitempty"`
+}
+
+// pullRequestStateResult reports the state of a pull request after a change
+func pullRequestStateResult(pr *github.PullRequest, message string) (*protocol.CallToolResult, error) {
+	state := pullRequestState{
+		Number:    pr.Number,
+		Title:     pr.Title,
+		State:     pr.State,
+		Draft:     pr.Draft,
+		Base:      pr.Base.Ref,
+		HTMLURL:   pr.HTMLURL,
+		AutoMerge: pr.AutoMerge,
+		Message:   message,
+	}
+	for _, user := range pr.RequestedReviewers {
+		state.RequestedReviewers = append(state.RequestedReviewers, user.Login)
+	}
+	for _, team := range pr.RequestedTeams {
+		state.RequestedTeams = append(state.RequestedTeams, team.Slug)
+	}
+	return jsonResult(state)
+}
+
+// handleUpdatePullRequest handles the update_pull_request tool
+func (s *Server) handleUpdatePullRequest(ctx context.Context, args map[string]interface{}) (*protocol.CallToolResult, error) {
+	owner, repo, number, err := pullRequestArgs(args)
+	if err != nil {
+		return nil, err
+	}
+
+	update := make(map[string]interface{})
+	for _, name := range []string{"title", "body", "base"} {
+		if value, ok := args[name].(string); ok {
+			update[name] = value
+		}
+	}
+	if value, ok := args["maintainer_can_modify"].(bool); ok {
+		update["maintainer_can_modify"] = value
+	}
+	if len(update) == 0 {
+		return nil, fmt.Errorf("at least one of title, body, base or maintainer_can_modify is required")
+	}
+
+	pr, err := s.client.UpdatePullRequest(ctx, owner, repo, number, update)
+	if err != nil {
+		return errorResult("Failed to update pull request: %v", err), nil
+	}
+
+	return pullRequestStateResult(pr, "")
+}
+
+// handleRequestReviewers handles the request_reviewers tool
+func (s *Server) handleRequestReviewers(ctx context.Context, args map[string]interface{}) (*protocol.CallToolResult, error) {
+	owner, repo, number, err := pullRequestArgs(args)
+	if err != nil {
+		return nil, err
+	}
+
+	reviewers, err := optionalStrings(args, "reviewers")
+	if err != nil {
+		return nil, err
+	}
+	teams, err := optionalStrings(args, "team_reviewers")
+	if err != nil {
+		return nil, err
+	}
+	if len(reviewers) == 0 && len(teams) == 0 {
+		return nil, fmt.Errorf("reviewers or team_reviewers is required")
+	}
+
+	pr, err := s.client.RequestReviewers(ctx, owner, repo, number, &github.ReviewersRequest{
+		Reviewers:     reviewers,
+		TeamReviewers: teams,
+	})
+	if err != nil {
+		return errorResult("Failed to request reviewers: %v", err), nil
+	}
+
+	return pullRequestStateResult(pr, "")
+}
+
+// handleSetPullRequestDraft handles the convert_pull_request_to_draft and
+// mark_pull_request_ready_for_review tools
+func (s *Server) handleSetPullRequestDraft(draft bool) ToolHandler {
+	return func(ctx context.Context, args map[string]interface{}) (*protocol.CallToolResult, error) {
+		owner, repo, number, err := pullRequestArgs(args)
+		if err != nil {
+			return nil, err
+		}
+
+		pr, err := s.client.GetPullRequest(ctx, owner, repo, number)
+		if err != nil {
+			return errorResult("Failed to get pull request: %v", err), nil
+		}
+		if pr.State != "open" {
+			return errorResult("Pull request #%d is %s", number, pr.State), nil
+		}
+		if pr.Draft == draft {
+			return pullRequestStateResult(pr, "No change needed")
+		}
+
+		if draft {
+			err = s.client.ConvertPullRequestToDraft(ctx, pr.NodeID)
+		} else {
+			err = s.client.MarkPullRequestReadyForReview(ctx, pr.NodeID)
+		}
+		if err != nil {
+			return errorResult("Failed to update draft state: %v", err), nil
+		}
+
+		pr.Draft = draft
+		return pullRequestStateResult(pr, "")
+	}
+}
+
+// handleUpdatePullRequestBranch handles the update_pull_request_branch tool
+func (s *Server) handleUpdatePullRequestBranch(ctx context.Context, args map[string]interface{}) (*protocol.CallToolResult, error) {
+	owner, repo, number, err := pullRequestArgs(args)
+	if err != nil {
+		return nil, err
+	}
+
+	message, err := s.client.UpdatePullRequestBranch(ctx, owner, repo, number, optionalString(args, "expected_head_sha", ""))
+	if err != nil {
+		return errorResult("Failed to update pull request branch: %v", err), nil
+	}
+
+	return &protocol.CallToolResult{
+		Content: []protocol.Content{
+			protocol.TextContent(message),
+		},
+	}, nil
+}
+
+// handleSetPullRequestState handles the close_pull_request and
+// reopen_pull_request tools
+func (s *Server) handleSetPullRequestState(state string) ToolHandler {
+	return func(ctx context.Context, args map[string]interface{}) (*protocol.CallToolResult, error) {
+		owner, repo, number, err := pullRequestArgs(args)
+		if err != nil {
+			return nil, err
+		}
+
+		pr, err := s.client.SetPullRequestState(ctx, owner, repo, number, state)
+		if err != nil {
+			return errorResult("Failed to set pull request state: %v", err), nil
+		}
+
+		return pullRequestStateResult(pr, "")
+	}
+}
+
+// handleEnableAutoMerge handles the enable_auto_merge tool
+func (s *Server) handleEnableAutoMerge(ctx context.Context, args map[string]interface{}) (*protocol.CallToolResult, error) {
+	owner, repo, number, err := pullRequestArgs(args)
+	if err != nil {
+		return nil, err
+	}
+
+	opts := &github.MergePullRequestOptions{
+		MergeMethod:   optionalString(args, "merge_method", "merge"),
+		CommitTitle:   optionalString(args, "commit_title", ""),
+		CommitMessage: optionalString(args, "commit_message", ""),
+		SHA:           optionalString(args, "sha", ""),
+	}
+	switch opts.MergeMethod {
+	case "merge", "squash", "rebase":
+	default:
+		return nil, fmt.Errorf("merge_method must be one of merge, squash or rebase")
+	}
+
+	pr, err := s.client.GetPullRequest(ctx, owner, repo, number)
+	if err != nil {
+		return errorResult("Failed to get pull request: %v", err), nil
+	}
+
+	if err := s.client.EnableAutoMerge(ctx, pr.NodeID, opts); err != nil {
+		return errorResult("Failed to enable auto-merge: %v", err), nil
+	}
+
+	pr.AutoMerge = &github.AutoMerge{
+		MergeMethod:   opts.MergeMethod,
+		CommitTitle:   opts.CommitTitle,
+		CommitMessage: opts.CommitMessage,
+	}
+	return pullRequestStateResult(pr, fmt.Sprintf("Pull request #%d will be merged once its requirements are met", number))
+}
+
+// handleDisableAutoMerge handles the disable_auto_merge tool
+func (s *Server) handleDisableAutoMerge(ctx context.Context, args map[string]interface{}) (*protocol.CallToolResult, error) {
+	owner, repo, number, err := pullRequestArgs(args)
+	if err != nil {
+		return nil, err
+	}
+
+	pr, err := s.client.GetPullRequest(ctx, owner, repo, number)
+	if err != nil {
+		return errorResult("Failed to get pull request: %v", err), nil
+	}
+
+	if err := s.client.DisableAutoMerge(ctx, pr.NodeID); err != nil {
+		return errorResult("Failed to disable auto-merge: %v", err), nil
+	}
+
+	pr.AutoMerge = nil
+	return pullRequestStateResult(pr, "Auto-merge disabled")
+}
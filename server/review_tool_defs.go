@@ -0,0 +1,169 @@
+package server
+
+import "github-mcp-server-go/protocol"
+
+// pullRequestProperties returns the schema properties identifying a pull request
+func pullRequestProperties() map[string]protocol.Property {
+	return map[string]protocol.Property{
+		"owner": {
+			Type:        "string",
+			Description: "Repository owner (username or organization)",
+		},
+		"repo": {
+			Type:        "string",
+			Description: "Repository name",
+		},
+		"number": {
+			Type:        "number",
+			Description: "Pull request number",
+		},
+	}
+}
+
+// paginate adds page and per_page properties to a schema
+func paginate(properties map[string]protocol.Property) map[string]protocol.Property {
+	properties["page"] = protocol.Property{
+		Type:        "number",
+		Description: "Page number for pagination",
+		Default:     1,
+	}
+	properties["per_page"] = protocol.Property{
+		Type:        "number",
+		Description: "Number of results per page",
+		Default:     30,
+	}
+	return properties
+}
+
+// listReviewsToolDef returns the definition for the list_pull_request_reviews tool
+func listReviewsToolDef() *protocol.Tool {
+	return &protocol.Tool{
+		Name:        "list_pull_request_reviews",
+		Description: "List the reviews of a pull request with their state (APPROVED, CHANGES_REQUESTED, COMMENTED, DISMISSED or PENDING)",
+		Schema: protocol.ToolSchema{
+			Type:       "object",
+			Properties: paginate(pullRequestProperties()),
+			Required:   []string{"owner", "repo", "number"},
+		},
+	}
+}
+
+// listReviewCommentsToolDef returns the definition for the list_review_comments tool
+func listReviewCommentsToolDef() *protocol.Tool {
+	return &protocol.Tool{
+		Name:        "list_review_comments",
+		Description: "List inline review comments on a pull request diff; replies carry the in_reply_to_id of their thread",
+		Schema: protocol.ToolSchema{
+			Type:       "object",
+			Properties: paginate(pullRequestProperties()),
+			Required:   []string{"owner", "repo", "number"},
+		},
+	}
+}
+
+// createReviewToolDef returns the definition for the create_pull_request_review tool
+func createReviewToolDef() *protocol.Tool {
+	properties := pullRequestProperties()
+	properties["body"] = protocol.Property{
+		Type:        "string",
+		Description: "Review summary",
+	}
+	properties["event"] = protocol.Property{
+		Type:        "string",
+		Description: "Submit immediately with this event; omit to leave the review pending",
+		Enum:        []string{"APPROVE", "REQUEST_CHANGES", "COMMENT"},
+	}
+	properties["commit_id"] = protocol.Property{
+		Type:        "string",
+		Description: "Commit SHA to review (defaults to the pull request head)",
+	}
+	properties["comments"] = protocol.Property{
+		Type: "array",
+		Description: "Inline comments, each an object with path, body and line (last line of the comment), " +
+			"plus optional side (LEFT for deleted lines, RIGHT otherwise), start_line and start_side for multi-line comments",
+	}
+
+	return &protocol.Tool{
+		Name:        "create_pull_request_review",
+		Description: "Create a pull request review with optional line or multi-line comments, pending unless an event is given",
+		Schema: protocol.ToolSchema{
+			Type:       "object",
+			Properties: properties,
+			Required:   []string{"owner", "repo", "number"},
+		},
+	}
+}
+
+// submitReviewToolDef returns the definition for the submit_pull_request_review tool
+func submitReviewToolDef() *protocol.Tool {
+	properties := pullRequestProperties()
+	properties["review_id"] = protocol.Property{
+		Type:        "number",
+		Description: "ID of the pending review",
+	}
+	properties["event"] = protocol.Property{
+		Type:        "string",
+		Description: "Review outcome",
+		Enum:        []string{"APPROVE", "REQUEST_CHANGES", "COMMENT"},
+	}
+	properties["body"] = protocol.Property{
+		Type:        "string",
+		Description: "Review summary (required for REQUEST_CHANGES and COMMENT unless set when creating the review)",
+	}
+
+	return &protocol.Tool{
+		Name:        "submit_pull_request_review",
+		Description: "Submit a pending pull request review",
+		Schema: protocol.ToolSchema{
+			Type:       "object",
+			Properties: properties,
+			Required:   []string{"owner", "repo", "number", "review_id", "event"},
+		},
+	}
+}
+
+// dismissReviewToolDef returns the definition for the dismiss_pull_request_review tool
+func dismissReviewToolDef() *protocol.Tool {
+	properties := pullRequestProperties()
+	properties["review_id"] = protocol.Property{
+		Type:        "number",
+		Description: "ID of the review to dismiss",
+	}
+	properties["message"] = protocol.Property{
+		Type:        "string",
+		Description: "Reason for dismissing the review",
+	}
+
+	return &protocol.Tool{
+		Name:        "dismiss_pull_request_review",
+		Description: "Dismiss a submitted pull request review",
+		Schema: protocol.ToolSchema{
+			Type:       "object",
+			Properties: properties,
+			Required:   []string{"owner", "repo", "number", "review_id", "message"},
+		},
+	}
+}
+
+// replyToReviewCommentToolDef returns the definition for the reply_to_review_comment tool
+func replyToReviewCommentToolDef() *protocol.Tool {
+	properties := pullRequestProperties()
+	properties["comment_id"] = protocol.Property{
+		Type:        "number",
+		Description: "ID of a comment in the thread to reply to",
+	}
+	properties["body"] = protocol.Property{
+		Type:        "string",
+		Description: "Reply text",
+	}
+
+	return &protocol.Tool{
+		Name:        "reply_to_review_comment",
+		Description: "Reply to an existing review comment thread",
+		Schema: protocol.ToolSchema{
+			Type:       "object",
+			Properties: properties,
+			Required:   []string{"owner", "repo", "number", "comment_id", "body"},
+		},
+	}
+}
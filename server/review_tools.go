@@ -0,0 +1,279 @@
+package server
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github-mcp-server-go/github"
+	"github-mcp-server-go/protocol"
+)
+
+// registerReviewTools registers pull request review tools
+func (s *Server) registerReviewTools() {
+	s.tools["list_pull_request_reviews"] = s.handleListReviews
+	s.tools["list_review_comments"] = s.handleListReviewComments
+	s.tools["create_pull_request_review"] = s.handleCreateReview
+	s.tools["submit_pull_request_review"] = s.handleSubmitReview
+	s.tools["dismiss_pull_request_review"] = s.handleDismissReview
+	s.tools["reply_to_review_comment"] = s.handleReplyToReviewComment
+}
+
+// handleListReviews handles the list_pull_request_reviews tool
+func (s *Server) handleListReviews(ctx context.Context, args map[string]interface{}) (*protocol.CallToolResult, error) {
+	owner, repo, number, err := pullRequestArgs(args)
+	if err != nil {
+		return nil, err
+	}
+
+	opts, err := listOptionsArgs(args)
+	if err != nil {
+		return nil, err
+	}
+
+	reviews, err := s.client.ListReviews(ctx, owner, repo, number, opts)
+	if err != nil {
+		return errorResult("Failed to list reviews: %v", err), nil
+	}
+
+	return jsonResult(reviews)
+}
+
+// handleListReviewComments handles the list_review_comments tool
+func (s *Server) handleListReviewComments(ctx context.Context, args map[string]interface{}) (*protocol.CallToolResult, error) {
+	owner, repo, number, err := pullRequestArgs(args)
+	if err != nil {
+		return nil, err
+	}
+
+	opts, err := listOptionsArgs(args)
+	if err != nil {
+		return nil, err
+	}
+
+	comments, err := s.client.ListReviewComments(ctx, owner, repo, number, opts)
+	if err != nil {
+		return errorResult("Failed to list review comments: %v", err), nil
+	}
+
+	return jsonResult(comments)
+}
+
+// handleCreateReview handles the create_pull_request_review tool
+func (s *Server) handleCreateReview(ctx context.Context, args map[string]interface{}) (*protocol.CallToolResult, error) {
+	owner, repo, number, err := pullRequestArgs(args)
+	if err != nil {
+		return nil, err
+	}
+
+	review := &github.CreateReviewRequest{
+		CommitID: optionalString(args, "commit_id", ""),
+		Body:     optionalString(args, "body", ""),
+	}
+
+	// Without an event the review stays pending for submit_pull_request_review
+	if event := optionalString(args, "event", ""); event != "" {
+		if review.Event, err = reviewEvent(event); err != nil {
+			return nil, err
+		}
+		if review.Event != github.ReviewEventApprove && review.Body == "" && args["comments"] == nil {
+			return nil, fmt.Errorf("body is required for %s reviews", review.Event)
+		}
+	}
+
+	if review.Comments, err = reviewCommentsArg(args["comments"]); err != nil {
+		return nil, err
+	}
+
+	created, err := s.client.CreateReview(ctx, owner, repo, number, review)
+	if err != nil {
+		return errorResult("Failed to create review: %v", err), nil
+	}
+
+	return jsonResult(created)
+}
+
+// handleSubmitReview handles the submit_pull_request_review tool
+func (s *Server) handleSubmitReview(ctx context.Context, args map[string]interface{}) (*protocol.CallToolResult, error) {
+	owner, repo, number, err := pullRequestArgs(args)
+	if err != nil {
+		return nil, err
+	}
+
+	reviewID, err := requireInt(args, "review_id")
+	if err != nil {
+		return nil, err
+	}
+
+	eventArg, err := requireString(args, "event")
+	if err != nil {
+		return nil, err
+	}
+	event, err := reviewEvent(eventArg)
+	if err != nil {
+		return nil, err
+	}
+
+	review, err := s.client.SubmitReview(ctx, owner, repo, number, reviewID, event, optionalString(args, "body", ""))
+	if err != nil {
+		return errorResult("Failed to submit review: %v", err), nil
+	}
+
+	return jsonResult(review)
+}
+
+// handleDismissReview handles the dismiss_pull_request_review tool
+func (s *Server) handleDismissReview(ctx context.Context, args map[string]interface{}) (*protocol.CallToolResult, error) {
+	owner, repo, number, err := pullRequestArgs(args)
+	if err != nil {
+		return nil, err
+	}
+
+	reviewID, err := requireInt(args, "review_id")
+	if err != nil {
+		return nil, err
+	}
+
+	message, err := requireString(args, "message")
+	if err != nil {
+		return nil, err
+	}
+
+	review, err := s.client.DismissReview(ctx, owner, repo, number, reviewID, message)
+	if err != nil {
+		return errorResult("Failed to dismiss review: %v", err), nil
+	}
+
+	return jsonResult(review)
+}
+
+// handleReplyToReviewComment handles the reply_to_review_comment tool
+func (s *Server) handleReplyToReviewComment(ctx context.Context, args map[string]interface{}) (*protocol.CallToolResult, error) {
+	owner, repo, number, err := pullRequestArgs(args)
+	if err != nil {
+		return nil, err
+	}
+
+	commentID, err := requireInt(args, "comment_id")
+	if err != nil {
+		return nil, err
+	}
+
+	body, err := requireString(args, "body")
+	if err != nil {
+		return nil, err
+	}
+
+	comment, err := s.client.ReplyToReviewComment(ctx, owner, repo, number, commentID, body)
+	if err != nil {
+		return errorResult("Failed to reply to review comment: %v", err), nil
+	}
+
+	return jsonResult(comment)
+}
+
+// pullRequestArgs returns the required owner, repo and number arguments
+func pullRequestArgs(args map[string]interface{}) (string, string, int, error) {
+	owner, repo, err := requireRepo(args)
+	if err != nil {
+		return "", "", 0, err
+	}
+
+	number, err := requireInt(args, "number")
+	if err != nil {
+		return "", "", 0, err
+	}
+
+	return owner, repo, int(number), nil
+}
+
+// listOptionsArgs returns the optional page and per_page arguments
+func listOptionsArgs(args map[string]interface{}) (*github.ListOptions, error) {
+	page, err := optionalInt(args, "page", 1)
+	if err != nil {
+		return nil, err
+	}
+
+	perPage, err := optionalInt(args, "per_page", 30)
+	if err != nil {
+		return nil, err
+	}
+
+	return &github.ListOptions{Page: int(page), PerPage: int(perPage)}, nil
+}
+
+// reviewEvent validates a review event
+func reviewEvent(event string) (string, error) {
+	switch strings.ToUpper(event) {
+	case github.ReviewEventApprove, github.ReviewEventRequestChanges, github.ReviewEventComment:
+		return strings.ToUpper(event), nil
+	default:
+		return "", fmt.Errorf("event must be one of APPROVE, REQUEST_CHANGES or COMMENT")
+	}
+}
+
+// reviewCommentsArg converts the comments argument to draft review comments
+func reviewCommentsArg(value interface{}) ([]*github.DraftReviewComment, error) {
+	if value == nil {
+		return nil, nil
+	}
+
+	items, ok := value.([]interface{})
+	if !ok {
+		return nil, fmt.Errorf("comments must be an array")
+	}
+
+	comments := make([]*github.DraftReviewComment, 0, len(items))
+	for i, item := range items {
+		args, ok := item.(map[string]interface{})
+		if !ok {
+			return nil, fmt.Errorf("comments[%d] must be an object", i)
+		}
+
+		path, err := requireString(args, "path")
+		if err != nil {
+			return nil, fmt.Errorf("comments[%d]: %w", i, err)
+		}
+		body, err := requireString(args, "body")
+		if err != nil {
+			return nil, fmt.Errorf("comments[%d]: %w", i, err)
+		}
+		line, err := requireInt(args, "line")
+		if err != nil {
+			return nil, fmt.Errorf("comments[%d]: %w", i, err)
+		}
+		startLine, err := optionalInt(args, "start_line", 0)
+		if err != nil {
+			return nil, fmt.Errorf("comments[%d]: %w", i, err)
+		}
+		if line <= 0 || startLine < 0 {
+			return nil, fmt.Errorf("comments[%d]: line and start_line must be positive", i)
+		}
+
+		comment := &github.DraftReviewComment{
+			Path:      path,
+			Body:      body,
+			Line:      int(line),
+			StartLine: int(startLine),
+			Side:      strings.ToUpper(optionalString(args, "side", "RIGHT")),
+			StartSide: strings.ToUpper(optionalString(args, "start_side", "")),
+		}
+		for _, side := range []string{comment.Side, comment.StartSide} {
+			if side != "" && side != "LEFT" && side != "RIGHT" {
+				return nil, fmt.Errorf("comments[%d]: side must be LEFT or RIGHT", i)
+			}
+		}
+		if comment.StartLine != 0 && comment.StartSide == "" {
+			comment.StartSide = comment.Side
+		}
+
+		// Line numbers are only ordered within one side of the diff
+		if comment.StartLine != 0 && comment.StartSide == comment.Side && startLine >= line {
+			return nil, fmt.Errorf("comments[%d]: start_line must be before line", i)
+		}
+
+		comments = append(comments, comment)
+	}
+
+	return comments, nil
+}
@@ -0,0 +1,117 @@
+package server
+
+import "github-mcp-server-go/protocol"
+
+// repoOrOrgProperties returns the schema properties selecting a repository
+// or an organization
+func repoOrOrgProperties() map[string]protocol.Property {
+	properties := repoProperties()
+	properties["org"] = protocol.Property{
+		Type:        "string",
+		Description: "Organization, instead of owner and repo, for organization-level resources (needs the admin:org scope)",
+	}
+	return properties
+}
+
+// listRunnersToolDef returns the definition for the list_runners tool
+func listRunnersToolDef() *protocol.Tool {
+	properties := repoOrOrgProperties()
+	properties["status"] = protocol.Property{
+		Type:        "string",
+		Description: "Only list runners with this status",
+		Enum:        []string{"online", "offline"},
+	}
+	properties["labels"] = protocol.Property{
+		Type:        "array",
+		Description: "Only list runners that have all of these labels",
+	}
+
+	return &protocol.Tool{
+		Name:        "list_runners",
+		Description: "List the self-hosted runners of a repository or organization with their status, busy state and labels",
+		Schema: protocol.ToolSchema{
+			Type:       "object",
+			Properties: properties,
+		},
+	}
+}
+
+// listRunnerGroupsToolDef returns the definition for the list_runner_groups tool
+func listRunnerGroupsToolDef() *protocol.Tool {
+	return &protocol.Tool{
+		Name:        "list_runner_groups",
+		Description: "List the runner groups of an organization with their visibility and workflow restrictions",
+		Schema: protocol.ToolSchema{
+			Type: "object",
+			Properties: paginate(map[string]protocol.Property{
+				"org": {
+					Type:        "string",
+					Description: "Organization",
+				},
+			}),
+			Required: []string{"org"},
+		},
+	}
+}
+
+// createRunnerTokenToolDef returns the definition for the create_runner_token tool
+func createRunnerTokenToolDef() *protocol.Tool {
+	properties := repoOrOrgProperties()
+	properties["type"] = protocol.Property{
+		Type:        "string",
+		Description: "Token for registering a new runner or for removing one with config.sh remove",
+		Enum:        []string{"registration", "removal"},
+		Default:     "registration",
+	}
+
+	return &protocol.Tool{
+		Name:        "create_runner_token",
+		Description: "Create a short-lived token for registering or removing a self-hosted runner",
+		Schema: protocol.ToolSchema{
+			Type:       "object",
+			Properties: properties,
+		},
+	}
+}
+
+// removeRunnerToolDef returns the definition for the remove_runner tool
+func removeRunnerToolDef() *protocol.Tool {
+	properties := repoOrOrgProperties()
+	properties["runner_id"] = protocol.Property{
+		Type:        "number",
+		Description: "Runner ID",
+	}
+
+	return &protocol.Tool{
+		Name:        "remove_runner",
+		Description: "Remove a self-hosted runner from a repository or organization",
+		Schema: protocol.ToolSchema{
+			Type:       "object",
+			Properties: properties,
+			Required:   []string{"runner_id"},
+		},
+	}
+}
+
+// removeOfflineRunnersToolDef returns the definition for the remove_offline_runners tool
+func removeOfflineRunnersToolDef() *protocol.Tool {
+	properties := repoOrOrgProperties()
+	properties["labels"] = protocol.Property{
+		Type:        "array",
+		Description: "Only remove offline runners that have all of these labels",
+	}
+	properties["dry_run"] = protocol.Property{
+		Type:        "boolean",
+		Description: "List the runners that would be removed without removing them",
+		Default:     false,
+	}
+
+	return &protocol.Tool{
+		Name:        "remove_offline_runners",
+		Description: "Remove the offline self-hosted runners of a repository or organization",
+		Schema: protocol.ToolSchema{
+			Type:       "object",
+			Properties: properties,
+		},
+	}
+}
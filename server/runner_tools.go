@@ -0,0 +1,182 @@
+package server
+
+import (
+	"context"
+	"fmt"
+
+	"github-mcp-server-go/github"
+	"github-mcp-server-go/protocol"
+)
+
+// registerRunnerTools registers self-hosted runner tools
+func (s *Server) registerRunnerTools() {
+	s.tools["list_runners"] = s.handleListRunners
+	s.tools["list_runner_groups"] = s.handleListRunnerGroups
+	s.tools["create_runner_token"] = s.handleCreateRunnerToken
+	s.tools["remove_runner"] = s.handleRemoveRunner
+	s.tools["remove_offline_runners"] = s.handleRemoveOfflineRunners
+}
+
+// repoOrOrgArgs returns the repository or organization named by the
+// arguments; unlike secrets, runners and caches have no environment level
+func repoOrOrgArgs(args map[string]interface{}) (github.ActionsScope, error) {
+	scope, err := secretScopeArgs(args)
+	if err != nil {
+		return github.ActionsScope{}, err
+	}
+	if scope.Environment != "" {
+		return github.ActionsScope{}, fmt.Errorf("environment does not apply to this tool")
+	}
+	return scope, nil
+}
+
+// filterRunners returns the runners with the given status, if any, and all
+// of the given labels
+func filterRunners(runners []*github.Runner, status string, labels []string) []*github.Runner {
+	filtered := []*github.Runner{}
+	for _, runner := range runners {
+		if status != "" && runner.Status != status {
+			continue
+		}
+		matches := true
+		for _, label := range labels {
+			if !runner.HasLabel(label) {
+				matches = false
+				break
+			}
+		}
+		if matches {
+			filtered = append(filtered, runner)
+		}
+	}
+	return filtered
+}
+
+// handleListRunners handles the list_runners tool
+func (s *Server) handleListRunners(ctx context.Context, args map[string]interface{}) (*protocol.CallToolResult, error) {
+	scope, err := repoOrOrgArgs(args)
+	if err != nil {
+		return nil, err
+	}
+	status := optionalString(args, "status", "")
+	if status != "" && status != github.RunnerStatusOnline && status != github.RunnerStatusOffline {
+		return nil, fmt.Errorf("status must be online or offline")
+	}
+	labels, err := optionalStrings(args, "labels")
+	if err != nil {
+		return nil, err
+	}
+
+	runners, err := s.client.ListAllRunners(ctx, scope)
+	if err != nil {
+		return errorResult("Failed to list runners: %v", err), nil
+	}
+
+	return jsonResult(filterRunners(runners, status, labels))
+}
+
+// handleListRunnerGroups handles the list_runner_groups tool
+func (s *Server) handleListRunnerGroups(ctx context.Context, args map[string]interface{}) (*protocol.CallToolResult, error) {
+	org, err := requireString(args, "org")
+	if err != nil {
+		return nil, err
+	}
+	opts, err := listOptionsArgs(args)
+	if err != nil {
+		return nil, err
+	}
+
+	groups, _, err := s.client.ListRunnerGroups(ctx, org, opts)
+	if err != nil {
+		return errorResult("Failed to list runner groups: %v", err), nil
+	}
+
+	return jsonResult(groups)
+}
+
+// handleCreateRunnerToken handles the create_runner_token tool
+func (s *Server) handleCreateRunnerToken(ctx context.Context, args map[string]interface{}) (*protocol.CallToolResult, error) {
+	scope, err := repoOrOrgArgs(args)
+	if err != nil {
+		return nil, err
+	}
+
+	var token *github.RunnerToken
+	switch kind := optionalString(args, "type", "registration"); kind {
+	case "registration":
+		token, err = s.client.CreateRunnerRegistrationToken(ctx, scope)
+	case "removal":
+		token, err = s.client.CreateRunnerRemoveToken(ctx, scope)
+	default:
+		return nil, fmt.Errorf("type must be registration or removal")
+	}
+	if err != nil {
+		return errorResult("Failed to create runner token: %v", err), nil
+	}
+
+	return jsonResult(token)
+}
+
+// handleRemoveRunner handles the remove_runner tool
+func (s *Server) handleRemoveRunner(ctx context.Context, args map[string]interface{}) (*protocol.CallToolResult, error) {
+	scope, err := repoOrOrgArgs(args)
+	if err != nil {
+		return nil, err
+	}
+	runnerID, err := requireInt(args, "runner_id")
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.client.DeleteRunner(ctx, scope, runnerID); err != nil {
+		return errorResult("Failed to remove runner: %v", err), nil
+	}
+
+	return &protocol.CallToolResult{
+		Content: []protocol.Content{
+			protocol.TextContent(fmt.Sprintf("Runner %d removed from %s", runnerID, scope)),
+		},
+	}, nil
+}
+
+// removedRunnersResult reports the outcome of remove_offline_runners
+type removedRunnersResult struct {
+	DryRun  bool             `json:"dry_run"`
+	Removed []*github.Runner `json:"removed"`
+	Errors  []string         `json:"errors,omitempty"`
+}
+
+// handleRemoveOfflineRunners handles the remove_offline_runners tool
+func (s *Server) handleRemoveOfflineRunners(ctx context.Context, args map[string]interface{}) (*protocol.CallToolResult, error) {
+	scope, err := repoOrOrgArgs(args)
+	if err != nil {
+		return nil, err
+	}
+	labels, err := optionalStrings(args, "labels")
+	if err != nil {
+		return nil, err
+	}
+
+	runners, err := s.client.ListAllRunners(ctx, scope)
+	if err != nil {
+		return errorResult("Failed to list runners: %v", err), nil
+	}
+
+	result := &removedRunnersResult{DryRun: optionalBool(args, "dry_run"), Removed: []*github.Runner{}}
+	offline := filterRunners(runners, github.RunnerStatusOffline, labels)
+	for i, runner := range offline {
+		if runner.Busy {
+			continue
+		}
+		if !result.DryRun {
+			reportProgress(ctx, float64(i), fmt.Sprintf("Removing runner %s", runner.Name))
+			if err := s.client.DeleteRunner(ctx, scope, runner.ID); err != nil {
+				result.Errors = append(result.Errors, fmt.Sprintf("%s (%d): %v", runner.Name, runner.ID, err))
+				continue
+			}
+		}
+		result.Removed = append(result.Removed, runner)
+	}
+
+	return jsonResult(result)
+}
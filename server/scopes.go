@@ -0,0 +1,127 @@
+package server
+
+import "sort"
+
+// toolScopes lists the classic OAuth scopes each tool needs. Tools that only
+// read public data are omitted. Tools that also act on an organization list
+// admin:org, and tools that can write workflow files list workflow.
+var toolScopes = map[string][]string{
+	// Repository tools
+	"get_repository":    {"repo"},
+	"list_repositories": {"repo"},
+	"create_repository": {"repo"},
+
+	// Issue tools
+	"get_issue":    {"repo"},
+	"list_issues":  {"repo"},
+	"create_issue": {"repo"},
+	"close_issue":  {"repo"},
+
+	// Pull request tools
+	"get_pull_request":                   {"repo"},
+	"list_pull_requests":                 {"repo"},
+	"create_pull_request":                {"repo"},
+	"merge_pull_request":                 {"repo"},
+	"get_pull_request_diff":              {"repo"},
+	"get_pull_request_checks":            {"repo"},
+	"update_pull_request":                {"repo"},
+	"request_reviewers":                  {"repo"},
+	"convert_pull_request_to_draft":      {"repo"},
+	"mark_pull_request_ready_for_review": {"repo"},
+	"update_pull_request_branch":         {"repo"},
+	"close_pull_request":                 {"repo"},
+	"reopen_pull_request":                {"repo"},
+	"enable_auto_merge":                  {"repo"},
+	"disable_auto_merge":                 {"repo"},
+
+	// Pull request review tools
+	"list_pull_request_reviews":   {"repo"},
+	"list_review_comments":        {"repo"},
+	"create_pull_request_review":  {"repo"},
+	"submit_pull_request_review":  {"repo"},
+	"dismiss_pull_request_review": {"repo"},
+	"reply_to_review_comment":     {"repo"},
+
+	// GitHub Actions tools
+	"list_workflows":              {"repo"},
+	"list_workflow_runs":          {"repo"},
+	"trigger_workflow":            {"repo"},
+	"get_workflow_run_failure":    {"repo"},
+	"get_workflow_run":            {"repo"},
+	"list_workflow_jobs":          {"repo"},
+	"cancel_workflow_run":         {"repo"},
+	"rerun_workflow_run":          {"repo"},
+	"list_workflow_run_artifacts": {"repo"},
+	"download_artifact":           {"repo"},
+	"watch_workflow_run":          {"repo"},
+	"get_workflow_inputs":         {"repo"},
+	"analyze_workflow_runs":       {"repo"},
+
+	// Actions secret and variable tools
+	"list_secrets":    {"repo", "admin:org"},
+	"set_secret":      {"repo", "admin:org"},
+	"delete_secret":   {"repo", "admin:org"},
+	"list_variables":  {"repo", "admin:org"},
+	"set_variable":    {"repo", "admin:org"},
+	"delete_variable": {"repo", "admin:org"},
+
+	// Self-hosted runner tools
+	"list_runners":           {"repo", "admin:org"},
+	"list_runner_groups":     {"admin:org"},
+	"create_runner_token":    {"repo", "admin:org"},
+	"remove_runner":          {"repo", "admin:org"},
+	"remove_offline_runners": {"repo", "admin:org"},
+
+	// Actions cache tools
+	"list_caches":     {"repo"},
+	"get_cache_usage": {"repo", "admin:org"},
+	"delete_caches":   {"repo", "admin:org"},
+
+	// Branch tools
+	"list_branches": {"repo"},
+	"get_branch":    {"repo"},
+	"create_branch": {"repo"},
+	"delete_branch": {"repo"},
+	"rename_branch": {"repo"},
+
+	// Branch protection tools
+	"get_branch_protection":   {"repo"},
+	"apply_branch_protection": {"repo"},
+	"diff_branch_protection":  {"repo"},
+	"audit_branch_protection": {"repo", "read:org"},
+
+	// Ruleset tools
+	"list_rulesets": {"repo"},
+	"get_ruleset":   {"repo"},
+	"apply_ruleset": {"repo"},
+
+	// File tools
+	"get_file_content":    {"repo"},
+	"create_file":         {"repo", "workflow"},
+	"update_file":         {"repo", "workflow"},
+	"delete_file":         {"repo", "workflow"},
+	"commit_changes":      {"repo", "workflow"},
+	"get_repository_tree": {"repo"},
+	"edit_file":           {"repo", "workflow"},
+
+	// Search tools
+	"search_code":   {"repo"},
+	"search_issues": {"repo"},
+}
+
+// requiredScopes returns the OAuth scopes needed by the registered tools
+func requiredScopes(tools map[string]ToolHandler) []string {
+	seen := make(map[string]bool)
+	var scopes []string
+	for name := range tools {
+		for _, scope := range toolScopes[name] {
+			if !seen[scope] {
+				seen[scope] = true
+				scopes = append(scopes, scope)
+			}
+		}
+	}
+	sort.Strings(scopes)
+
+	return scopes
+}
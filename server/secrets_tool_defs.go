@@ -0,0 +1,128 @@
+package server
+
+import "github-mcp-server-go/protocol"
+
+// secretScopeProperties returns the schema properties selecting a
+// repository, environment or organization
+func secretScopeProperties() map[string]protocol.Property {
+	properties := repoProperties()
+	properties["environment"] = protocol.Property{
+		Type:        "string",
+		Description: "Deployment environment of the repository (optional)",
+	}
+	properties["org"] = protocol.Property{
+		Type:        "string",
+		Description: "Organization, instead of owner and repo, for organization-level entries (needs the admin:org scope)",
+	}
+	return properties
+}
+
+// namedSecretScopeProperties adds the name of a secret or variable to the
+// scope properties
+func namedSecretScopeProperties(description string) map[string]protocol.Property {
+	properties := secretScopeProperties()
+	properties["name"] = protocol.Property{
+		Type:        "string",
+		Description: description,
+	}
+	return properties
+}
+
+// visibilityProperty describes the visibility of organization entries
+func visibilityProperty() protocol.Property {
+	return protocol.Property{
+		Type:        "string",
+		Description: "Which repositories of the organization can use the entry (organization only, defaults to private)",
+		Enum:        []string{"all", "private"},
+	}
+}
+
+// listSecretsToolDef returns the definition for the list_secrets tool
+func listSecretsToolDef() *protocol.Tool {
+	return &protocol.Tool{
+		Name:        "list_secrets",
+		Description: "List the Actions secrets of a repository, environment or organization. Values are never returned",
+		Schema: protocol.ToolSchema{
+			Type:       "object",
+			Properties: paginate(secretScopeProperties()),
+		},
+	}
+}
+
+// setSecretToolDef returns the definition for the set_secret tool
+func setSecretToolDef() *protocol.Tool {
+	properties := namedSecretScopeProperties("Secret name")
+	properties["value"] = protocol.Property{
+		Type:        "string",
+		Description: "Secret value; encrypted with the scope's public key before it is sent",
+	}
+	properties["visibility"] = visibilityProperty()
+
+	return &protocol.Tool{
+		Name:        "set_secret",
+		Description: "Create or update an Actions secret of a repository, environment or organization",
+		Schema: protocol.ToolSchema{
+			Type:       "object",
+			Properties: properties,
+			Required:   []string{"name", "value"},
+		},
+	}
+}
+
+// deleteSecretToolDef returns the definition for the delete_secret tool
+func deleteSecretToolDef() *protocol.Tool {
+	return &protocol.Tool{
+		Name:        "delete_secret",
+		Description: "Delete an Actions secret of a repository, environment or organization",
+		Schema: protocol.ToolSchema{
+			Type:       "object",
+			Properties: namedSecretScopeProperties("Secret name"),
+			Required:   []string{"name"},
+		},
+	}
+}
+
+// listVariablesToolDef returns the definition for the list_variables tool
+func listVariablesToolDef() *protocol.Tool {
+	return &protocol.Tool{
+		Name:        "list_variables",
+		Description: "List the Actions variables of a repository, environment or organization with their values",
+		Schema: protocol.ToolSchema{
+			Type:       "object",
+			Properties: paginate(secretScopeProperties()),
+		},
+	}
+}
+
+// setVariableToolDef returns the definition for the set_variable tool
+func setVariableToolDef() *protocol.Tool {
+	properties := namedSecretScopeProperties("Variable name")
+	properties["value"] = protocol.Property{
+		Type:        "string",
+		Description: "Variable value",
+	}
+	properties["visibility"] = visibilityProperty()
+
+	return &protocol.Tool{
+		Name:        "set_variable",
+		Description: "Create or update an Actions variable of a repository, environment or organization",
+		Schema: protocol.ToolSchema{
+			Type:       "object",
+			Properties: properties,
+			Required:   []string{"name", "value"},
+		},
+	}
+}
+
+// deleteVariableToolDef returns the definition for the delete_variable tool
+func deleteVariableToolDef() *protocol.Tool {
+	return &protocol.Tool{
+		Name:        "delete_variable",
+		Description: "Delete an Actions variable of a repository, environment or organization",
+		Schema: protocol.ToolSchema{
+			Type:       "object",
+			Properties: namedSecretScopeProperties("Variable name"),
+			Required:   []string{"name"},
+		},
+	}
+}
@@ -0,0 +1,230 @@
+package server
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"github-mcp-server-go/github"
+	"github-mcp-server-go/protocol"
+)
+
+// registerSecretsTools registers Actions secret and variable tools
+func (s *Server) registerSecretsTools() {
+	s.tools["list_secrets"] = s.handleListSecrets
+	s.tools["set_secret"] = s.handleSetSecret
+	s.tools["delete_secret"] = s.handleDeleteSecret
+	s.tools["list_variables"] = s.handleListVariables
+	s.tools["set_variable"] = s.handleSetVariable
+	s.tools["delete_variable"] = s.handleDeleteVariable
+}
+
+// secretScopeArgs returns the repository, environment or organization named
+// by the arguments
+func secretScopeArgs(args map[string]interface{}) (github.ActionsScope, error) {
+	if org := optionalString(args, "org", ""); org != "" {
+		if optionalString(args, "owner", "") != "" || optionalString(args, "repo", "") != "" ||
+			optionalString(args, "environment", "") != "" {
+			return github.ActionsScope{}, fmt.Errorf("org cannot be combined with owner, repo or environment")
+		}
+		return github.ActionsScope{Org: org}, nil
+	}
+
+	owner, repo, err := requireRepo(args)
+	if err != nil {
+		return github.ActionsScope{}, fmt.Errorf("either org or owner and repo are required: %w", err)
+	}
+
+	return github.ActionsScope{
+		Owner:       owner,
+		Repo:        repo,
+		Environment: optionalString(args, "environment", ""),
+	}, nil
+}
+
+// visibilityArg returns the visibility of an organization secret or
+// variable; it does not apply to repositories and environments
+func visibilityArg(args map[string]interface{}, scope github.ActionsScope) (string, error) {
+	visibility := optionalString(args, "visibility", "")
+	if scope.Org == "" {
+		if visibility != "" {
+			return "", fmt.Errorf("visibility only applies to organization secrets and variables")
+		}
+		return "", nil
+	}
+
+	switch visibility {
+	case "":
+		return "private", nil
+	case "all", "private":
+		return visibility, nil
+	default:
+		return "", fmt.Errorf("visibility must be all or private")
+	}
+}
+
+// handleListSecrets handles the list_secrets tool
+func (s *Server) handleListSecrets(ctx context.Context, args map[string]interface{}) (*protocol.CallToolResult, error) {
+	scope, err := secretScopeArgs(args)
+	if err != nil {
+		return nil, err
+	}
+	opts, err := listOptionsArgs(args)
+	if err != nil {
+		return nil, err
+	}
+
+	secrets, _, err := s.client.ListSecrets(ctx, scope, opts)
+	if err != nil {
+		return errorResult("Failed to list secrets: %v", err), nil
+	}
+
+	return jsonResult(secrets)
+}
+
+// handleSetSecret handles the set_secret tool. The value is encrypted with
+// the scope's public key before it leaves the server and is never echoed.
+func (s *Server) handleSetSecret(ctx context.Context, args map[string]interface{}) (*protocol.CallToolResult, error) {
+	scope, err := secretScopeArgs(args)
+	if err != nil {
+		return nil, err
+	}
+	name, err := requireString(args, "name")
+	if err != nil {
+		return nil, err
+	}
+	value, ok := args["value"].(string)
+	if !ok {
+		return nil, fmt.Errorf("value is required and must be a string")
+	}
+	visibility, err := visibilityArg(args, scope)
+	if err != nil {
+		return nil, err
+	}
+
+	key, err := s.client.GetSecretsPublicKey(ctx, scope)
+	if err != nil {
+		return errorResult("Failed to get public key: %v", err), nil
+	}
+
+	encrypted, err := github.EncryptSecret(key, value)
+	if err != nil {
+		return errorResult("Failed to encrypt secret: %v", err), nil
+	}
+
+	err = s.client.CreateOrUpdateSecret(ctx, scope, name, &github.EncryptedSecret{
+		EncryptedValue: encrypted,
+		KeyID:          key.KeyID,
+		Visibility:     visibility,
+	})
+	if err != nil {
+		return errorResult("Failed to set secret: %v", err), nil
+	}
+
+	return &protocol.CallToolResult{
+		Content: []protocol.Content{
+			protocol.TextContent(fmt.Sprintf("Secret %s set in %s", name, scope)),
+		},
+	}, nil
+}
+
+// handleDeleteSecret handles the delete_secret tool
+func (s *Server) handleDeleteSecret(ctx context.Context, args map[string]interface{}) (*protocol.CallToolResult, error) {
+	scope, err := secretScopeArgs(args)
+	if err != nil {
+		return nil, err
+	}
+	name, err := requireString(args, "name")
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.client.DeleteSecret(ctx, scope, name); err != nil {
+		return errorResult("Failed to delete secret: %v", err), nil
+	}
+
+	return &protocol.CallToolResult{
+		Content: []protocol.Content{
+			protocol.TextContent(fmt.Sprintf("Secret %s deleted from %s", name, scope)),
+		},
+	}, nil
+}
+
+// handleListVariables handles the list_variables tool
+func (s *Server) handleListVariables(ctx context.Context, args map[string]interface{}) (*protocol.CallToolResult, error) {
+	scope, err := secretScopeArgs(args)
+	if err != nil {
+		return nil, err
+	}
+	opts, err := listOptionsArgs(args)
+	if err != nil {
+		return nil, err
+	}
+
+	variables, _, err := s.client.ListVariables(ctx, scope, opts)
+	if err != nil {
+		return errorResult("Failed to list variables: %v", err), nil
+	}
+
+	return jsonResult(variables)
+}
+
+// handleSetVariable handles the set_variable tool, updating the variable
+// or creating it when it does not exist yet
+func (s *Server) handleSetVariable(ctx context.Context, args map[string]interface{}) (*protocol.CallToolResult, error) {
+	scope, err := secretScopeArgs(args)
+	if err != nil {
+		return nil, err
+	}
+	name, err := requireString(args, "name")
+	if err != nil {
+		return nil, err
+	}
+	value, ok := args["value"].(string)
+	if !ok {
+		return nil, fmt.Errorf("value is required and must be a string")
+	}
+	visibility, err := visibilityArg(args, scope)
+	if err != nil {
+		return nil, err
+	}
+
+	variable := &github.VariableRequest{Name: name, Value: value, Visibility: visibility}
+	action := "updated"
+	err = s.client.UpdateVariable(ctx, scope, variable)
+	if github.IsStatus(err, http.StatusNotFound) {
+		action = "created"
+		err = s.client.CreateVariable(ctx, scope, variable)
+	}
+	if err != nil {
+		return errorResult("Failed to set variable: %v", err), nil
+	}
+
+	return &protocol.CallToolResult{
+		Content: []protocol.Content{
+			protocol.TextContent(fmt.Sprintf("Variable %s %s in %s", name, action, scope)),
+		},
+	}, nil
+}
+
+// handleDeleteVariable handles the delete_variable tool
+func (s *Server) handleDeleteVariable(ctx context.Context, args map[string]interface{}) (*protocol.CallToolResult, error) {
+	scope, err := secretScopeArgs(args)
+	if err != nil {
+		return nil, err
+	}
+	name, err := requireString(args, "name")
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.client.DeleteVariable(ctx, scope, name); err != nil {
+		return errorResult("Failed to delete variable: %v", err), nil
+	}
+
+	return &protocol.CallToolResult{
+		Content: []protocol.Content{
+			protocol.TextContent(fmt.Sprintf("Variable %s deleted from %s", name, scope)),
+		},
+	}, nil
+}
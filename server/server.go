@@ -13,14 +13,29 @@ import (
 	"github-mcp-server-go/config"
 	"github-mcp-server-go/github"
 	"github-mcp-server-go/protocol"
+	"github-mcp-server-go/storage"
 	"github-mcp-server-go/transport"
 )
 
 // Config represents the server configuration
 type Config struct {
-	// GitHub Personal Access Token
+	// GitHub Personal Access Token from the -token flag or environment
 	Token string
 
+	// Auth is used when no token is available, e.g. a GitHub App
+	Auth github.AuthProvider
+
+	// OAuth app client ID used for device flow login
+	OAuthClientID string
+
+	// TokenStore selects the backend for stored login tokens
+	TokenStore storage.StoreConfig
+
+	// LintWorkflows lints workflow files written by create_file,
+	// update_file, commit_changes and edit_file and refuses to commit files
+	// with errors
+	LintWorkflows bool
+
 	// Logger for server logs
 	Logger *log.Logger
 
@@ -37,6 +52,8 @@ type Server struct {
 	initialized bool
 	client      *github.Client
 	mu          sync.Mutex
+	// transport the server is serving, used for notifications
+	transport transport.Transport
 	// registry of supported operations
 	tools map[string]ToolHandler
 	// authentication tool
@@ -80,12 +97,13 @@ func New(config Config) *Server {
 
 // Serve starts the server with the given transport
 func (s *Server) Serve(ctx context.Context, t transport.Transport) error {
-	// Initialize GitHub client
-	s.client = github.NewClient(s.config.Token)
-
 	// Register tools
 	s.registerTools()
 
+	// Initialize GitHub client
+	s.client = github.NewClientWithAuth(s.clientAuth())
+	s.transport = t
+
 	// Main message handling loop
 	for {
 		select {
@@ -104,7 +122,7 @@ func (s *Server) Serve(ctx context.Context, t transport.Transport) error {
 			if err := json.Unmarshal(msg, &request); err != nil {
 				s.config.Logger.Printf("Error parsing message: %v", err)
 				response := protocol.NewErrorResponse(nil, protocol.ParseError, "Invalid JSON", nil)
-				if err := s.sendResponse(ctx, t, response); err != nil {
+				if err := s.sendResponse(ctx, t, nil, response); err != nil {
 					s.config.Logger.Printf("Error sending response: %v", err)
 				}
 				continue
@@ -113,7 +131,7 @@ func (s *Server) Serve(ctx context.Context, t transport.Transport) error {
 			// Handle message
 			go func() {
 				response := s.HandleRequest(ctx, &request)
-				if err := s.sendResponse(ctx, t, response); err != nil {
+				if err := s.sendResponse(ctx, t, &request, response); err != nil {
 					s.config.Logger.Printf("Error sending response: %v", err)
 				}
 			}()
@@ -121,11 +139,32 @@ func (s *Server) Serve(ctx context.Context, t transport.Transport) error {
 	}
 }
 
-// sendResponse sends a response message
-func (s *Server) sendResponse(ctx context.Context, t transport.Transport, response *protocol.Message) error {
+// clientAuth builds the auth provider for the GitHub client. The token is
+// resolved on every request in this order of precedence: a token stored with
+// the auth tools, then the -token flag or GITHUB_PERSONAL_ACCESS_TOKEN
+// (Config.Token), then the GitHub App credentials (Config.Auth). Logging in
+// or out therefore takes effect immediately.
+func (s *Server) clientAuth() github.AuthProvider {
+	var providers github.ChainTokenProvider
+	if s.authTool != nil {
+		providers = append(providers, s.authTool)
+	}
+	if s.config.Token != "" {
+		providers = append(providers, github.TokenAuth(s.config.Token))
+	}
+
+	return &github.TokenProviderAuth{
+		Provider: providers,
+		Fallback: s.config.Auth,
+	}
+}
+
+// sendResponse sends a response message. request is the message being
+// answered, or nil for notifications and unparseable messages.
+func (s *Server) sendResponse(ctx context.Context, t transport.Transport, request, response *protocol.Message) error {
 	// Debug log response
 	if s.config.Debug {
-		respJSON, _ := json.Marshal(response)
+		respJSON, _ := json.Marshal(redactResponse(request, response))
 		s.config.Logger.Printf("Response: %s", string(respJSON))
 	}
 
@@ -143,7 +182,7 @@ func (s *Server) sendResponse(ctx context.Context, t transport.Transport, respon
 func (s *Server) HandleRequest(ctx context.Context, request *protocol.Message) *protocol.Message {
 	// Debug log request
 	if s.config.Debug {
-		reqJSON, _ := json.Marshal(request)
+		reqJSON, _ := json.Marshal(redactRequest(request))
 		s.config.Logger.Printf("Request: %s", string(reqJSON))
 	}
 
@@ -222,6 +261,9 @@ func (s *Server) handleListTools(ctx context.Context, request *protocol.Message)
 		// Get tool definition from registry
 		tool := getToolDefinition(name)
 		if tool != nil {
+			if usesGitHubAccount(name) {
+				addAccountProperty(tool)
+			}
 			tools = append(tools, *tool)
 		}
 	}
@@ -250,6 +292,19 @@ func (s *Server) handleCallTool(ctx context.Context, request *protocol.Message)
 		return protocol.NewErrorResponse(request.ID, protocol.ToolNotFound, "Tool not found", nil)
 	}
 
+	// Run the call as another stored account when one is named
+	if account, ok := params.Arguments["account"].(string); ok && account != "" {
+		if err := auth.ValidateAccountName(account); err != nil {
+			return protocol.NewErrorResponse(request.ID, protocol.InvalidParams, err.Error(), nil)
+		}
+		ctx = auth.WithAccount(ctx, account)
+	}
+
+	// Report progress when the client sent a progress token
+	if params.Meta != nil && params.Meta.ProgressToken != nil && s.transport != nil {
+		ctx = withProgress(ctx, s.progressNotifier(ctx, params.Meta.ProgressToken))
+	}
+
 	// Call tool
 	result, err := handler(ctx, params.Arguments)
 	if err != nil {
@@ -276,14 +331,98 @@ func (s *Server) registerTools() {
 	// Register pull request tools
 	s.registerPullRequestTools()
 
+	// Register pull request review tools
+	s.registerReviewTools()
+
 	// Register GitHub Actions tools
 	s.registerActionsTools()
 
+	// Register Actions secret and variable tools
+	s.registerSecretsTools()
+
+	// Register self-hosted runner and Actions cache tools
+	s.registerRunnerTools()
+	s.registerCacheTools()
+
+	// Register branch, protection and ruleset tools
+	s.registerBranchTools()
+
 	// Register file tools
 	s.registerFileTools()
 
 	// Register search tools
 	s.registerSearchTools()
+
+	// Let auth status warn about scopes the registered tools need
+	if s.authTool != nil {
+		s.authTool.SetRequiredScopes(requiredScopes(s.tools))
+	}
+}
+
+// sensitiveArguments lists the tool arguments that must never be logged
+var sensitiveArguments = map[string][]string{
+	"auth_login_token": {"token"},
+	"set_secret":       {"value"},
+}
+
+// sensitiveResults lists the tools whose results must never be logged
+var sensitiveResults = map[string]bool{
+	"create_runner_token": true,
+}
+
+// callToolParams returns the parameters of a tools/call request, and false
+// for other requests
+func callToolParams(request *protocol.Message) (*protocol.CallToolParams, bool) {
+	if request == nil || request.Method != "tools/call" {
+		return nil, false
+	}
+
+	var params protocol.CallToolParams
+	if err := parseParams(request.Params, &params); err != nil {
+		return nil, false
+	}
+	return &params, true
+}
+
+// redactRequest returns a copy of a tools/call request with its sensitive
+// arguments replaced, for logging
+func redactRequest(request *protocol.Message) *protocol.Message {
+	params, ok := callToolParams(request)
+	if !ok {
+		return request
+	}
+	names, ok := sensitiveArguments[params.Name]
+	if !ok {
+		return request
+	}
+
+	arguments := make(map[string]interface{}, len(params.Arguments))
+	for name, value := range params.Arguments {
+		arguments[name] = value
+	}
+	for _, name := range names {
+		if _, ok := arguments[name]; ok {
+			arguments[name] = "[REDACTED]"
+		}
+	}
+	params.Arguments = arguments
+
+	redacted := *request
+	redacted.Params = params
+	return &redacted
+}
+
+// redactResponse returns a copy of the response to a tools/call request
+// with its result replaced if the tool's results are sensitive, for logging
+func redactResponse(request, response *protocol.Message) *protocol.Message {
+	params, ok := callToolParams(request)
+	if !ok || !sensitiveResults[params.Name] || response.Result == nil {
+		return response
+	}
+
+	redacted := *response
+	redacted.Result = "[REDACTED]"
+	return &redacted
 }
 
 // Helper function to parse request parameters
@@ -10,10 +10,16 @@ func getToolDefinition(name string) *protocol.Tool {
 	// Authentication tools
 	case "auth_login_token":
 		return loginWithTokenToolDef()
+	case "auth_login_device":
+		return loginWithDeviceToolDef()
 	case "auth_logout":
 		return logoutToolDef()
 	case "auth_status":
 		return getAuthStatusToolDef()
+	case "auth_list_accounts":
+		return listAccountsToolDef()
+	case "auth_switch":
+		return switchAccountToolDef()
 
 	// Configuration tools
 	case "config_get":
@@ -62,6 +68,42 @@ func getToolDefinition(name string) *protocol.Tool {
 		return createPullRequestToolDef()
 	case "merge_pull_request":
 		return mergePullRequestToolDef()
+	case "get_pull_request_diff":
+		return getPullRequestDiffToolDef()
+	case "get_pull_request_checks":
+		return getPullRequestChecksToolDef()
+	case "update_pull_request":
+		return updatePullRequestToolDef()
+	case "request_reviewers":
+		return requestReviewersToolDef()
+	case "convert_pull_request_to_draft":
+		return convertToDraftToolDef()
+	case "mark_pull_request_ready_for_review":
+		return markReadyForReviewToolDef()
+	case "update_pull_request_branch":
+		return updatePullRequestBranchToolDef()
+	case "close_pull_request":
+		return closePullRequestToolDef()
+	case "reopen_pull_request":
+		return reopenPullRequestToolDef()
+	case "enable_auto_merge":
+		return enableAutoMergeToolDef()
+	case "disable_auto_merge":
+		return disableAutoMergeToolDef()
+
+	// Pull request review tools
+	case "list_pull_request_reviews":
+		return listReviewsToolDef()
+	case "list_review_comments":
+		return listReviewCommentsToolDef()
+	case "create_pull_request_review":
+		return createReviewToolDef()
+	case "submit_pull_request_review":
+		return submitReviewToolDef()
+	case "dismiss_pull_request_review":
+		return dismissReviewToolDef()
+	case "reply_to_review_comment":
+		return replyToReviewCommentToolDef()
 
 	// GitHub Actions tools
 	case "list_workflows":
@@ -70,6 +112,92 @@ func getToolDefinition(name string) *protocol.Tool {
 		return listWorkflowRunsToolDef()
 	case "trigger_workflow":
 		return triggerWorkflowToolDef()
+	case "get_workflow_run_failure":
+		return getWorkflowRunFailureToolDef()
+	case "get_workflow_run":
+		return getWorkflowRunToolDef()
+	case "list_workflow_jobs":
+		return listWorkflowJobsToolDef()
+	case "cancel_workflow_run":
+		return cancelWorkflowRunToolDef()
+	case "rerun_workflow_run":
+		return rerunWorkflowRunToolDef()
+	case "list_workflow_run_artifacts":
+		return listWorkflowRunArtifactsToolDef()
+	case "download_artifact":
+		return downloadArtifactToolDef()
+	case "watch_workflow_run":
+		return watchWorkflowRunToolDef()
+	case "get_workflow_inputs":
+		return getWorkflowInputsToolDef()
+	case "analyze_workflow_runs":
+		return analyzeWorkflowRunsToolDef()
+	case "lint_workflow":
+		return lintWorkflowToolDef()
+
+	// Actions secret and variable tools
+	case "list_secrets":
+		return listSecretsToolDef()
+	case "set_secret":
+		return setSecretToolDef()
+	case "delete_secret":
+		return deleteSecretToolDef()
+	case "list_variables":
+		return listVariablesToolDef()
+	case "set_variable":
+		return setVariableToolDef()
+	case "delete_variable":
+		return deleteVariableToolDef()
+
+	// Self-hosted runner tools
+	case "list_runners":
+		return listRunnersToolDef()
+	case "list_runner_groups":
+		return listRunnerGroupsToolDef()
+	case "create_runner_token":
+		return createRunnerTokenToolDef()
+	case "remove_runner":
+		return removeRunnerToolDef()
+	case "remove_offline_runners":
+		return removeOfflineRunnersToolDef()
+
+	// Actions cache tools
+	case "list_caches":
+		return listCachesToolDef()
+	case "get_cache_usage":
+		return getCacheUsageToolDef()
+	case "delete_caches":
+		return deleteCachesToolDef()
+
+	// Branch tools
+	case "list_branches":
+		return listBranchesToolDef()
+	case "get_branch":
+		return getBranchToolDef()
+	case "create_branch":
+		return createBranchToolDef()
+	case "delete_branch":
+		return deleteBranchToolDef()
+	case "rename_branch":
+		return renameBranchToolDef()
+
+	// Branch protection tools
+	case "get_branch_protection":
+		return getBranchProtectionToolDef()
+	case "apply_branch_protection":
+		return applyBranchProtectionToolDef()
+	case "diff_branch_protection":
+		return diffBranchProtectionToolDef()
+	case "audit_branch_protection":
+		return auditBranchProtectionToolDef()
+
+	// Ruleset tools
+	case "list_rulesets":
+		return listRulesetsToolDef()
+	case "get_ruleset":
+		return getRulesetToolDef()
+	case "apply_ruleset":
+		return applyRulesetToolDef()
 
 	// File tools
 	case "get_file_content":
@@ -80,6 +208,12 @@ func getToolDefinition(name string) *protocol.Tool {
 		return updateFileToolDef()
 	case "delete_file":
 		return deleteFileToolDef()
+	case "get_repository_tree":
+		return getRepositoryTreeToolDef()
+	case "commit_changes":
+		return commitChangesToolDef()
+	case "edit_file":
+		return editFileToolDef()
 
 	// Search tools
 	case "search_code":
@@ -410,7 +544,7 @@ func createPullRequestToolDef() *protocol.Tool {
 func mergePullRequestToolDef() *protocol.Tool {
 	return &protocol.Tool{
 		Name:        "merge_pull_request",
-		Description: "Merge a pull request",
+		Description: "Merge a pull request after checking it is open, not a draft, free of conflicts and not blocked by required status checks or reviews",
 		Schema: protocol.ToolSchema{
 			Type: "object",
 			Properties: map[string]protocol.Property{
@@ -440,6 +574,15 @@ func mergePullRequestToolDef() *protocol.Tool {
 					Enum:        []string{"merge", "squash", "rebase"},
 					Default:     "merge",
 				},
+				"sha": {
+					Type:        "string",
+					Description: "Expected head commit SHA; the merge is refused if the head branch has moved",
+				},
+				"delete_branch": {
+					Type:        "boolean",
+					Description: "Delete the head branch after merging (skipped for branches in forks)",
+					Default:     false,
+				},
 			},
 			Required: []string{"owner", "repo", "number"},
 		},
@@ -495,8 +638,8 @@ func listWorkflowRunsToolDef() *protocol.Tool {
 					Description: "Repository name",
 				},
 				"workflow_id": {
-					Type:        "number",
-					Description: "Workflow ID",
+					Type:        "string",
+					Description: "Workflow ID, file name (e.g. deploy.yml) or name",
 				},
 				"page": {
 					Type:        "number",
@@ -530,8 +673,8 @@ func triggerWorkflowToolDef() *protocol.Tool {
 					Description: "Repository name",
 				},
 				"workflow_id": {
-					Type:        "number",
-					Description: "Workflow ID",
+					Type:        "string",
+					Description: "Workflow ID, file name (e.g. deploy.yml) or name",
 				},
 				"ref": {
 					Type:        "string",
@@ -539,7 +682,7 @@ func triggerWorkflowToolDef() *protocol.Tool {
 				},
 				"inputs": {
 					Type:        "object",
-					Description: "Workflow inputs",
+					Description: "Workflow inputs, checked against the workflow's workflow_dispatch inputs (see get_workflow_inputs)",
 				},
 			},
 			Required: []string{"owner", "repo", "workflow_id", "ref"},
@@ -552,7 +695,7 @@ func triggerWorkflowToolDef() *protocol.Tool {
 func getFileContentToolDef() *protocol.Tool {
 	return &protocol.Tool{
 		Name:        "get_file_content",
-		Description: "Get the content of a file in a repository",
+		Description: "Get the content of a file in a repository, or list a directory. Files of any size can be read by byte or line range; binary files are returned as embedded resources with a MIME type.",
 		Schema: protocol.ToolSchema{
 			Type: "object",
 			Properties: map[string]protocol.Property{
@@ -572,6 +715,27 @@ func getFileContentToolDef() *protocol.Tool {
 					Type:        "string",
 					Description: "Git reference (branch, tag, or SHA)",
 				},
+				"offset": {
+					Type:        "number",
+					Description: "Byte offset to start reading at",
+				},
+				"length": {
+					Type:        "number",
+					Description: "Number of bytes to read (at most max_bytes)",
+				},
+				"start_line": {
+					Type:        "number",
+					Description: "First line to return (1-based) for text files",
+				},
+				"end_line": {
+					Type:        "number",
+					Description: "Last line to return (inclusive) for text files",
+				},
+				"max_bytes": {
+					Type:        "number",
+					Description: "Maximum number of bytes to return when no line range is given; larger files are truncated",
+					Default:     defaultMaxFileBytes,
+				},
 			},
 			Required: []string{"owner", "repo", "path"},
 		},
@@ -609,6 +773,10 @@ func createFileToolDef() *protocol.Tool {
 					Type:        "string",
 					Description: "Branch to commit to (default: repository's default branch)",
 				},
+				"skip_lint": {
+					Type:        "boolean",
+					Description: "Commit a workflow file even if lint_workflow reports errors (when workflow linting is enabled)",
+				},
 			},
 			Required: []string{"owner", "repo", "path", "content", "message"},
 		},
@@ -650,6 +818,10 @@ func updateFileToolDef() *protocol.Tool {
 					Type:        "string",
 					Description: "Branch to commit to (default: repository's default branch)",
 				},
+				"skip_lint": {
+					Type:        "boolean",
+					Description: "Commit a workflow file even if lint_workflow reports errors (when workflow linting is enabled)",
+				},
 			},
 			Required: []string{"owner", "repo", "path", "content", "message", "sha"},
 		},
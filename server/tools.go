@@ -6,9 +6,11 @@ import (
 	"encoding/base64"
 	"fmt"
 	"strconv"
+	"time"
 
 	"github-mcp-server-go/github"
 	"github-mcp-server-go/protocol"
+	"github-mcp-server-go/workflow"
 )
 
 // registerRepositoryTools registers repository-related tools
@@ -51,6 +53,33 @@ func (s *Server) registerPullRequestTools() {
 
 	// Merge pull request
 	s.tools["merge_pull_request"] = s.handleMergePullRequest
+
+	// Get pull request diff
+	s.tools["get_pull_request_diff"] = s.handleGetPullRequestDiff
+
+	// Get pull request checks
+	s.tools["get_pull_request_checks"] = s.handleGetPullRequestChecks
+
+	// Update pull request
+	s.tools["update_pull_request"] = s.handleUpdatePullRequest
+
+	// Request reviewers
+	s.tools["request_reviewers"] = s.handleRequestReviewers
+
+	// Toggle draft state
+	s.tools["convert_pull_request_to_draft"] = s.handleSetPullRequestDraft(true)
+	s.tools["mark_pull_request_ready_for_review"] = s.handleSetPullRequestDraft(false)
+
+	// Update pull request branch from base
+	s.tools["update_pull_request_branch"] = s.handleUpdatePullRequestBranch
+
+	// Close and reopen pull request
+	s.tools["close_pull_request"] = s.handleSetPullRequestState("closed")
+	s.tools["reopen_pull_request"] = s.handleSetPullRequestState("open")
+
+	// Auto-merge
+	s.tools["enable_auto_merge"] = s.handleEnableAutoMerge
+	s.tools["disable_auto_merge"] = s.handleDisableAutoMerge
 }
 
 // registerActionsTools registers GitHub Actions-related tools
@@ -63,6 +92,31 @@ func (s *Server) registerActionsTools() {
 
 	// Trigger workflow
 	s.tools["trigger_workflow"] = s.handleTriggerWorkflow
+
+	// Explain workflow run failures
+	s.tools["get_workflow_run_failure"] = s.handleGetWorkflowRunFailure
+
+	// Workflow run details and control
+	s.tools["get_workflow_run"] = s.handleGetWorkflowRun
+	s.tools["list_workflow_jobs"] = s.handleListWorkflowJobs
+	s.tools["cancel_workflow_run"] = s.handleCancelWorkflowRun
+	s.tools["rerun_workflow_run"] = s.handleRerunWorkflowRun
+
+	// Artifacts
+	s.tools["list_workflow_run_artifacts"] = s.handleListWorkflowRunArtifacts
+	s.tools["download_artifact"] = s.handleDownloadArtifact
+
+	// Watch a run until it completes
+	s.tools["watch_workflow_run"] = s.handleWatchWorkflowRun
+
+	// Workflow dispatch inputs
+	s.tools["get_workflow_inputs"] = s.handleGetWorkflowInputs
+
+	// Flakiness and duration analytics
+	s.tools["analyze_workflow_runs"] = s.handleAnalyzeWorkflowRuns
+
+	// Lint workflow files offline
+	s.tools["lint_workflow"] = s.handleLintWorkflow
 }
 
 // registerFileTools registers file-related tools
@@ -78,6 +132,15 @@ func (s *Server) registerFileTools() {
 
 	// Delete file
 	s.tools["delete_file"] = s.handleDeleteFile
+
+	// Browse the repository tree
+	s.tools["get_repository_tree"] = s.handleGetRepositoryTree
+
+	// Commit several file changes at once
+	s.tools["commit_changes"] = s.handleCommitChanges
+
+	// Edit part of a file
+	s.tools["edit_file"] = s.handleEditFile
 }
 
 // registerSearchTools registers search-related tools
@@ -262,7 +325,7 @@ func (s *Server) handleGetFileContent(ctx context.Context, args map[string]inter
 	}
 
 	// Get file content
-	content, err := s.client.GetContent(ctx, owner, repo, path, ref)
+	content, entries, err := s.client.GetContents(ctx, owner, repo, path, ref)
 	if err != nil {
 		return &protocol.CallToolResult{
 			Content: []protocol.Content{
@@ -271,35 +334,14 @@ func (s *Server) handleGetFileContent(ctx context.Context, args map[string]inter
 		}, nil
 	}
 
-	// Decode content if it's base64 encoded
-	decodedContent := content.Content
-	if content.Encoding == "base64" {
-		bytes, err := base64.StdEncoding.DecodeString(content.Content)
-		if err != nil {
-			return &protocol.CallToolResult{
-				Content: []protocol.Content{
-					protocol.ErrorContent(fmt.Sprintf("Failed to decode content: %v", err)),
-				},
-			}, nil
-		}
-		decodedContent = string(bytes)
+	// List directories
+	if content == nil {
+		return jsonResult(directoryListing(path, entries))
 	}
 
-	// Format result
-	result := fmt.Sprintf(`{
-	"name": "%s",
-	"path": "%s",
-	"sha": "%s",
-	"size": %d,
-	"type": "%s",
-	"content": %s
-}`, content.Name, content.Path, content.SHA, content.Size, content.Type, strconv.Quote(decodedContent))
-
-	return &protocol.CallToolResult{
-		Content: []protocol.Content{
-			protocol.TextContent(result),
-		},
-	}, nil
+	// Read the requested part of the file; GetContents has already decoded
+	// inline content, and larger files are fetched raw
+	return s.fileContentResult(ctx, owner, repo, ref, content, args)
 }
 
 // handleCreateFile handles the create_file tool
@@ -336,6 +378,11 @@ func (s *Server) handleCreateFile(ctx context.Context, args map[string]interface
 		branch = branchArg
 	}
 
+	// Refuse broken workflow files
+	if result := s.checkWorkflowFile(args, path, content); result != nil {
+		return result, nil
+	}
+
 	// Create file
 	req := &github.CreateFileRequest{
 		Message: message,
@@ -398,6 +445,11 @@ func (s *Server) handleUpdateFile(ctx context.Context, args map[string]interface
 		branch = branchArg
 	}
 
+	// Refuse broken workflow files
+	if result := s.checkWorkflowFile(args, path, content); result != nil {
+		return result, nil
+	}
+
 	// Update file
 	req := &github.UpdateFileRequest{
 		Message: message,
@@ -1010,15 +1062,129 @@ func (s *Server) handleCreatePullRequest(ctx context.Context, args map[string]in
 	}, nil
 }
 
+const (
+	// mergeabilityRetries is how often to re-check a pull request whose
+	// mergeability is still being computed
+	mergeabilityRetries = 3
+
+	// mergeabilityRetryDelay is the wait between mergeability checks
+	mergeabilityRetryDelay = time.Second
+)
+
+// mergeResult describes a merged pull request
+type mergeResult struct {
+	Merged         bool   `json:"merged"`
+	SHA            string `json:"sha"`
+	Message        string `json:"message"`
+	MergeMethod    string `json:"merge_method"`
+	MergeableState string `json:"mergeable_state"`
+	BranchDeleted  bool   `json:"branch_deleted"`
+	BranchMessage  string `json:"branch_message"`
+}
+
 // handleMergePullRequest handles the merge_pull_request tool
 func (s *Server) handleMergePullRequest(ctx context.Context, args map[string]interface{}) (*protocol.CallToolResult, error) {
-	// This would be implemented to merge a pull request
-	// Not implemented in this example
-	return &protocol.CallToolResult{
-		Content: []protocol.Content{
-			protocol.ErrorContent("Not implemented"),
-		},
-	}, nil
+	owner, repo, err := requireRepo(args)
+	if err != nil {
+		return nil, err
+	}
+	number, err := requireInt(args, "number")
+	if err != nil {
+		return nil, err
+	}
+
+	opts := &github.MergePullRequestOptions{
+		MergeMethod:   optionalString(args, "merge_method", "merge"),
+		CommitTitle:   optionalString(args, "commit_title", ""),
+		CommitMessage: optionalString(args, "commit_message", ""),
+		SHA:           optionalString(args, "sha", ""),
+	}
+	switch opts.MergeMethod {
+	case "merge", "squash", "rebase":
+	default:
+		return nil, fmt.Errorf("merge_method must be one of merge, squash or rebase")
+	}
+
+	// Check the pull request can be merged before attempting it
+	pr, err := s.waitForMergeability(ctx, owner, repo, int(number))
+	if err != nil {
+		return errorResult("Failed to get pull request: %v", err), nil
+	}
+	if opts.SHA != "" && opts.SHA != pr.Head.SHA {
+		return errorResult("Head branch has moved: expected %s, but %s is at %s", opts.SHA, pr.Head.Ref, pr.Head.SHA), nil
+	}
+	if reason := mergeBlocker(pr); reason != "" {
+		return errorResult("Pull request #%d cannot be merged: %s", number, reason), nil
+	}
+
+	merge, err := s.client.MergePullRequest(ctx, owner, repo, int(number), opts)
+	if err != nil {
+		return errorResult("Failed to merge pull request: %v", err), nil
+	}
+
+	result := &mergeResult{
+		Merged:         merge.Merged,
+		SHA:            merge.SHA,
+		Message:        merge.Message,
+		MergeMethod:    opts.MergeMethod,
+		MergeableState: pr.MergeableState,
+	}
+
+	// Delete the head branch if it lives in this repository
+	if optionalBool(args, "delete_branch") {
+		switch {
+		case pr.Head.Repo.FullName != pr.Base.Repo.FullName:
+			result.BranchMessage = "head branch is in a fork and was not deleted"
+		default:
+			if err := s.client.DeleteRef(ctx, owner, repo, "heads/"+pr.Head.Ref); err != nil {
+				result.BranchMessage = fmt.Sprintf("failed to delete head branch: %v", err)
+			} else {
+				result.BranchDeleted = true
+			}
+		}
+	}
+
+	return jsonResult(result)
+}
+
+// waitForMergeability gets a pull request, retrying briefly while GitHub
+// is still computing whether it can be merged
+func (s *Server) waitForMergeability(ctx context.Context, owner, repo string, number int) (*github.PullRequest, error) {
+	for attempt := 0; ; attempt++ {
+		pr, err := s.client.GetPullRequest(ctx, owner, repo, number)
+		if err != nil {
+			return nil, err
+		}
+		if pr.Mergeable != nil || pr.State != "open" || attempt >= mergeabilityRetries {
+			return pr, nil
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-time.After(mergeabilityRetryDelay):
+		}
+	}
+}
+
+// mergeBlocker explains why a pull request cannot be merged, or returns
+// an empty string if the merge can be attempted
+func mergeBlocker(pr *github.PullRequest) string {
+	switch {
+	case pr.Merged:
+		return "it is already merged"
+	case pr.State != "open":
+		return fmt.Sprintf("it is %s", pr.State)
+	case pr.Draft || pr.MergeableState == "draft":
+		return "it is a draft"
+	case pr.Mergeable != nil && !*pr.Mergeable, pr.MergeableState == "dirty":
+		return "it has merge conflicts with the base branch"
+	case pr.MergeableState == "blocked":
+		return "required status checks or reviews have not passed"
+	case pr.MergeableState == "behind":
+		return "the head branch is behind the base branch and must be updated"
+	}
+	return ""
 }
 
 // ============== GitHub Actions Tool Handlers ==============
@@ -1095,25 +1261,16 @@ func (s *Server) handleListWorkflowRuns(ctx context.Context, args map[string]int
 		return nil, fmt.Errorf("repo is required and must be a string")
 	}
 
-	// Parse workflow_id argument
-	var workflowID int64
-	switch w := args["workflow_id"].(type) {
-	case float64:
-		workflowID = int64(w)
-	case int64:
-		workflowID = w
-	case int:
-		workflowID = int64(w)
-	case string:
-		var err error
-		id, err := strconv.ParseInt(w, 10, 64)
-		if err != nil {
-			return nil, fmt.Errorf("workflow_id must be an integer: %v", err)
-		}
-		workflowID = id
-	default:
-		return nil, fmt.Errorf("workflow_id is required and must be an integer")
+	// Resolve workflow_id, which may also be a file or workflow name
+	wf, err := s.workflowArg(ctx, owner, repo, args)
+	if err != nil {
+		return &protocol.CallToolResult{
+			Content: []protocol.Content{
+				protocol.ErrorContent(fmt.Sprintf("Failed to find workflow: %v", err)),
+			},
+		}, nil
 	}
+	workflowID := wf.ID
 
 	// Parse optional arguments
 	page := 1
@@ -1179,25 +1336,16 @@ func (s *Server) handleTriggerWorkflow(ctx context.Context, args map[string]inte
 		return nil, fmt.Errorf("repo is required and must be a string")
 	}
 
-	// Parse workflow_id argument
-	var workflowID int64
-	switch w := args["workflow_id"].(type) {
-	case float64:
-		workflowID = int64(w)
-	case int64:
-		workflowID = w
-	case int:
-		workflowID = int64(w)
-	case string:
-		var err error
-		id, err := strconv.ParseInt(w, 10, 64)
-		if err != nil {
-			return nil, fmt.Errorf("workflow_id must be an integer: %v", err)
-		}
-		workflowID = id
-	default:
-		return nil, fmt.Errorf("workflow_id is required and must be an integer")
+	// Resolve workflow_id, which may also be a file or workflow name
+	wf, err := s.workflowArg(ctx, owner, repo, args)
+	if err != nil {
+		return &protocol.CallToolResult{
+			Content: []protocol.Content{
+				protocol.ErrorContent(fmt.Sprintf("Failed to find workflow: %v", err)),
+			},
+		}, nil
 	}
+	workflowID := wf.ID
 
 	ref, ok := args["ref"].(string)
 	if !ok || ref == "" {
@@ -1210,6 +1358,31 @@ func (s *Server) handleTriggerWorkflow(ctx context.Context, args map[string]inte
 		inputs = inputsArg
 	}
 
+	// Check the inputs against the workflow's declaration at the ref
+	declared, dispatchable, err := s.workflowInputs(ctx, owner, repo, wf, ref)
+	if err != nil {
+		return &protocol.CallToolResult{
+			Content: []protocol.Content{
+				protocol.ErrorContent(fmt.Sprintf("Failed to read workflow inputs: %v", err)),
+			},
+		}, nil
+	}
+	if !dispatchable {
+		return &protocol.CallToolResult{
+			Content: []protocol.Content{
+				protocol.ErrorContent(fmt.Sprintf("Workflow %s has no workflow_dispatch trigger on ref '%s'", wf.Path, ref)),
+			},
+		}, nil
+	}
+	inputs, err = workflow.ValidateInputs(declared, inputs)
+	if err != nil {
+		return &protocol.CallToolResult{
+			Content: []protocol.Content{
+				protocol.ErrorContent(fmt.Sprintf("Invalid workflow inputs: %v", err)),
+			},
+		}, nil
+	}
+
 	// Create request
 	req := &github.TriggerWorkflowRequest{
 		Ref:    ref,
@@ -1217,7 +1390,7 @@ func (s *Server) handleTriggerWorkflow(ctx context.Context, args map[string]inte
 	}
 
 	// Trigger workflow
-	err := s.client.TriggerWorkflow(ctx, owner, repo, workflowID, req)
+	err = s.client.TriggerWorkflow(ctx, owner, repo, workflowID, req)
 	if err != nil {
 		return &protocol.CallToolResult{
 			Content: []protocol.Content{
@@ -1228,7 +1401,7 @@ func (s *Server) handleTriggerWorkflow(ctx context.Context, args map[string]inte
 
 	return &protocol.CallToolResult{
 		Content: []protocol.Content{
-			protocol.TextContent(fmt.Sprintf("Workflow '%d' triggered successfully in repository '%s/%s' on ref '%s'", workflowID, owner, repo, ref)),
+			protocol.TextContent(fmt.Sprintf("Workflow '%s' (%d) triggered successfully in repository '%s/%s' on ref '%s'", wf.Name, workflowID, owner, repo, ref)),
 		},
 	}, nil
 }
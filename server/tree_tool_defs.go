@@ -0,0 +1,42 @@
+package server
+
+import "github-mcp-server-go/protocol"
+
+// getRepositoryTreeToolDef returns the definition for the get_repository_tree tool
+func getRepositoryTreeToolDef() *protocol.Tool {
+	properties := repoProperties()
+	properties["ref"] = protocol.Property{
+		Type:        "string",
+		Description: "Branch, tag or commit SHA (default: the default branch)",
+	}
+	properties["path"] = protocol.Property{
+		Type:        "string",
+		Description: "Only list entries below this directory",
+	}
+	properties["pattern"] = protocol.Property{
+		Type: "string",
+		Description: "Glob matched against paths relative to path, e.g. src/**/*.ts; ** matches any " +
+			"number of directories and a pattern without a slash matches file names at any depth",
+	}
+	properties["type"] = protocol.Property{
+		Type:        "string",
+		Description: "Only list files (blob) or directories (tree)",
+		Enum:        []string{"blob", "tree"},
+	}
+	properties["max_entries"] = protocol.Property{
+		Type:        "number",
+		Description: "Maximum number of entries to return",
+		Default:     defaultTreeMaxEntries,
+	}
+
+	return &protocol.Tool{
+		Name: "get_repository_tree",
+		Description: "List the files and directories of a repository recursively with their type, size and SHA, " +
+			"optionally filtered by directory and glob",
+		Schema: protocol.ToolSchema{
+			Type:       "object",
+			Properties: properties,
+			Required:   []string{"owner", "repo"},
+		},
+	}
+}
@@ -0,0 +1,120 @@
+package server
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github-mcp-server-go/github"
+	"github-mcp-server-go/protocol"
+)
+
+// defaultTreeMaxEntries bounds the entries get_repository_tree returns
+const defaultTreeMaxEntries = 1000
+
+// directoryEntry is an entry of a directory listing or repository tree
+type directoryEntry struct {
+	Name string `json:"name,omitempty"`
+	Path string `json:"path"`
+	Type string `json:"type"`
+	Size int    `json:"size,omitempty"`
+	SHA  string `json:"sha"`
+}
+
+// directoryListing summarizes the entries of a directory
+func directoryListing(path string, entries []*github.FileContent) map[string]interface{} {
+	listing := make([]*directoryEntry, 0, len(entries))
+	for _, entry := range entries {
+		listing = append(listing, &directoryEntry{
+			Name: entry.Name,
+			Path: entry.Path,
+			Type: entry.Type,
+			Size: entry.Size,
+			SHA:  entry.SHA,
+		})
+	}
+	return map[string]interface{}{
+		"path":    path,
+		"type":    "dir",
+		"entries": listing,
+	}
+}
+
+// treeResult is the result of get_repository_tree
+type treeResult struct {
+	Ref     string            `json:"ref"`
+	SHA     string            `json:"sha"`
+	Path    string            `json:"path,omitempty"`
+	Total   int               `json:"total"`
+	Entries []*directoryEntry `json:"entries"`
+
+	// Truncated is set when GitHub could not list the whole tree, and
+	// Limited when more than max_entries entries matched
+	Truncated bool `json:"truncated,omitempty"`
+	Limited   bool `json:"limited,omitempty"`
+}
+
+// handleGetRepositoryTree handles the get_repository_tree tool
+func (s *Server) handleGetRepositoryTree(ctx context.Context, args map[string]interface{}) (*protocol.CallToolResult, error) {
+	owner, repo, err := requireRepo(args)
+	if err != nil {
+		return nil, err
+	}
+	maxEntries, err := optionalInt(args, "max_entries", defaultTreeMaxEntries)
+	if err != nil {
+		return nil, err
+	}
+	if maxEntries < 1 {
+		return nil, fmt.Errorf("max_entries must be positive")
+	}
+	dir := strings.Trim(optionalString(args, "path", ""), "/")
+	pattern := optionalString(args, "pattern", "")
+	entryType := optionalString(args, "type", "")
+	if entryType != "" && entryType != "blob" && entryType != "tree" {
+		return nil, fmt.Errorf("type must be blob or tree")
+	}
+
+	ref := optionalString(args, "ref", "")
+	if ref == "" {
+		repository, err := s.client.GetRepository(ctx, owner, repo)
+		if err != nil {
+			return errorResult("Failed to get repository: %v", err), nil
+		}
+		ref = repository.DefaultBranch
+	}
+
+	tree, err := s.client.GetFullTree(ctx, owner, repo, ref)
+	if err != nil {
+		return errorResult("Failed to get tree: %v", err), nil
+	}
+
+	result := &treeResult{Ref: ref, SHA: tree.SHA, Path: dir, Truncated: tree.Truncated, Entries: []*directoryEntry{}}
+	for _, entry := range tree.Entries {
+		relative := entry.Path
+		if dir != "" {
+			if !strings.HasPrefix(entry.Path, dir+"/") {
+				continue
+			}
+			relative = strings.TrimPrefix(entry.Path, dir+"/")
+		}
+		if entryType != "" && entry.Type != entryType {
+			continue
+		}
+		if pattern != "" && !github.MatchPath(pattern, relative) {
+			continue
+		}
+
+		result.Total++
+		if len(result.Entries) >= int(maxEntries) {
+			result.Limited = true
+			continue
+		}
+		sha := ""
+		if entry.SHA != nil {
+			sha = *entry.SHA
+		}
+		result.Entries = append(result.Entries, &directoryEntry{Path: entry.Path, Type: entry.Type, Size: entry.Size, SHA: sha})
+	}
+
+	return jsonResult(result)
+}
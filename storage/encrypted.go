@@ -0,0 +1,396 @@
+package storage
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"golang.org/x/crypto/pbkdf2"
+)
+
+const (
+	// Key derivation parameters, matching ssh.KeyEncryption
+	iterationCount = 100000
+	keyLength      = 32
+	saltLength     = 16
+
+	// saltFile holds the store's key derivation salt
+	saltFile = "salt"
+
+	// encryptedExt is the extension of encrypted token files
+	encryptedExt = ".enc"
+)
+
+// Token store backends
+const (
+	BackendFile      = "file"
+	BackendEncrypted = "encrypted"
+)
+
+// StoreConfig selects and configures a token store backend
+type StoreConfig struct {
+	// Backend is BackendFile (default) or BackendEncrypted
+	Backend string
+
+	// Passphrase derives the encryption key for the encrypted backend
+	Passphrase string
+
+	// KeyFile is a file whose contents derive the encryption key for the
+	// encrypted backend; it is created with a random key if missing
+	KeyFile string
+}
+
+// NewStore creates the token store selected by the config
+func NewStore(basePath string, config StoreConfig) (SecureTokenStore, error) {
+	switch config.Backend {
+	case "", BackendFile:
+		return NewFileSystemStore(basePath)
+	case BackendEncrypted:
+		if config.KeyFile != "" {
+			return NewEncryptedStoreFromKeyFile(basePath, config.KeyFile)
+		}
+		if config.Passphrase == "" {
+			return nil, fmt.Errorf("encrypted token store requires a passphrase or key file")
+		}
+		return NewEncryptedStore(basePath, config.Passphrase)
+	default:
+		return nil, fmt.Errorf("unknown token store backend: %s", config.Backend)
+	}
+}
+
+// EncryptedStore implements SecureTokenStore with tokens encrypted at rest
+// using AES-GCM. The key is derived from a passphrase with PBKDF2 and a salt
+// kept alongside the tokens.
+type EncryptedStore struct {
+	BasePath string
+	key      []byte
+	mu       sync.RWMutex
+}
+
+// NewEncryptedStore creates a new EncryptedStore, migrating any plaintext
+// tokens left by a FileSystemStore in the same directory
+func NewEncryptedStore(basePath, passphrase string) (*EncryptedStore, error) {
+	if passphrase == "" {
+		return nil, fmt.Errorf("passphrase must not be empty")
+	}
+
+	if err := os.MkdirAll(basePath, 0700); err != nil {
+		return nil, fmt.Errorf("failed to create token store directory: %w", err)
+	}
+
+	salt, err := loadOrCreateSalt(filepath.Join(basePath, saltFile))
+	if err != nil {
+		return nil, err
+	}
+
+	s := &EncryptedStore{
+		BasePath: basePath,
+		key:      pbkdf2.Key([]byte(passphrase), salt, iterationCount, keyLength, sha256.New),
+	}
+
+	if err := s.verifyKey(); err != nil {
+		return nil, err
+	}
+	if err := s.migratePlaintext(); err != nil {
+		return nil, err
+	}
+
+	return s, nil
+}
+
+// NewEncryptedStoreFromKeyFile creates a new EncryptedStore keyed by the
+// contents of a key file, generating a random key file if none exists
+func NewEncryptedStoreFromKeyFile(basePath, keyFile string) (*EncryptedStore, error) {
+	data, err := os.ReadFile(keyFile)
+	if os.IsNotExist(err) {
+		data, err = createKeyFile(keyFile)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read key file: %w", err)
+	}
+
+	passphrase := strings.TrimSpace(string(data))
+	if passphrase == "" {
+		return nil, fmt.Errorf("key file %s is empty", keyFile)
+	}
+
+	return NewEncryptedStore(basePath, passphrase)
+}
+
+// tokenPath returns the full path for an encrypted token file
+func (s *EncryptedStore) tokenPath(id string) string {
+	return filepath.Join(s.BasePath, id+encryptedExt)
+}
+
+// StoreToken encrypts a token and saves it to the filesystem
+func (s *EncryptedStore) StoreToken(token *Token) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	// Update timestamps
+	now := time.Now().UTC()
+	if token.CreatedAt.IsZero() {
+		token.CreatedAt = now
+	}
+	token.UpdatedAt = now
+
+	return s.writeToken(token)
+}
+
+// GetToken retrieves and decrypts a token from the filesystem
+func (s *EncryptedStore) GetToken(id string) (*Token, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	data, err := os.ReadFile(s.tokenPath(id))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, fmt.Errorf("token not found: %s", id)
+		}
+		return nil, fmt.Errorf("failed to read token file: %w", err)
+	}
+
+	return s.decryptToken(data)
+}
+
+// ListTokens returns all tokens from the filesystem
+func (s *EncryptedStore) ListTokens() ([]*Token, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	entries, err := os.ReadDir(s.BasePath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read token directory: %w", err)
+	}
+
+	var tokens []*Token
+	for _, entry := range entries {
+		if entry.IsDir() || filepath.Ext(entry.Name()) != encryptedExt {
+			continue
+		}
+
+		data, err := os.ReadFile(filepath.Join(s.BasePath, entry.Name()))
+		if err != nil {
+			return nil, fmt.Errorf("failed to read token file %s: %w", entry.Name(), err)
+		}
+
+		token, err := s.decryptToken(data)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load token from %s: %w", entry.Name(), err)
+		}
+
+		tokens = append(tokens, token)
+	}
+
+	return tokens, nil
+}
+
+// DeleteToken removes a token from the filesystem
+func (s *EncryptedStore) DeleteToken(id string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if err := os.Remove(s.tokenPath(id)); err != nil {
+		if os.IsNotExist(err) {
+			return fmt.Errorf("token not found: %s", id)
+		}
+		return fmt.Errorf("failed to delete token file: %w", err)
+	}
+
+	return nil
+}
+
+// writeToken encrypts a token and writes it to its file
+func (s *EncryptedStore) writeToken(token *Token) error {
+	data, err := json.Marshal(token)
+	if err != nil {
+		return fmt.Errorf("failed to marshal token: %w", err)
+	}
+
+	encrypted, err := s.encrypt(data)
+	if err != nil {
+		return err
+	}
+
+	if err := os.WriteFile(s.tokenPath(token.ID), encrypted, 0600); err != nil {
+		return fmt.Errorf("failed to write token file: %w", err)
+	}
+
+	return nil
+}
+
+// decryptToken decrypts and unmarshals a token file
+func (s *EncryptedStore) decryptToken(data []byte) (*Token, error) {
+	plaintext, err := s.decrypt(data)
+	if err != nil {
+		return nil, err
+	}
+
+	var token Token
+	if err := json.Unmarshal(plaintext, &token); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal token: %w", err)
+	}
+
+	return &token, nil
+}
+
+// verifyKey checks the derived key against an existing token, so a wrong
+// passphrase fails at startup rather than on first use
+func (s *EncryptedStore) verifyKey() error {
+	entries, err := os.ReadDir(s.BasePath)
+	if err != nil {
+		return fmt.Errorf("failed to read token directory: %w", err)
+	}
+
+	for _, entry := range entries {
+		if entry.IsDir() || filepath.Ext(entry.Name()) != encryptedExt {
+			continue
+		}
+
+		data, err := os.ReadFile(filepath.Join(s.BasePath, entry.Name()))
+		if err != nil {
+			return fmt.Errorf("failed to read token file %s: %w", entry.Name(), err)
+		}
+		if _, err := s.decrypt(data); err != nil {
+			return fmt.Errorf("failed to unlock token store (wrong passphrase or key file?): %w", err)
+		}
+		return nil
+	}
+
+	return nil
+}
+
+// migratePlaintext encrypts tokens written by a FileSystemStore and removes
+// the plaintext files
+func (s *EncryptedStore) migratePlaintext() error {
+	plain := &FileSystemStore{BasePath: s.BasePath}
+	tokens, err := plain.ListTokens()
+	if err != nil {
+		return fmt.Errorf("failed to read plaintext tokens: %w", err)
+	}
+
+	for _, token := range tokens {
+		if err := s.writeToken(token); err != nil {
+			return fmt.Errorf("failed to migrate token %s: %w", token.ID, err)
+		}
+		if err := plain.DeleteToken(token.ID); err != nil {
+			return fmt.Errorf("failed to remove plaintext token %s: %w", token.ID, err)
+		}
+	}
+
+	return nil
+}
+
+// encrypt encrypts data using AES-GCM
+func (s *EncryptedStore) encrypt(data []byte) ([]byte, error) {
+	gcm, err := s.gcm()
+	if err != nil {
+		return nil, err
+	}
+
+	// Generate nonce
+	nonce := make([]byte, gcm.NonceSize())
+	if _, err := io.ReadFull(rand.Reader, nonce); err != nil {
+		return nil, fmt.Errorf("failed to generate nonce: %w", err)
+	}
+
+	// Encrypt and seal, then encode to base64
+	ciphertext := gcm.Seal(nonce, nonce, data, nil)
+	return []byte(base64.StdEncoding.EncodeToString(ciphertext)), nil
+}
+
+// decrypt decrypts data using AES-GCM
+func (s *EncryptedStore) decrypt(data []byte) ([]byte, error) {
+	decoded, err := base64.StdEncoding.DecodeString(string(data))
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode base64: %w", err)
+	}
+
+	gcm, err := s.gcm()
+	if err != nil {
+		return nil, err
+	}
+
+	// Extract nonce
+	nonceSize := gcm.NonceSize()
+	if len(decoded) < nonceSize {
+		return nil, fmt.Errorf("ciphertext too short")
+	}
+	nonce, ciphertext := decoded[:nonceSize], decoded[nonceSize:]
+
+	// Decrypt and verify
+	plaintext, err := gcm.Open(nil, nonce, ciphertext, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decrypt: %w", err)
+	}
+
+	return plaintext, nil
+}
+
+// gcm creates the AES-GCM cipher for the store key
+func (s *EncryptedStore) gcm() (cipher.AEAD, error) {
+	block, err := aes.NewCipher(s.key)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create cipher block: %w", err)
+	}
+
+	gcm, err := cipher.NewGCM(block)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create GCM: %w", err)
+	}
+
+	return gcm, nil
+}
+
+// loadOrCreateSalt reads the store's salt, generating it on first use
+func loadOrCreateSalt(path string) ([]byte, error) {
+	salt, err := os.ReadFile(path)
+	if err == nil {
+		if len(salt) != saltLength {
+			return nil, fmt.Errorf("invalid salt file %s", path)
+		}
+		return salt, nil
+	}
+	if !os.IsNotExist(err) {
+		return nil, fmt.Errorf("failed to read salt: %w", err)
+	}
+
+	salt = make([]byte, saltLength)
+	if _, err := io.ReadFull(rand.Reader, salt); err != nil {
+		return nil, fmt.Errorf("failed to generate salt: %w", err)
+	}
+	if err := os.WriteFile(path, salt, 0600); err != nil {
+		return nil, fmt.Errorf("failed to write salt: %w", err)
+	}
+
+	return salt, nil
+}
+
+// createKeyFile writes a random key to a new key file
+func createKeyFile(path string) ([]byte, error) {
+	key := make([]byte, keyLength)
+	if _, err := io.ReadFull(rand.Reader, key); err != nil {
+		return nil, fmt.Errorf("failed to generate key: %w", err)
+	}
+
+	if err := os.MkdirAll(filepath.Dir(path), 0700); err != nil {
+		return nil, fmt.Errorf("failed to create key file directory: %w", err)
+	}
+
+	encoded := []byte(base64.StdEncoding.EncodeToString(key))
+	if err := os.WriteFile(path, encoded, 0600); err != nil {
+		return nil, fmt.Errorf("failed to write key file: %w", err)
+	}
+
+	return encoded, nil
+}
@@ -0,0 +1,105 @@
+package storage
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestEncryptedStore_StoreAndGetToken(t *testing.T) {
+	tmpDir := t.TempDir()
+
+	store, err := NewEncryptedStore(tmpDir, "correct horse battery staple")
+	if err != nil {
+		t.Fatalf("NewEncryptedStore failed: %v", err)
+	}
+
+	token := &Token{ID: "default", AccessToken: "ghp_secret", RefreshToken: "ghr_secret", TokenType: "bearer"}
+	if err := store.StoreToken(token); err != nil {
+		t.Fatalf("StoreToken failed: %v", err)
+	}
+
+	// Secrets must not appear in the file on disk
+	data, err := os.ReadFile(filepath.Join(tmpDir, "default.enc"))
+	if err != nil {
+		t.Fatalf("Failed to read token file: %v", err)
+	}
+	if strings.Contains(string(data), "ghp_secret") || strings.Contains(string(data), "ghr_secret") {
+		t.Error("Token file contains plaintext secrets")
+	}
+
+	// A store reopened with the same passphrase reads the token back
+	reopened, err := NewEncryptedStore(tmpDir, "correct horse battery staple")
+	if err != nil {
+		t.Fatalf("Failed to reopen store: %v", err)
+	}
+	retrieved, err := reopened.GetToken("default")
+	if err != nil {
+		t.Fatalf("GetToken failed: %v", err)
+	}
+	if retrieved.AccessToken != "ghp_secret" || retrieved.RefreshToken != "ghr_secret" {
+		t.Errorf("Unexpected token: %+v", retrieved)
+	}
+
+	// The wrong passphrase is rejected up front
+	if _, err := NewEncryptedStore(tmpDir, "wrong"); err == nil {
+		t.Error("Expected error opening store with the wrong passphrase")
+	}
+
+	if err := reopened.DeleteToken("default"); err != nil {
+		t.Fatalf("DeleteToken failed: %v", err)
+	}
+	if _, err := reopened.GetToken("default"); err == nil || !strings.HasPrefix(err.Error(), "token not found: ") {
+		t.Errorf("Expected token not found error, got %v", err)
+	}
+}
+
+func TestEncryptedStore_MigratesPlaintext(t *testing.T) {
+	tmpDir := t.TempDir()
+
+	plain, err := NewFileSystemStore(tmpDir)
+	if err != nil {
+		t.Fatalf("NewFileSystemStore failed: %v", err)
+	}
+	for _, id := range []string{"personal", "work"} {
+		if err := plain.StoreToken(&Token{ID: id, AccessToken: "ghp_" + id}); err != nil {
+			t.Fatalf("StoreToken failed: %v", err)
+		}
+	}
+
+	store, err := NewEncryptedStoreFromKeyFile(tmpDir, filepath.Join(t.TempDir(), "token.key"))
+	if err != nil {
+		t.Fatalf("NewEncryptedStoreFromKeyFile failed: %v", err)
+	}
+
+	tokens, err := store.ListTokens()
+	if err != nil {
+		t.Fatalf("ListTokens failed: %v", err)
+	}
+	if len(tokens) != 2 {
+		t.Fatalf("Expected 2 migrated tokens, got %d", len(tokens))
+	}
+
+	matches, _ := filepath.Glob(filepath.Join(tmpDir, "*.json"))
+	if len(matches) != 0 {
+		t.Errorf("Expected plaintext token files to be removed, found %v", matches)
+	}
+}
+
+func TestNewStore(t *testing.T) {
+	if _, err := NewStore(t.TempDir(), StoreConfig{Backend: BackendEncrypted}); err == nil {
+		t.Error("Expected error for encrypted store without a key source")
+	}
+	if _, err := NewStore(t.TempDir(), StoreConfig{Backend: "keychain"}); err == nil {
+		t.Error("Expected error for unknown backend")
+	}
+
+	store, err := NewStore(t.TempDir(), StoreConfig{})
+	if err != nil {
+		t.Fatalf("NewStore failed: %v", err)
+	}
+	if _, ok := store.(*FileSystemStore); !ok {
+		t.Errorf("Expected file store by default, got %T", store)
+	}
+}
@@ -12,6 +12,8 @@ import (
 // Token represents an authentication token with metadata
 type Token struct {
 	ID           string    `json:"id"`
+	Login        string    `json:"login,omitempty"`
+	Host         string    `json:"host,omitempty"`
 	AccessToken  string    `json:"access_token"`
 	TokenType    string    `json:"token_type"`
 	RefreshToken string    `json:"refresh_token,omitempty"`
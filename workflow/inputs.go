@@ -0,0 +1,217 @@
+// Package workflow parses GitHub Actions workflow files.
+package workflow
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Input types of workflow_dispatch inputs
+const (
+	InputString      = "string"
+	InputBoolean     = "boolean"
+	InputNumber      = "number"
+	InputChoice      = "choice"
+	InputEnvironment = "environment"
+)
+
+// Input is a workflow_dispatch input
+type Input struct {
+	Name        string   `json:"name"`
+	Description string   `json:"description,omitempty"`
+	Type        string   `json:"type"`
+	Required    bool     `json:"required"`
+	Default     string   `json:"default,omitempty"`
+	Options     []string `json:"options,omitempty"`
+}
+
+// inputSpec is an input as written in the workflow file
+type inputSpec struct {
+	Description string    `yaml:"description"`
+	Type        string    `yaml:"type"`
+	Required    bool      `yaml:"required"`
+	Default     yaml.Node `yaml:"default"`
+	Options     []string  `yaml:"options"`
+}
+
+// DispatchInputs parses the on.workflow_dispatch.inputs block of a workflow
+// file, keeping the order of the file. It reports whether the workflow can
+// be dispatched at all.
+func DispatchInputs(data []byte) ([]*Input, bool, error) {
+	var doc yaml.Node
+	if err := yaml.Unmarshal(data, &doc); err != nil {
+		return nil, false, fmt.Errorf("failed to parse workflow: %w", err)
+	}
+	if len(doc.Content) == 0 {
+		return nil, false, fmt.Errorf("workflow file is empty")
+	}
+
+	on := mappingValue(doc.Content[0], "on")
+	if on == nil {
+		return nil, false, nil
+	}
+
+	// on: workflow_dispatch, on: [push, workflow_dispatch] or a mapping
+	switch on.Kind {
+	case yaml.ScalarNode:
+		return nil, on.Value == "workflow_dispatch", nil
+	case yaml.SequenceNode:
+		for _, event := range on.Content {
+			if event.Value == "workflow_dispatch" {
+				return nil, true, nil
+			}
+		}
+		return nil, false, nil
+	case yaml.MappingNode:
+	default:
+		return nil, false, fmt.Errorf("line %d: unexpected value for on", on.Line)
+	}
+
+	dispatch := mappingValue(on, "workflow_dispatch")
+	if dispatch == nil {
+		return nil, hasKey(on, "workflow_dispatch"), nil
+	}
+	inputsNode := mappingValue(dispatch, "inputs")
+	if inputsNode == nil {
+		return nil, true, nil
+	}
+	if inputsNode.Kind != yaml.MappingNode {
+		return nil, true, fmt.Errorf("line %d: workflow_dispatch inputs must be a mapping", inputsNode.Line)
+	}
+
+	var inputs []*Input
+	for i := 0; i+1 < len(inputsNode.Content); i += 2 {
+		name := inputsNode.Content[i].Value
+
+		var spec inputSpec
+		if err := inputsNode.Content[i+1].Decode(&spec); err != nil {
+			return nil, true, fmt.Errorf("input %s: %w", name, err)
+		}
+
+		input := &Input{
+			Name:        name,
+			Description: spec.Description,
+			Type:        spec.Type,
+			Required:    spec.Required,
+			Default:     spec.Default.Value,
+			Options:     spec.Options,
+		}
+		if input.Type == "" {
+			input.Type = InputString
+		}
+		inputs = append(inputs, input)
+	}
+
+	return inputs, true, nil
+}
+
+// ValidateInputs checks dispatch values against the declared inputs and
+// returns them as the strings the dispatch API expects. Missing optional
+// inputs are left out so their defaults apply.
+func ValidateInputs(inputs []*Input, values map[string]interface{}) (map[string]interface{}, error) {
+	declared := make(map[string]*Input)
+	for _, input := range inputs {
+		declared[input.Name] = input
+	}
+
+	var unknown []string
+	for name := range values {
+		if declared[name] == nil {
+			unknown = append(unknown, name)
+		}
+	}
+	if len(unknown) > 0 {
+		sort.Strings(unknown)
+		return nil, fmt.Errorf("unknown inputs: %s", strings.Join(unknown, ", "))
+	}
+
+	result := make(map[string]interface{})
+	for _, input := range inputs {
+		value, ok := values[input.Name]
+		if !ok || value == nil {
+			if input.Required && input.Default == "" {
+				return nil, fmt.Errorf("input %s is required", input.Name)
+			}
+			continue
+		}
+
+		str, err := inputValue(input, value)
+		if err != nil {
+			return nil, err
+		}
+		result[input.Name] = str
+	}
+
+	return result, nil
+}
+
+// inputValue converts and checks a single input value
+func inputValue(input *Input, value interface{}) (string, error) {
+	var str string
+	switch v := value.(type) {
+	case string:
+		str = v
+	case bool:
+		str = strconv.FormatBool(v)
+	case float64:
+		str = strconv.FormatFloat(v, 'f', -1, 64)
+	case int:
+		str = strconv.Itoa(v)
+	default:
+		return "", fmt.Errorf("input %s must be a string, number or boolean", input.Name)
+	}
+
+	switch input.Type {
+	case InputBoolean:
+		if str != "true" && str != "false" {
+			return "", fmt.Errorf("input %s must be true or false", input.Name)
+		}
+	case InputNumber:
+		if _, err := strconv.ParseFloat(str, 64); err != nil {
+			return "", fmt.Errorf("input %s must be a number", input.Name)
+		}
+	case InputChoice:
+		for _, option := range input.Options {
+			if str == option {
+				return str, nil
+			}
+		}
+		return "", fmt.Errorf("input %s must be one of %s", input.Name, strings.Join(input.Options, ", "))
+	}
+
+	if input.Required && str == "" {
+		return "", fmt.Errorf("input %s is required", input.Name)
+	}
+	return str, nil
+}
+
+// mappingValue returns the value of key in a mapping node, or nil
+func mappingValue(node *yaml.Node, key string) *yaml.Node {
+	if node.Kind != yaml.MappingNode {
+		return nil
+	}
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		if node.Content[i].Value == key {
+			value := node.Content[i+1]
+			if value.Tag == "!!null" {
+				return nil
+			}
+			return value
+		}
+	}
+	return nil
+}
+
+// hasKey reports whether a mapping node has key, even with a null value
+func hasKey(node *yaml.Node, key string) bool {
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		if node.Content[i].Value == key {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,125 @@
+package workflow
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+const deployWorkflow = `name: Deploy
+on:
+  push:
+    branches: [main]
+  workflow_dispatch:
+    inputs:
+      environment:
+        description: Target environment
+        type: choice
+        required: true
+        default: staging
+        options: [staging, production]
+      dry_run:
+        type: boolean
+        default: false
+      replicas:
+        type: number
+        required: true
+      message:
+        description: Release note
+jobs:
+  deploy:
+    runs-on: ubuntu-latest
+    steps:
+      - run: echo deploying
+`
+
+func TestDispatchInputs(t *testing.T) {
+	inputs, dispatchable, err := DispatchInputs([]byte(deployWorkflow))
+	if err != nil {
+		t.Fatalf("DispatchInputs failed: %v", err)
+	}
+	if !dispatchable || len(inputs) != 4 {
+		t.Fatalf("Expected 4 dispatch inputs, got %d (dispatchable %v)", len(inputs), dispatchable)
+	}
+
+	want := &Input{
+		Name:        "environment",
+		Description: "Target environment",
+		Type:        InputChoice,
+		Required:    true,
+		Default:     "staging",
+		Options:     []string{"staging", "production"},
+	}
+	if !reflect.DeepEqual(inputs[0], want) {
+		t.Errorf("Unexpected first input: %+v", inputs[0])
+	}
+	if inputs[1].Name != "dry_run" || inputs[1].Default != "false" || inputs[3].Type != InputString {
+		t.Errorf("Unexpected inputs: %+v %+v", inputs[1], inputs[3])
+	}
+}
+
+func TestDispatchInputs_Triggers(t *testing.T) {
+	tests := []struct {
+		name         string
+		workflow     string
+		dispatchable bool
+	}{
+		{name: "scalar", workflow: "on: workflow_dispatch\n", dispatchable: true},
+		{name: "sequence", workflow: "on: [push, workflow_dispatch]\n", dispatchable: true},
+		{name: "null mapping value", workflow: "on:\n  workflow_dispatch:\n  push:\n", dispatchable: true},
+		{name: "not dispatchable", workflow: "on:\n  push:\n    branches: [main]\n", dispatchable: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			inputs, dispatchable, err := DispatchInputs([]byte(tt.workflow))
+			if err != nil {
+				t.Fatalf("DispatchInputs failed: %v", err)
+			}
+			if dispatchable != tt.dispatchable || len(inputs) != 0 {
+				t.Errorf("Expected dispatchable %v and no inputs, got %v and %d", tt.dispatchable, dispatchable, len(inputs))
+			}
+		})
+	}
+}
+
+func TestValidateInputs(t *testing.T) {
+	inputs, _, err := DispatchInputs([]byte(deployWorkflow))
+	if err != nil {
+		t.Fatalf("DispatchInputs failed: %v", err)
+	}
+
+	values, err := ValidateInputs(inputs, map[string]interface{}{
+		"environment": "production",
+		"dry_run":     true,
+		"replicas":    float64(3),
+	})
+	if err != nil {
+		t.Fatalf("ValidateInputs failed: %v", err)
+	}
+	want := map[string]interface{}{"environment": "production", "dry_run": "true", "replicas": "3"}
+	if !reflect.DeepEqual(values, want) {
+		t.Errorf("Expected %v, got %v", want, values)
+	}
+
+	tests := []struct {
+		name    string
+		values  map[string]interface{}
+		wantErr string
+	}{
+		{name: "unknown input", values: map[string]interface{}{"replicas": 1, "region": "eu"}, wantErr: "unknown inputs: region"},
+		{name: "missing required", values: map[string]interface{}{}, wantErr: "replicas is required"},
+		{name: "invalid choice", values: map[string]interface{}{"replicas": 1, "environment": "qa"}, wantErr: "one of staging, production"},
+		{name: "invalid boolean", values: map[string]interface{}{"replicas": 1, "dry_run": "yes"}, wantErr: "true or false"},
+		{name: "invalid number", values: map[string]interface{}{"replicas": "many"}, wantErr: "must be a number"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := ValidateInputs(inputs, tt.values)
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("Expected error containing %q, got %v", tt.wantErr, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,601 @@
+package workflow
+
+import (
+	"fmt"
+	"path"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Issue severities
+const (
+	SeverityError   = "error"
+	SeverityWarning = "warning"
+)
+
+// Issue is a problem found in a workflow file
+type Issue struct {
+	Line     int    `json:"line"`
+	Column   int    `json:"column"`
+	Severity string `json:"severity"`
+	Message  string `json:"message"`
+}
+
+// String formats the issue as "line:column: severity: message"
+func (i Issue) String() string {
+	return fmt.Sprintf("%d:%d: %s: %s", i.Line, i.Column, i.Severity, i.Message)
+}
+
+// IsWorkflowPath reports whether a repository path is a workflow file
+func IsWorkflowPath(p string) bool {
+	ext := path.Ext(p)
+	return path.Dir(p) == ".github/workflows" && (ext == ".yml" || ext == ".yaml")
+}
+
+// HasErrors reports whether any issue is an error
+func HasErrors(issues []Issue) bool {
+	for _, issue := range issues {
+		if issue.Severity == SeverityError {
+			return true
+		}
+	}
+	return false
+}
+
+var (
+	workflowKeys = keySet("name", "run-name", "on", "permissions", "env", "defaults", "concurrency", "jobs")
+
+	jobKeys = keySet("name", "needs", "permissions", "runs-on", "environment", "concurrency", "outputs", "env",
+		"defaults", "if", "steps", "timeout-minutes", "strategy", "continue-on-error", "container", "services",
+		"uses", "with", "secrets")
+
+	// reusableJobKeys are the keys allowed on a job that calls a reusable workflow
+	reusableJobKeys = keySet("name", "needs", "permissions", "if", "uses", "with", "secrets", "strategy", "concurrency")
+
+	stepKeys = keySet("id", "if", "name", "uses", "run", "shell", "with", "env", "continue-on-error",
+		"timeout-minutes", "working-directory")
+
+	events = keySet("branch_protection_rule", "check_run", "check_suite", "create", "delete", "deployment",
+		"deployment_status", "discussion", "discussion_comment", "fork", "gollum", "issue_comment", "issues",
+		"label", "merge_group", "milestone", "page_build", "public", "pull_request", "pull_request_review",
+		"pull_request_review_comment", "pull_request_target", "push", "registry_package", "release",
+		"repository_dispatch", "schedule", "status", "watch", "workflow_call", "workflow_dispatch", "workflow_run")
+
+	inputTypes = keySet(InputString, InputBoolean, InputNumber, InputChoice, InputEnvironment)
+
+	contexts = keySet("github", "env", "vars", "job", "jobs", "steps", "runner", "secrets", "strategy",
+		"matrix", "needs", "inputs")
+
+	functions = keySet("contains", "startswith", "endswith", "format", "join", "tojson", "fromjson",
+		"hashfiles", "success", "always", "cancelled", "failure", "case")
+
+	identifierPattern = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_-]*$`)
+	yamlErrorLine     = regexp.MustCompile(`line (\d+)`)
+
+	// actionPattern matches owner/repo[/path]@ref
+	actionPattern = regexp.MustCompile(`^[A-Za-z0-9_.-]+/[A-Za-z0-9_.-]+(/[^@\s]+)?@[^@\s]+$`)
+
+	// reusablePattern matches owner/repo/.github/workflows/file.yml@ref
+	reusablePattern = regexp.MustCompile(`^[A-Za-z0-9_.-]+/[A-Za-z0-9_.-]+/\.github/workflows/[^@/\s]+\.ya?ml@[^@\s]+$`)
+)
+
+// keySet builds a set of strings
+func keySet(keys ...string) map[string]bool {
+	set := make(map[string]bool, len(keys))
+	for _, key := range keys {
+		set[key] = true
+	}
+	return set
+}
+
+// linter collects issues while walking a workflow
+type linter struct {
+	issues []Issue
+}
+
+// errorf records an error at a node
+func (l *linter) errorf(node *yaml.Node, format string, args ...interface{}) {
+	l.issues = append(l.issues, Issue{Line: node.Line, Column: node.Column, Severity: SeverityError, Message: fmt.Sprintf(format, args...)})
+}
+
+// warnf records a warning at a node
+func (l *linter) warnf(node *yaml.Node, format string, args ...interface{}) {
+	l.issues = append(l.issues, Issue{Line: node.Line, Column: node.Column, Severity: SeverityWarning, Message: fmt.Sprintf(format, args...)})
+}
+
+// Lint validates the structure of a workflow file without network access.
+// It checks the triggers, the job and step schema, the needs graph, the
+// syntax and context names of ${{ }} expressions and the shape of uses
+// references. Issues are sorted by position.
+func Lint(data []byte) []Issue {
+	var doc yaml.Node
+	if err := yaml.Unmarshal(data, &doc); err != nil {
+		line := 0
+		if match := yamlErrorLine.FindStringSubmatch(err.Error()); match != nil {
+			line, _ = strconv.Atoi(match[1])
+		}
+		return []Issue{{Line: line, Severity: SeverityError, Message: strings.TrimPrefix(err.Error(), "yaml: ")}}
+	}
+	if len(doc.Content) == 0 {
+		return []Issue{{Line: 1, Column: 1, Severity: SeverityError, Message: "workflow file is empty"}}
+	}
+
+	l := &linter{}
+	root := doc.Content[0]
+	if root.Kind != yaml.MappingNode {
+		l.errorf(root, "workflow must be a mapping")
+		return l.issues
+	}
+
+	l.checkKeys(root, workflowKeys, "workflow")
+	if on := l.required(root, "on", "workflow"); on != nil {
+		l.checkTriggers(on)
+	}
+	if jobs := l.required(root, "jobs", "workflow"); jobs != nil {
+		l.checkJobs(jobs)
+	}
+	l.checkExpressions(root)
+
+	sort.SliceStable(l.issues, func(i, j int) bool {
+		if l.issues[i].Line != l.issues[j].Line {
+			return l.issues[i].Line < l.issues[j].Line
+		}
+		return l.issues[i].Column < l.issues[j].Column
+	})
+	return l.issues
+}
+
+// checkKeys reports keys of a mapping that are not in allowed
+func (l *linter) checkKeys(node *yaml.Node, allowed map[string]bool, what string) {
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		key := node.Content[i]
+		if !allowed[key.Value] {
+			l.errorf(key, "unknown key %q in %s", key.Value, what)
+		}
+	}
+}
+
+// required returns the value of a required key, reporting it if missing
+func (l *linter) required(node *yaml.Node, key, what string) *yaml.Node {
+	if value := mappingValue(node, key); value != nil {
+		return value
+	}
+	l.errorf(node, "%s is missing required key %q", what, key)
+	return nil
+}
+
+// checkTriggers validates the on: section
+func (l *linter) checkTriggers(on *yaml.Node) {
+	switch on.Kind {
+	case yaml.ScalarNode:
+		l.checkEvent(on)
+	case yaml.SequenceNode:
+		for _, event := range on.Content {
+			l.checkEvent(event)
+		}
+	case yaml.MappingNode:
+		for i := 0; i+1 < len(on.Content); i += 2 {
+			key, value := on.Content[i], on.Content[i+1]
+			if !l.checkEvent(key) {
+				continue
+			}
+			switch key.Value {
+			case "schedule":
+				l.checkSchedule(value)
+			case "workflow_dispatch", "workflow_call":
+				if inputs := mappingValue(value, "inputs"); inputs != nil {
+					l.checkInputs(inputs, key.Value)
+				}
+			}
+		}
+	default:
+		l.errorf(on, "on must be an event name, a list of events or a mapping")
+	}
+}
+
+// checkEvent reports unknown event names
+func (l *linter) checkEvent(node *yaml.Node) bool {
+	if node.Kind != yaml.ScalarNode || !events[node.Value] {
+		l.errorf(node, "unknown event %q", node.Value)
+		return false
+	}
+	return true
+}
+
+// checkSchedule validates schedule: - cron: entries
+func (l *linter) checkSchedule(node *yaml.Node) {
+	if node.Kind != yaml.SequenceNode {
+		l.errorf(node, "schedule must be a list of cron entries")
+		return
+	}
+	for _, entry := range node.Content {
+		cron := mappingValue(entry, "cron")
+		if cron == nil {
+			l.errorf(entry, "schedule entry is missing cron")
+			continue
+		}
+		if fields := strings.Fields(cron.Value); len(fields) != 5 {
+			l.errorf(cron, "cron %q must have 5 fields, has %d", cron.Value, len(fields))
+		}
+	}
+}
+
+// checkInputs validates workflow_dispatch and workflow_call inputs
+func (l *linter) checkInputs(inputs *yaml.Node, event string) {
+	if inputs.Kind != yaml.MappingNode {
+		l.errorf(inputs, "%s inputs must be a mapping", event)
+		return
+	}
+	for i := 0; i+1 < len(inputs.Content); i += 2 {
+		name, spec := inputs.Content[i], inputs.Content[i+1]
+		if !identifierPattern.MatchString(name.Value) {
+			l.errorf(name, "invalid input name %q", name.Value)
+		}
+		if spec.Kind != yaml.MappingNode {
+			continue
+		}
+
+		inputType := InputString
+		if typeNode := mappingValue(spec, "type"); typeNode != nil {
+			inputType = typeNode.Value
+			if !inputTypes[inputType] || (event == "workflow_call" && (inputType == InputChoice || inputType == InputEnvironment)) {
+				l.errorf(typeNode, "invalid type %q for %s input %s", inputType, event, name.Value)
+			}
+		} else if event == "workflow_call" {
+			l.errorf(spec, "workflow_call input %s is missing type", name.Value)
+		}
+
+		if inputType == InputChoice {
+			options := mappingValue(spec, "options")
+			if options == nil || options.Kind != yaml.SequenceNode || len(options.Content) == 0 {
+				l.errorf(spec, "choice input %s needs a list of options", name.Value)
+				continue
+			}
+			if def := mappingValue(spec, "default"); def != nil && !containsValue(options, def.Value) {
+				l.errorf(def, "default %q of input %s is not one of its options", def.Value, name.Value)
+			}
+		}
+	}
+}
+
+// checkJobs validates jobs, their steps and the needs graph
+func (l *linter) checkJobs(jobs *yaml.Node) {
+	if jobs.Kind != yaml.MappingNode || len(jobs.Content) == 0 {
+		l.errorf(jobs, "jobs must be a mapping with at least one job")
+		return
+	}
+
+	ids := make(map[string]bool)
+	for i := 0; i+1 < len(jobs.Content); i += 2 {
+		ids[jobs.Content[i].Value] = true
+	}
+
+	needs := make(map[string][]string)
+	for i := 0; i+1 < len(jobs.Content); i += 2 {
+		key, job := jobs.Content[i], jobs.Content[i+1]
+		if !identifierPattern.MatchString(key.Value) {
+			l.errorf(key, "invalid job ID %q: must start with a letter or _ and contain only letters, digits, - and _", key.Value)
+		}
+		if job.Kind != yaml.MappingNode {
+			l.errorf(job, "job %s must be a mapping", key.Value)
+			continue
+		}
+
+		needs[key.Value] = l.checkNeeds(key.Value, job, ids)
+		l.checkJob(key.Value, job)
+	}
+
+	l.checkCycles(jobs, needs)
+}
+
+// checkJob validates a single job
+func (l *linter) checkJob(id string, job *yaml.Node) {
+	what := "job " + id
+	if uses := mappingValue(job, "uses"); uses != nil {
+		l.checkKeys(job, reusableJobKeys, what+" (which calls a reusable workflow)")
+		if !strings.HasPrefix(uses.Value, "./.github/workflows/") && !reusablePattern.MatchString(uses.Value) {
+			l.errorf(uses, "invalid reusable workflow %q: use ./.github/workflows/<file>.yml or owner/repo/.github/workflows/<file>.yml@ref", uses.Value)
+		}
+		return
+	}
+
+	l.checkKeys(job, jobKeys, what)
+	l.required(job, "runs-on", what)
+	steps := l.required(job, "steps", what)
+	if steps == nil {
+		return
+	}
+	if steps.Kind != yaml.SequenceNode || len(steps.Content) == 0 {
+		l.errorf(steps, "steps of job %s must be a non-empty list", id)
+		return
+	}
+
+	stepIDs := make(map[string]bool)
+	for n, step := range steps.Content {
+		if step.Kind != yaml.MappingNode {
+			l.errorf(step, "step %d of job %s must be a mapping", n+1, id)
+			continue
+		}
+		l.checkKeys(step, stepKeys, fmt.Sprintf("step %d of job %s", n+1, id))
+
+		if stepID := mappingValue(step, "id"); stepID != nil {
+			if !identifierPattern.MatchString(stepID.Value) {
+				l.errorf(stepID, "invalid step ID %q", stepID.Value)
+			}
+			if stepIDs[stepID.Value] {
+				l.errorf(stepID, "duplicate step ID %q in job %s", stepID.Value, id)
+			}
+			stepIDs[stepID.Value] = true
+		}
+
+		uses, run := mappingValue(step, "uses"), mappingValue(step, "run")
+		switch {
+		case uses != nil && run != nil:
+			l.errorf(step, "step %d of job %s has both uses and run", n+1, id)
+		case uses == nil && run == nil:
+			l.errorf(step, "step %d of job %s needs uses or run", n+1, id)
+		case uses != nil:
+			l.checkActionRef(uses)
+		case mappingValue(step, "with") != nil:
+			l.warnf(mappingValue(step, "with"), "with has no effect on a run step")
+		}
+	}
+}
+
+// checkActionRef validates the uses: of a step
+func (l *linter) checkActionRef(uses *yaml.Node) {
+	ref := uses.Value
+	switch {
+	case strings.HasPrefix(ref, "docker://"):
+		if len(ref) == len("docker://") {
+			l.errorf(uses, "docker action %q is missing an image", ref)
+		}
+	case strings.HasPrefix(ref, "./"):
+		if strings.Contains(ref, "@") {
+			l.errorf(uses, "local action %q must not have a ref", ref)
+		}
+	case strings.Contains(ref, "${{"):
+		l.errorf(uses, "uses cannot contain expressions")
+	case !strings.Contains(ref, "@"):
+		l.errorf(uses, "action %q is missing a ref, e.g. %s@v4", ref, ref)
+	case !actionPattern.MatchString(ref):
+		l.errorf(uses, "invalid action reference %q: use owner/repo@ref, owner/repo/path@ref, ./path or docker://image", ref)
+	}
+}
+
+// checkNeeds validates a job's needs and returns the jobs it depends on
+func (l *linter) checkNeeds(id string, job *yaml.Node, ids map[string]bool) []string {
+	node := mappingValue(job, "needs")
+	if node == nil {
+		return nil
+	}
+
+	var items []*yaml.Node
+	switch node.Kind {
+	case yaml.ScalarNode:
+		items = []*yaml.Node{node}
+	case yaml.SequenceNode:
+		items = node.Content
+	default:
+		l.errorf(node, "needs of job %s must be a job ID or a list of job IDs", id)
+		return nil
+	}
+
+	var needs []string
+	for _, item := range items {
+		switch {
+		case item.Value == id:
+			l.errorf(item, "job %s needs itself", id)
+		case !ids[item.Value]:
+			l.errorf(item, "job %s needs unknown job %q", id, item.Value)
+		default:
+			needs = append(needs, item.Value)
+		}
+	}
+	return needs
+}
+
+// checkCycles reports dependency cycles in the needs graph
+func (l *linter) checkCycles(jobs *yaml.Node, needs map[string][]string) {
+	const (
+		unvisited = iota
+		visiting
+		done
+	)
+	state := make(map[string]int)
+	reported := make(map[string]bool)
+
+	var visit func(id string, path []string)
+	visit = func(id string, path []string) {
+		state[id] = visiting
+		path = append(path, id)
+		for _, dep := range needs[id] {
+			switch state[dep] {
+			case visiting:
+				start := 0
+				for i, p := range path {
+					if p == dep {
+						start = i
+					}
+				}
+				cycle := append(append([]string{}, path[start:]...), dep)
+				if !reported[dep] {
+					reported[dep] = true
+					l.errorf(jobKey(jobs, dep), "needs cycle: %s", strings.Join(cycle, " -> "))
+				}
+			case unvisited:
+				visit(dep, path)
+			}
+		}
+		state[id] = done
+	}
+
+	for i := 0; i+1 < len(jobs.Content); i += 2 {
+		if id := jobs.Content[i].Value; state[id] == unvisited {
+			visit(id, nil)
+		}
+	}
+}
+
+// jobKey returns the key node of a job
+func jobKey(jobs *yaml.Node, id string) *yaml.Node {
+	for i := 0; i+1 < len(jobs.Content); i += 2 {
+		if jobs.Content[i].Value == id {
+			return jobs.Content[i]
+		}
+	}
+	return jobs
+}
+
+// checkExpressions validates every ${{ }} expression and every if:
+// condition in the document
+func (l *linter) checkExpressions(node *yaml.Node) {
+	switch node.Kind {
+	case yaml.MappingNode:
+		for i := 0; i+1 < len(node.Content); i += 2 {
+			key, value := node.Content[i], node.Content[i+1]
+			l.checkExpressions(key)
+			if key.Value == "if" && value.Kind == yaml.ScalarNode && !strings.Contains(value.Value, "${{") {
+				l.checkExpression(value, value.Value)
+				continue
+			}
+			l.checkExpressions(value)
+		}
+	case yaml.SequenceNode:
+		for _, item := range node.Content {
+			l.checkExpressions(item)
+		}
+	case yaml.ScalarNode:
+		text := node.Value
+		for {
+			start := strings.Index(text, "${{")
+			if start < 0 {
+				return
+			}
+			end := strings.Index(text[start:], "}}")
+			if end < 0 {
+				l.errorf(node, "unterminated expression: missing }}")
+				return
+			}
+			l.checkExpression(node, text[start+3:start+end])
+			text = text[start+end+2:]
+		}
+	}
+}
+
+// checkExpression validates the syntax and context names of an expression
+func (l *linter) checkExpression(node *yaml.Node, expr string) {
+	if strings.TrimSpace(expr) == "" {
+		l.errorf(node, "empty expression")
+		return
+	}
+
+	depth := 0
+	prevDot := false
+	for i := 0; i < len(expr); {
+		c := expr[i]
+		switch {
+		case c == ' ' || c == '\t' || c == '\n':
+			i++
+			continue
+		case c == '\'':
+			// String literal; '' escapes a quote
+			j := i + 1
+			for ; j < len(expr); j++ {
+				if expr[j] == '\'' {
+					if j+1 < len(expr) && expr[j+1] == '\'' {
+						j++
+						continue
+					}
+					break
+				}
+			}
+			if j >= len(expr) {
+				l.errorf(node, "unterminated string in expression %q", strings.TrimSpace(expr))
+				return
+			}
+			i = j + 1
+		case c == '"':
+			l.errorf(node, "strings in expressions use single quotes: %q", strings.TrimSpace(expr))
+			return
+		case c >= '0' && c <= '9' || (c == '-' && i+1 < len(expr) && expr[i+1] >= '0' && expr[i+1] <= '9'):
+			j := i + 1
+			for j < len(expr) && (isIdentChar(expr[j]) || expr[j] == '.') {
+				j++
+			}
+			i = j
+		case isIdentStart(c):
+			j := i
+			for j < len(expr) && isIdentChar(expr[j]) {
+				j++
+			}
+			word := expr[i:j]
+			next := j
+			for next < len(expr) && expr[next] == ' ' {
+				next++
+			}
+			switch {
+			case prevDot:
+				// Property name
+			case next < len(expr) && expr[next] == '(':
+				if !functions[strings.ToLower(word)] {
+					l.errorf(node, "unknown function %q in expression", word)
+				}
+			case word == "true" || word == "false" || word == "null" || word == "NaN" || word == "Infinity":
+			case !contexts[word]:
+				l.errorf(node, "unknown context %q in expression; expected one of github, env, vars, job, jobs, steps, runner, secrets, strategy, matrix, needs or inputs", word)
+			}
+			i = j
+			prevDot = false
+			continue
+		case c == '(' || c == '[':
+			depth++
+			i++
+		case c == ')' || c == ']':
+			depth--
+			if depth < 0 {
+				l.errorf(node, "unbalanced %q in expression %q", c, strings.TrimSpace(expr))
+				return
+			}
+			i++
+		case c == '.':
+			prevDot = true
+			i++
+			continue
+		case strings.ContainsRune("!=<>&|,*", rune(c)):
+			i++
+		default:
+			l.errorf(node, "unexpected character %q in expression %q", c, strings.TrimSpace(expr))
+			return
+		}
+		prevDot = false
+	}
+
+	if depth != 0 {
+		l.errorf(node, "unbalanced parentheses in expression %q", strings.TrimSpace(expr))
+	}
+}
+
+// isIdentStart reports whether c can start an identifier
+func isIdentStart(c byte) bool {
+	return c == '_' || (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z')
+}
+
+// isIdentChar reports whether c can continue an identifier
+func isIdentChar(c byte) bool {
+	return isIdentStart(c) || c == '-' || (c >= '0' && c <= '9')
+}
+
+// containsValue reports whether a sequence node contains a scalar value
+func containsValue(node *yaml.Node, value string) bool {
+	for _, item := range node.Content {
+		if item.Value == value {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,153 @@
+package workflow
+
+import (
+	"strings"
+	"testing"
+)
+
+const validWorkflow = `name: CI
+on:
+  push:
+    branches: [main]
+  pull_request:
+  schedule:
+    - cron: "0 4 * * 1"
+  workflow_dispatch:
+    inputs:
+      level:
+        type: choice
+        options: [debug, info]
+        default: info
+jobs:
+  build:
+    runs-on: ${{ matrix.os }}
+    strategy:
+      matrix:
+        os: [ubuntu-latest, macos-latest]
+    steps:
+      - uses: actions/checkout@v4
+      - id: test
+        run: go test ./... -v=${{ inputs.level == 'debug' }}
+      - uses: ./.github/actions/report
+        if: always() && steps.test.outcome != 'skipped'
+  deploy:
+    needs: build
+    if: github.ref == 'refs/heads/main' && needs.build.result == 'success'
+    uses: octo/shared/.github/workflows/deploy.yml@v1
+    secrets: inherit
+`
+
+func TestLint_Valid(t *testing.T) {
+	if issues := Lint([]byte(validWorkflow)); len(issues) != 0 {
+		t.Errorf("Expected no issues, got %v", issues)
+	}
+}
+
+func TestLint(t *testing.T) {
+	tests := []struct {
+		name     string
+		workflow string
+		want     string
+	}{
+		{
+			name:     "yaml syntax",
+			workflow: "on: push\njobs:\n  build: [\n",
+			want:     "error",
+		},
+		{
+			name:     "missing jobs",
+			workflow: "on: push\n",
+			want:     `workflow is missing required key "jobs"`,
+		},
+		{
+			name:     "unknown event",
+			workflow: "on: [push, pull-request]\njobs:\n  a:\n    runs-on: x\n    steps:\n      - run: echo\n",
+			want:     `1:12: error: unknown event "pull-request"`,
+		},
+		{
+			name:     "bad cron",
+			workflow: "on:\n  schedule:\n    - cron: '0 4 * *'\njobs:\n  a:\n    runs-on: x\n    steps:\n      - run: echo\n",
+			want:     "must have 5 fields",
+		},
+		{
+			name:     "unknown job key",
+			workflow: "on: push\njobs:\n  a:\n    runs_on: x\n    steps:\n      - run: echo\n",
+			want:     `unknown key "runs_on" in job a`,
+		},
+		{
+			name:     "step with uses and run",
+			workflow: "on: push\njobs:\n  a:\n    runs-on: x\n    steps:\n      - uses: actions/checkout@v4\n        run: echo\n",
+			want:     "has both uses and run",
+		},
+		{
+			name:     "action without ref",
+			workflow: "on: push\njobs:\n  a:\n    runs-on: x\n    steps:\n      - uses: actions/checkout\n",
+			want:     "missing a ref",
+		},
+		{
+			name:     "unknown needs",
+			workflow: "on: push\njobs:\n  a:\n    needs: [setup]\n    runs-on: x\n    steps:\n      - run: echo\n",
+			want:     `job a needs unknown job "setup"`,
+		},
+		{
+			name:     "needs cycle",
+			workflow: "on: push\njobs:\n  a:\n    needs: c\n    runs-on: x\n    steps:\n      - run: echo\n  b:\n    needs: a\n    runs-on: x\n    steps:\n      - run: echo\n  c:\n    needs: b\n    runs-on: x\n    steps:\n      - run: echo\n",
+			want:     "needs cycle: a -> c -> b -> a",
+		},
+		{
+			name:     "unknown context",
+			workflow: "on: push\njobs:\n  a:\n    runs-on: x\n    steps:\n      - run: echo ${{ secret.TOKEN }}\n",
+			want:     `unknown context "secret"`,
+		},
+		{
+			name:     "unterminated expression",
+			workflow: "on: push\njobs:\n  a:\n    runs-on: x\n    steps:\n      - run: echo ${{ github.sha\n",
+			want:     "missing }}",
+		},
+		{
+			name:     "double quoted string",
+			workflow: "on: push\njobs:\n  a:\n    if: github.ref == \"main\"\n    runs-on: x\n    steps:\n      - run: echo\n",
+			want:     "single quotes",
+		},
+		{
+			name:     "unknown function",
+			workflow: "on: push\njobs:\n  a:\n    if: startsWit(github.ref, 'refs/tags')\n    runs-on: x\n    steps:\n      - run: echo\n",
+			want:     `unknown function "startsWit"`,
+		},
+		{
+			name:     "choice default not an option",
+			workflow: "on:\n  workflow_dispatch:\n    inputs:\n      env:\n        type: choice\n        options: [dev]\n        default: prod\njobs:\n  a:\n    runs-on: x\n    steps:\n      - run: echo\n",
+			want:     "not one of its options",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			issues := Lint([]byte(tt.workflow))
+			var messages []string
+			for _, issue := range issues {
+				messages = append(messages, issue.String())
+			}
+			if !strings.Contains(strings.Join(messages, "\n"), tt.want) {
+				t.Errorf("Expected an issue containing %q, got %v", tt.want, messages)
+			}
+			if !HasErrors(issues) {
+				t.Error("Expected at least one error")
+			}
+		})
+	}
+}
+
+func TestIsWorkflowPath(t *testing.T) {
+	for p, want := range map[string]bool{
+		".github/workflows/ci.yml":        true,
+		".github/workflows/ci.yaml":       true,
+		".github/workflows/nested/ci.yml": false,
+		".github/dependabot.yml":          false,
+		"workflows/ci.yml":                false,
+	} {
+		if got := IsWorkflowPath(p); got != want {
+			t.Errorf("IsWorkflowPath(%q) = %v, want %v", p, got, want)
+		}
+	}
+}